@@ -0,0 +1,176 @@
+// Package agenda собирает план на день: ритуалы, дедлайны, начатые фокус-квесты
+// и предложение фокус-сессий, которые укладываются в текущую ману.
+package agenda
+
+import (
+	"sort"
+	"time"
+
+	"magus/deadline"
+	"magus/deps"
+	"magus/dungeon"
+	"magus/player"
+	"magus/snooze"
+	"magus/utils"
+)
+
+// MaxPlannedSessions ограничивает длину предложенного плана.
+const MaxPlannedSessions = 4
+
+// preferredSession — длительность сессии, которую план предлагает в первую очередь.
+const preferredSession = 25 * time.Minute
+
+// Session — одна предложенная фокус-сессия.
+type Session struct {
+	Quest    player.Quest
+	Duration time.Duration
+	ManaCost int
+}
+
+// Agenda — план на текущий день.
+type Agenda struct {
+	DayStart   time.Time
+	DayEnd     time.Time
+	Rituals    []player.Quest // Ритуалы, еще не выполненные сегодня
+	Due        []player.Quest // Квесты с дедлайном сегодня или просроченные
+	InProgress []player.Quest // Фокус-квесты, у которых 0 < Progress < HP
+	Plan       []Session      // Предложенные фокус-сессии
+	ManaLeft   int            // Мана, которая останется после плана
+}
+
+// Build собирает план на день, в который попадает now. Отложенные
+// квесты в план не попадают. manaCost считает стоимость сессии с учетом
+// навыков (см. effects.Sheet.ManaCost); nil — базовая dungeon.ManaCost.
+func Build(quests []player.Quest, p *player.Player, manaCost func(time.Duration) int, now time.Time, dayStartHour int) Agenda {
+	a := Agenda{
+		DayStart: utils.DayStart(now, dayStartHour),
+		DayEnd:   utils.DayEnd(now, dayStartHour),
+	}
+	// Отложенные квесты ждут своей даты начала
+	active := snooze.Active(quests, now)
+
+	for _, q := range active {
+		if q.Completed {
+			continue
+		}
+		switch {
+		case q.Type == player.TypeRitual:
+			// Ритуал с расписанием ждет своего дня, обычный — каждый день
+			if q.Recurrence != "" {
+				if IsDue(q, a.DayEnd) {
+					a.Rituals = append(a.Rituals, q)
+				}
+			} else if q.CompletedAt.Before(a.DayStart) {
+				a.Rituals = append(a.Rituals, q)
+			}
+			continue
+		case IsDue(q, a.DayEnd):
+			a.Due = append(a.Due, q)
+		case IsInProgress(q):
+			a.InProgress = append(a.InProgress, q)
+		}
+	}
+
+	sort.SliceStable(a.Due, func(i, j int) bool {
+		return deadline.Due(*a.Due[i].Deadline, a.Due[i].DeadlineTime).Before(deadline.Due(*a.Due[j].Deadline, a.Due[j].DeadlineTime))
+	})
+	sort.SliceStable(a.InProgress, func(i, j int) bool {
+		return progressRatio(a.InProgress[i]) > progressRatio(a.InProgress[j])
+	})
+
+	mana := 0
+	if p != nil {
+		mana = p.Mana
+	}
+	if manaCost == nil {
+		manaCost = dungeon.ManaCost
+	}
+	a.Plan, a.ManaLeft = plan(planCandidates(active, quests, a), mana, manaCost)
+	return a
+}
+
+// planCandidates упорядочивает фокус-квесты для плана: сначала горящие,
+// затем начатые, затем остальные открытые в порядке файла. Блокировки
+// проверяются по всем квестам, включая отложенные.
+func planCandidates(quests, all []player.Quest, a Agenda) []player.Quest {
+	candidates := append(focusOnly(a.Due), a.InProgress...)
+	seen := make(map[string]bool)
+	for _, q := range candidates {
+		seen[q.ID] = true
+	}
+	for _, q := range quests {
+		if q.Type == player.TypeFocus && !q.Completed && !seen[q.ID] {
+			candidates = append(candidates, q)
+		}
+	}
+
+	// Заблокированные квесты в план не попадают: их пока нельзя сделать
+	index := deps.Index(all)
+	ready := candidates[:0]
+	for _, q := range candidates {
+		if !deps.IsBlocked(q, index) {
+			ready = append(ready, q)
+		}
+	}
+	return ready
+}
+
+// IsDue сообщает, что дедлайн квеста наступает до конца текущего дня.
+func IsDue(q player.Quest, dayEnd time.Time) bool {
+	return q.Deadline != nil && !deadline.Due(*q.Deadline, q.DeadlineTime).After(dayEnd)
+}
+
+// IsOverdue сообщает, что дедлайн квеста уже прошел.
+func IsOverdue(q player.Quest, now time.Time) bool {
+	return q.Deadline != nil && deadline.Overdue(*q.Deadline, q.DeadlineTime, now)
+}
+
+// IsInProgress сообщает, что фокус-квест начат, но еще не добит.
+func IsInProgress(q player.Quest) bool {
+	return q.Type == player.TypeFocus && q.Progress > 0 && q.Progress < q.HP
+}
+
+// plan жадно раздает ману кандидатам по порядку: каждому по одной сессии
+// предпочтительной длины, а если на нее не хватает — самой короткой.
+func plan(candidates []player.Quest, mana int, manaCost func(time.Duration) int) ([]Session, int) {
+	var sessions []Session
+	shortest := dungeon.SessionDurations[0]
+	for _, q := range candidates {
+		if len(sessions) >= MaxPlannedSessions {
+			break
+		}
+		d := preferredSession
+		if manaCost(d) > mana {
+			d = shortest
+		}
+		cost := manaCost(d)
+		if cost > mana {
+			break
+		}
+		mana -= cost
+		sessions = append(sessions, Session{Quest: q, Duration: d, ManaCost: cost})
+	}
+	return sessions, mana
+}
+
+func focusOnly(quests []player.Quest) []player.Quest {
+	var out []player.Quest
+	for _, q := range quests {
+		if q.Type == player.TypeFocus {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+func progressRatio(q player.Quest) float64 {
+	if q.HP == 0 {
+		return 0
+	}
+	return float64(q.Progress) / float64(q.HP)
+}
+
+// IsEmpty сообщает, что на сегодня делать нечего.
+func (a Agenda) IsEmpty() bool {
+	return len(a.Rituals) == 0 && len(a.Due) == 0 && len(a.InProgress) == 0
+}
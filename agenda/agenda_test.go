@@ -0,0 +1,85 @@
+package agenda
+
+import (
+	"testing"
+	"time"
+
+	"magus/player"
+)
+
+func TestBuildRespectsDayBoundary(t *testing.T) {
+	// 02:00 при границе дня в 4 утра еще относится ко вчерашнему дню
+	now := time.Date(2025, 8, 2, 2, 0, 0, 0, time.Local)
+	doneLateYesterday := time.Date(2025, 8, 1, 23, 30, 0, 0, time.Local)
+	doneYesterdayMorning := time.Date(2025, 8, 1, 3, 0, 0, 0, time.Local)
+
+	quests := []player.Quest{
+		{ID: "r1", Type: player.TypeRitual, CompletedAt: doneLateYesterday},
+		{ID: "r2", Type: player.TypeRitual, CompletedAt: doneYesterdayMorning},
+	}
+
+	a := Build(quests, &player.Player{}, nil, now, 4)
+	if len(a.Rituals) != 1 || a.Rituals[0].ID != "r2" {
+		t.Fatalf("expected only r2 to be pending, got %+v", a.Rituals)
+	}
+
+	a = Build(quests, &player.Player{}, nil, now, 0)
+	if len(a.Rituals) != 2 {
+		t.Fatalf("with midnight boundary both rituals are pending, got %+v", a.Rituals)
+	}
+}
+
+func TestBuildSectionsAndPlan(t *testing.T) {
+	now := time.Date(2025, 8, 2, 12, 0, 0, 0, time.Local)
+	today := time.Date(2025, 8, 2, 0, 0, 0, 0, time.Local)
+	nextWeek := today.AddDate(0, 0, 7)
+
+	quests := []player.Quest{
+		{ID: "due", Type: player.TypeFocus, HP: 100, Deadline: &today},
+		{ID: "later", Type: player.TypeFocus, HP: 100, Deadline: &nextWeek},
+		{ID: "started", Type: player.TypeFocus, HP: 100, Progress: 40},
+		{ID: "done", Type: player.TypeFocus, HP: 100, Progress: 40, Completed: true},
+	}
+
+	a := Build(quests, &player.Player{Mana: 8}, nil, now, 0)
+	if len(a.Due) != 1 || a.Due[0].ID != "due" {
+		t.Errorf("unexpected due list: %+v", a.Due)
+	}
+	if len(a.InProgress) != 1 || a.InProgress[0].ID != "started" {
+		t.Errorf("unexpected in-progress list: %+v", a.InProgress)
+	}
+
+	// 8 маны: 25 минут (5) для "due", затем на 25 минут не хватает — 15 минут (3)
+	if len(a.Plan) != 2 {
+		t.Fatalf("expected 2 sessions, got %+v", a.Plan)
+	}
+	if a.Plan[0].Quest.ID != "due" || a.Plan[0].Duration != 25*time.Minute {
+		t.Errorf("unexpected first session: %+v", a.Plan[0])
+	}
+	if a.Plan[1].Quest.ID != "started" || a.Plan[1].Duration != 15*time.Minute {
+		t.Errorf("unexpected second session: %+v", a.Plan[1])
+	}
+	if a.ManaLeft != 0 {
+		t.Errorf("expected no mana left, got %d", a.ManaLeft)
+	}
+}
+
+func TestBuildSkipsDeferred(t *testing.T) {
+	now := time.Date(2025, 8, 2, 12, 0, 0, 0, time.Local)
+	today := time.Date(2025, 8, 2, 0, 0, 0, 0, time.Local)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	quests := []player.Quest{
+		{ID: "deferred", Type: player.TypeFocus, HP: 100, Deadline: &today, StartAt: &tomorrow},
+		{ID: "ritual", Type: player.TypeRitual, StartAt: &tomorrow},
+		{ID: "open", Type: player.TypeFocus, HP: 100},
+	}
+
+	a := Build(quests, &player.Player{Mana: 8}, nil, now, 0)
+	if len(a.Due) != 0 || len(a.Rituals) != 0 {
+		t.Errorf("deferred quests in agenda: due %+v, rituals %+v", a.Due, a.Rituals)
+	}
+	if len(a.Plan) != 1 || a.Plan[0].Quest.ID != "open" {
+		t.Errorf("unexpected plan: %+v", a.Plan)
+	}
+}
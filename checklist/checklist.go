@@ -0,0 +1,79 @@
+// Package checklist управляет чек-листами внутри квеста: короткими
+// пунктами с отметкой о выполнении, для которых отдельная подзадача
+// была бы излишней.
+package checklist
+
+import (
+	"errors"
+	"fmt"
+	"magus/player"
+	"strings"
+)
+
+var (
+	ErrEmptyText  = errors.New("checklist item text is empty")
+	ErrNoSuchItem = errors.New("checklist item not found")
+)
+
+// Add добавляет пункт в конец чек-листа.
+func Add(q *player.Quest, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ErrEmptyText
+	}
+	q.Checklist = append(q.Checklist, player.ChecklistItem{Text: text})
+	return nil
+}
+
+// Toggle переключает отметку пункта i и возвращает новое состояние.
+func Toggle(q *player.Quest, i int) (bool, error) {
+	if i < 0 || i >= len(q.Checklist) {
+		return false, ErrNoSuchItem
+	}
+	q.Checklist[i].Done = !q.Checklist[i].Done
+	return q.Checklist[i].Done, nil
+}
+
+// Remove удаляет пункт i.
+func Remove(q *player.Quest, i int) error {
+	if i < 0 || i >= len(q.Checklist) {
+		return ErrNoSuchItem
+	}
+	q.Checklist = append(q.Checklist[:i], q.Checklist[i+1:]...)
+	return nil
+}
+
+// Reset снимает все отметки, например для нового экземпляра повторяющегося квеста.
+func Reset(q *player.Quest) {
+	for i := range q.Checklist {
+		q.Checklist[i].Done = false
+	}
+}
+
+// Count возвращает число отмеченных пунктов и их общее число.
+func Count(q player.Quest) (done, total int) {
+	for _, item := range q.Checklist {
+		if item.Done {
+			done++
+		}
+	}
+	return done, len(q.Checklist)
+}
+
+// Summary возвращает компактную сводку вида "3/7" или "", если чек-листа нет.
+func Summary(q player.Quest) string {
+	done, total := Count(q)
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", done, total)
+}
+
+// Share возвращает долю HP, которую приносит отметка (done-1 → done) пункта
+// из total. Доли округляются так, что все пункты вместе дают ровно hp.
+func Share(hp, done, total int) int {
+	if total <= 0 || done <= 0 || done > total {
+		return 0
+	}
+	return hp*done/total - hp*(done-1)/total
+}
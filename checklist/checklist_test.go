@@ -0,0 +1,41 @@
+package checklist
+
+import (
+	"magus/player"
+	"testing"
+)
+
+func TestShareAddsUpToHP(t *testing.T) {
+	for _, total := range []int{1, 3, 7} {
+		sum := 0
+		for done := 1; done <= total; done++ {
+			sum += Share(10, done, total)
+		}
+		if sum != 10 {
+			t.Errorf("shares for %d items add up to %d, want 10", total, sum)
+		}
+	}
+}
+
+func TestEditChecklist(t *testing.T) {
+	var q player.Quest
+	if err := Add(&q, "  "); err != ErrEmptyText {
+		t.Errorf("Add(empty) = %v, want ErrEmptyText", err)
+	}
+	Add(&q, "купить")
+	Add(&q, "собрать")
+	Add(&q, "проверить")
+
+	if done, err := Toggle(&q, 1); err != nil || !done {
+		t.Fatalf("Toggle = %v, %v", done, err)
+	}
+	if got := Summary(q); got != "1/3" {
+		t.Errorf("Summary = %q, want 1/3", got)
+	}
+	if err := Remove(&q, 0); err != nil || q.Checklist[0].Text != "собрать" {
+		t.Errorf("Remove: %v, %+v", err, q.Checklist)
+	}
+	if _, err := Toggle(&q, 5); err != ErrNoSuchItem {
+		t.Errorf("Toggle(5) = %v, want ErrNoSuchItem", err)
+	}
+}
@@ -1,10 +1,19 @@
 package cmd
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"magus/config"
+	"magus/deadline"
+	"magus/deps"
+	"magus/i18n"
 	"magus/player"
+	"magus/recur"
+	"magus/snooze"
 	"magus/storage"
+	"magus/tags"
+	"magus/templates"
 	"magus/utils"
 	"os"
 	"strings"
@@ -13,54 +22,141 @@ import (
 
 func Add() {
 	if len(os.Args) < 3 {
-		fmt.Println("Usage: magus add \"название задачи\" [--type=daily] [--xp=10] [--parent=ID] [--tags=\"tag1,tag2\"] [--deadline=\"YYYY-MM-DD\"]")
+		fmt.Println(i18n.T("cmd.add.usage"))
 		return
 	}
-	title := os.Args[2]
+	// С --template название необязательно: оно подставляется в {{name}}
+	args := os.Args[2:]
+	title := ""
+	if !strings.HasPrefix(args[0], "-") {
+		title, args = args[0], args[1:]
+	}
 
 	addCmd := flag.NewFlagSet("add", flag.ExitOnError)
-	taskType := addCmd.String("type", "daily", "Тип квеста (daily, arc, meta, epic, chore)")
-	xp := addCmd.Int("xp", 10, "Количество XP за квест")
-	parentID := addCmd.String("parent", "", "ID родительского квеста")
-	tagsStr := addCmd.String("tags", "", "Теги через запятую (e.g., \"работа,дом\")")
-	deadlineStr := addCmd.String("deadline", "", "Дедлайн в формате YYYY-MM-DD")
-
-	addCmd.Parse(os.Args[3:])
+	taskType := addCmd.String("type", string(player.TypeFocus), i18n.T("cmd.add.flag_type"))
+	xp := addCmd.Int("xp", 10, i18n.T("cmd.add.flag_xp"))
+	parentID := addCmd.String("parent", "", i18n.T("cmd.add.flag_parent"))
+	tagsStr := addCmd.String("tags", "", i18n.T("cmd.add.flag_tags"))
+	deadlineStr := addCmd.String("deadline", "", i18n.T("cmd.add.flag_deadline"))
+	every := addCmd.String("every", "", i18n.T("cmd.add.flag_every"))
+	blockedBy := addCmd.String("blocked-by", "", i18n.T("cmd.add.flag_blocked_by"))
+	priorityStr := addCmd.String("priority", "", i18n.T("cmd.add.flag_priority"))
+	templateName := addCmd.String("template", "", i18n.T("cmd.add.flag_template"))
+	startStr := addCmd.String("start", "", i18n.T("cmd.add.flag_start"))
 
-	var tags []string
-	if *tagsStr != "" {
-		tags = strings.Split(*tagsStr, ",")
+	addCmd.Parse(args)
+	if title == "" && *templateName == "" {
+		fmt.Println(i18n.T("cmd.add.usage"))
+		return
 	}
 
-	var deadline *time.Time
+	questTags := tags.Parse(*tagsStr)
+
+	var due *time.Time
+	var timed bool
 	if *deadlineStr != "" {
-		t, err := time.Parse("2006-01-02", *deadlineStr)
+		t, hasTime, err := deadline.Parse(*deadlineStr)
 		if err != nil {
-			fmt.Println("❌ Ошибка парсинга дедлайна. Используйте формат YYYY-MM-DD:", err)
+			fmt.Println(i18n.T("cmd.add.err_deadline"), *deadlineStr)
 			return
 		}
-		deadline = &t
+		due, timed = &t, hasTime
 	}
 
+	switch player.QuestType(*taskType) {
+	case player.TypeFocus, player.TypeRitual, player.TypeGoal:
+	default:
+		fmt.Println(i18n.T("cmd.add.err_type", *taskType))
+		return
+	}
+
+	priority, ok := player.ParsePriority(*priorityStr)
+	if !ok {
+		fmt.Println(i18n.T("cmd.add.err_priority", *priorityStr))
+		return
+	}
+
+	var start time.Time
+	if *startStr != "" {
+		cfg, _ := config.Load()
+		var err error
+		if start, err = snooze.Parse(*startStr, time.Now(), cfg.DayStartHour); err != nil {
+			fmt.Println(i18n.T("cmd.add.err_start", *startStr))
+			return
+		}
+	}
+
+	var recurrence string
+	if *every != "" {
+		rule, err := recur.Parse(*every)
+		if err != nil {
+			fmt.Println(i18n.T("cmd.add.err_every"), err)
+			return
+		}
+		recurrence = rule.String()
+	}
+
+	// Просрочка новых квестов учитывается с момента создания
+	created := time.Now()
 	newQuest := player.Quest{
-		ID:        utils.GenerateID(),
-		ParentID:  *parentID,
-		Title:     title,
-		Type:      player.QuestType(*taskType),
-		XP:        *xp,
-		Tags:      tags,
-		Deadline:  deadline,
-		Completed: false,
-		CreatedAt: time.Now(),
+		ID:           utils.GenerateID(),
+		ParentID:     *parentID,
+		Title:        title,
+		Priority:     priority,
+		Type:         player.QuestType(*taskType),
+		XP:           *xp,
+		Tags:         questTags,
+		Deadline:     due,
+		DeadlineTime: timed,
+		Completed:    false,
+		CreatedAt:    created,
+		PenaltyAt:    &created,
+		Recurrence:   recurrence,
+	}
+	// Срок первого экземпляра берется из расписания, если не задан явно
+	if rule, ok := recur.RuleOf(newQuest); ok && newQuest.Deadline == nil {
+		due := rule.First(newQuest.CreatedAt)
+		newQuest.Deadline = &due
+	}
+	newQuests := []player.Quest{newQuest}
+
+	// Шаблон создает целое дерево квестов вместо одного
+	if *templateName != "" {
+		tmpl, err := templates.Load(*templateName)
+		if err != nil {
+			printTemplateError(*templateName, err)
+			return
+		}
+		now := time.Now()
+		newQuests, err = templates.Instantiate(tmpl, *parentID, templates.Vars(title, now), now)
+		if err != nil {
+			fmt.Println(i18n.T("cmd.add.err_template"), err)
+			return
+		}
+		newQuest = newQuests[0]
+	}
+	// Отложенный квест появится в списке с даты начала вместе с подзадачами
+	if !start.IsZero() {
+		snooze.Until(&newQuests[0], start, time.Now())
+		newQuest = newQuests[0]
 	}
 
 	quests, err := storage.LoadAllQuests()
 	if err != nil {
-		fmt.Println("❌ Ошибка загрузки квестов:", err)
+		fmt.Println(i18n.T("err.load_quests"), err)
 		return
 	}
 
-	quests = append(quests, newQuest)
+	quests = append(quests, newQuests...)
+
+	if *blockedBy != "" {
+		for _, blockerID := range strings.Split(*blockedBy, ",") {
+			if err := deps.AddBlocker(quests, newQuest.ID, strings.TrimSpace(blockerID)); err != nil {
+				fmt.Println(blockError(err), blockerID)
+				return
+			}
+		}
+	}
 
 	// Применяем перк "Планирование"
 	if *parentID != "" {
@@ -69,14 +165,14 @@ func Add() {
 			if err == player.ErrPlayerNotFound {
 				// Игнорируем ошибку, если игрок не найден, перк просто не применяется
 			} else {
-				fmt.Println("❌ Ошибка загрузки игрока для применения перка:", err)
+				fmt.Println(i18n.T("cmd.add.err_load_player_perk"), err)
 			}
-		} else if hasPerk(p, "Планирование") {
+		} else if hasPerk(p, player.PerkPlanning) {
 			for i, q := range quests {
 				if q.ID == *parentID {
 					bonusXP := q.XP * 20 / 100
 					quests[i].XP += bonusXP
-					fmt.Printf("✨ Перк 'Планирование': +%d XP к родительскому квесту!\n", bonusXP)
+					fmt.Println(i18n.T("cmd.add.perk_planning", bonusXP))
 					break
 				}
 			}
@@ -84,12 +180,38 @@ func Add() {
 	}
 
 	if err := storage.SaveAllQuests(quests); err != nil {
-		fmt.Println("❌ Ошибка сохранения квеста:", err)
+		fmt.Println(i18n.T("cmd.add.err_save"), err)
 		return
 	}
 
-	fmt.Println("🗒️ Добавлен квест:", title)
+	if *templateName != "" {
+		fmt.Println(i18n.T("cmd.add.template_added", *templateName, len(newQuests)))
+		depth := map[string]int{newQuest.ID: 0}
+		for _, q := range newQuests {
+			indent := ""
+			if q.ID != newQuest.ID {
+				depth[q.ID] = depth[q.ParentID] + 1
+				indent = strings.Repeat("  ", depth[q.ID]) + "└─ "
+			}
+			fmt.Printf("%s%s {id: %s}\n", indent, q.Title, q.ID)
+		}
+	} else {
+		fmt.Println(i18n.T("cmd.add.added"), title)
+	}
 	if *parentID != "" {
-		fmt.Printf("   (Подзадача для квеста %s)\n", *parentID)
+		fmt.Println(i18n.T("cmd.add.subquest_of", *parentID))
+	}
+}
+
+// printTemplateError сообщает об ошибке загрузки шаблона и перечисляет доступные.
+func printTemplateError(name string, err error) {
+	if !errors.Is(err, templates.ErrNotFound) {
+		fmt.Println(i18n.T("cmd.add.err_template"), err)
+		return
+	}
+	fmt.Println(i18n.T("cmd.add.template_not_found", name, templates.Dir))
+	list, _ := templates.List()
+	for _, t := range list {
+		fmt.Printf("  - %s: %s\n", t.Name, t.Description)
 	}
 }
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"magus/agenda"
+	"magus/config"
+	"magus/i18n"
+	"magus/player"
+	"magus/storage"
+	"time"
+)
+
+func Agenda() {
+	quests, err := storage.LoadAllQuests()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_quests"), err)
+		return
+	}
+	p, err := player.LoadPlayer()
+	if err != nil && err != player.ErrPlayerNotFound {
+		fmt.Println(i18n.T("cmd.show.err_read_player"), err)
+		return
+	}
+	cfg, _ := config.Load()
+
+	a := agenda.Build(quests, p, loadEffects(p).ManaCost, time.Now(), cfg.DayStartHour)
+	fmt.Println(i18n.T("cmd.agenda.header", a.DayStart.Format("2006-01-02"), a.DayStart.Format("15:04")))
+
+	if a.IsEmpty() {
+		fmt.Println(i18n.T("cmd.agenda.empty"))
+		return
+	}
+
+	if len(a.Rituals) > 0 {
+		fmt.Println("\n" + i18n.T("agenda.rituals"))
+		for _, q := range a.Rituals {
+			fmt.Printf("  - %s {id: %s}\n", q.Title, q.ID)
+		}
+	}
+
+	if len(a.Due) > 0 {
+		fmt.Println("\n" + i18n.T("agenda.due"))
+		for _, q := range a.Due {
+			status := i18n.T("agenda.due_today")
+			if agenda.IsOverdue(q, time.Now()) {
+				status = i18n.T("agenda.overdue")
+			}
+			fmt.Printf("  - %s (%s) {id: %s}\n", q.Title, status, q.ID)
+		}
+	}
+
+	if len(a.InProgress) > 0 {
+		fmt.Println("\n" + i18n.T("agenda.in_progress"))
+		for _, q := range a.InProgress {
+			fmt.Printf("  - %s (HP: %d/%d) {id: %s}\n", q.Title, q.Progress, q.HP, q.ID)
+		}
+	}
+
+	mana := 0
+	if p != nil {
+		mana = p.Mana
+	}
+	fmt.Println("\n" + i18n.T("agenda.plan", mana))
+	if len(a.Plan) == 0 {
+		fmt.Println("  " + i18n.T("agenda.plan_empty"))
+		return
+	}
+	for i, s := range a.Plan {
+		fmt.Printf("  %d. %s\n", i+1, i18n.T("agenda.session", int(s.Duration.Minutes()), s.Quest.Title, s.ManaCost))
+	}
+	fmt.Println("  " + i18n.T("agenda.mana_left", a.ManaLeft))
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"magus/i18n"
+	"magus/storage"
+	"os"
+)
+
+// Archive показывает архив выполненных квестов или восстанавливает квест из него.
+func Archive() {
+	if len(os.Args) > 2 {
+		switch os.Args[2] {
+		case "restore":
+			if len(os.Args) < 4 {
+				fmt.Println(i18n.T("cmd.archive.usage"))
+				return
+			}
+			restoreQuest(os.Args[3])
+		default:
+			fmt.Println(i18n.T("cmd.archive.usage"))
+		}
+		return
+	}
+
+	archive, err := storage.LoadArchive()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_archive"), err)
+		return
+	}
+	if len(archive) == 0 {
+		fmt.Println(i18n.T("cmd.archive.empty"))
+		return
+	}
+
+	fmt.Println(i18n.T("cmd.archive.header", len(archive)))
+	for _, q := range archive {
+		indent := ""
+		if q.ParentID != "" {
+			indent = "  └─ "
+		}
+		fmt.Printf("%s📦 %s (%s) {id: %s}\n", indent, q.Title, q.CompletedAt.Format("2006-01-02"), q.ID)
+	}
+}
+
+// restoreQuest возвращает квест из архива в список квестов.
+func restoreQuest(questID string) {
+	archive, err := storage.LoadArchive()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_archive"), err)
+		return
+	}
+	restored, rest, err := storage.Unarchive(archive, questID)
+	if errors.Is(err, storage.ErrNotArchived) {
+		fmt.Println(i18n.T("cmd.archive.not_found"))
+		return
+	}
+
+	quests, err := storage.LoadAllQuests()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_quests"), err)
+		return
+	}
+	if err := storage.SaveAllQuests(append(quests, restored...)); err != nil {
+		fmt.Println(i18n.T("err.save_quests"), err)
+		return
+	}
+	if err := storage.SaveArchive(rest); err != nil {
+		fmt.Println(i18n.T("err.save_archive"), err)
+		return
+	}
+	for _, q := range restored {
+		if q.ID == questID {
+			fmt.Println(i18n.T("cmd.archive.restored", q.Title, len(restored)))
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"magus/deps"
+	"magus/i18n"
+	"magus/storage"
+	"os"
+)
+
+// Block добавляет связь «квест ждет другой квест».
+func Block() {
+	if len(os.Args) < 4 {
+		fmt.Println(i18n.T("cmd.block.usage"))
+		return
+	}
+	questID, blockerID := os.Args[2], os.Args[3]
+
+	quests, err := storage.LoadAllQuests()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_quests"), err)
+		return
+	}
+
+	if err := deps.AddBlocker(quests, questID, blockerID); err != nil {
+		fmt.Println(blockError(err))
+		return
+	}
+	if err := storage.SaveAllQuests(quests); err != nil {
+		fmt.Println(i18n.T("err.save_quests"), err)
+		return
+	}
+	fmt.Println(i18n.T("cmd.block.done", questID, blockerID))
+}
+
+// Unblock удаляет связь «квест ждет другой квест».
+func Unblock() {
+	if len(os.Args) < 4 {
+		fmt.Println(i18n.T("cmd.unblock.usage"))
+		return
+	}
+	questID, blockerID := os.Args[2], os.Args[3]
+
+	quests, err := storage.LoadAllQuests()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_quests"), err)
+		return
+	}
+
+	if err := deps.RemoveBlocker(quests, questID, blockerID); err != nil {
+		fmt.Println(blockError(err))
+		return
+	}
+	if err := storage.SaveAllQuests(quests); err != nil {
+		fmt.Println(i18n.T("err.save_quests"), err)
+		return
+	}
+	fmt.Println(i18n.T("cmd.unblock.done", questID, blockerID))
+}
+
+// blockError переводит ошибки пакета deps в сообщения для пользователя.
+func blockError(err error) string {
+	switch {
+	case errors.Is(err, deps.ErrQuestNotFound):
+		return i18n.T("err.quest_not_found")
+	case errors.Is(err, deps.ErrSelfBlock):
+		return i18n.T("cmd.block.self")
+	case errors.Is(err, deps.ErrCycle):
+		return i18n.T("cmd.block.cycle")
+	case errors.Is(err, deps.ErrNotBlocked):
+		return i18n.T("cmd.unblock.not_blocked")
+	}
+	return err.Error()
+}
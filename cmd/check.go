@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"magus/checklist"
+	"magus/config"
+	"magus/deps"
+	"magus/game"
+	"magus/i18n"
+	"magus/player"
+	"magus/storage"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Check показывает и редактирует чек-лист квеста:
+// magus check <id> [add <текст> | toggle <n> | remove <n>].
+func Check() {
+	if len(os.Args) < 3 {
+		fmt.Println(i18n.T("cmd.check.usage"))
+		return
+	}
+	questID := os.Args[2]
+
+	quests, err := storage.LoadAllQuests()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_quests"), err)
+		return
+	}
+	i := -1
+	for j, q := range quests {
+		if q.ID == questID {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		fmt.Println(i18n.T("err.quest_not_found"))
+		return
+	}
+
+	if len(os.Args) < 4 {
+		printChecklist(quests[i])
+		return
+	}
+
+	var events []game.Event
+	var p *player.Player
+	switch os.Args[3] {
+	case "add":
+		err = checklist.Add(&quests[i], strings.Join(os.Args[4:], " "))
+	case "toggle":
+		var n int
+		if n, err = checkItemArg(); err != nil {
+			break
+		}
+		p, err = player.LoadPlayer()
+		if err != nil && err != player.ErrPlayerNotFound {
+			fmt.Println(i18n.T("cmd.show.err_read_player"), err)
+			return
+		}
+		cfg, _ := config.Load()
+		w := &game.World{Player: p, Quests: quests, DayStartHour: cfg.DayStartHour, Tags: loadTags(), Effects: loadEffects(p), Curve: cfg.Curve()}
+		events, err = game.ToggleCheck(w, questID, n, cfg.ChecklistProgress, time.Now())
+	case "remove":
+		var n int
+		if n, err = checkItemArg(); err == nil {
+			err = checklist.Remove(&quests[i], n)
+		}
+	default:
+		fmt.Println(i18n.T("cmd.check.usage"))
+		return
+	}
+
+	switch {
+	case errors.Is(err, checklist.ErrEmptyText):
+		fmt.Println(i18n.T("cmd.check.err_empty"))
+		return
+	case errors.Is(err, checklist.ErrNoSuchItem):
+		fmt.Println(i18n.T("cmd.check.err_item", len(quests[i].Checklist)))
+		return
+	case err != nil:
+		fmt.Println(err)
+		return
+	}
+
+	if err := storage.SaveAllQuests(quests); err != nil {
+		fmt.Println(i18n.T("err.save_quests"), err)
+		return
+	}
+	if p != nil && len(events) > 0 {
+		if err := player.SavePlayer(p); err != nil {
+			fmt.Println(i18n.T("cmd.complete.err_save_player"), err)
+		}
+	}
+	printChecklist(deps.Index(quests)[questID])
+	printEvents(events)
+}
+
+// checkItemArg разбирает номер пункта (с 1) и возвращает его индекс.
+func checkItemArg() (int, error) {
+	if len(os.Args) < 5 {
+		return 0, checklist.ErrNoSuchItem
+	}
+	n, err := strconv.Atoi(os.Args[4])
+	if err != nil {
+		return 0, checklist.ErrNoSuchItem
+	}
+	return n - 1, nil
+}
+
+// printChecklist печатает чек-лист квеста с номерами пунктов.
+func printChecklist(q player.Quest) {
+	if len(q.Checklist) == 0 {
+		fmt.Println(i18n.T("cmd.check.empty", q.Title))
+		return
+	}
+	fmt.Println(i18n.T("cmd.check.header", q.Title, checklist.Summary(q)))
+	for n, item := range q.Checklist {
+		mark := "[ ]"
+		if item.Done {
+			mark = "[x]"
+		}
+		fmt.Printf("  %d. %s %s\n", n+1, mark, item.Text)
+	}
+}
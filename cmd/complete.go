@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"magus/config"
+	"magus/deps"
+	"magus/game"
+	"magus/i18n"
 	"magus/player"
+	"magus/ritual"
 	"magus/storage"
 	"os"
 	"time"
@@ -10,113 +16,104 @@ import (
 
 func Complete() {
 	if len(os.Args) < 3 {
-		fmt.Println("Usage: magus complete <quest_id>")
+		fmt.Println(i18n.T("cmd.complete.usage"))
 		return
 	}
 	questID := os.Args[2]
 
 	quests, err := storage.LoadAllQuests()
 	if err != nil {
-		fmt.Println("❌ Ошибка загрузки квестов:", err)
+		fmt.Println(i18n.T("err.load_quests"), err)
 		return
 	}
-
-	var completedQuest player.Quest
-	var found bool
-
-	for i, q := range quests {
-		if q.ID == questID {
-			if q.Completed {
-				fmt.Println("⚠️ Квест уже выполнен.")
-				return
-			}
-
-			switch q.Type {
-			case player.TypeGoal:
-				fmt.Println("⚠️ Цели (Goal) нельзя завершить напрямую. Завершите все подзадачи.")
-				return
-			case player.TypeRitual:
-				fmt.Println("💧 Ритуал выполнен. Мана восстановлена (в TUI).")
-				// Логика начисления маны находится в TUI, здесь просто сообщение
-			case player.TypeFocus:
-				quests[i].Completed = true
-				quests[i].CompletedAt = time.Now()
-				quests[i].Progress = q.HP // Считаем выполненным
-				addXP(q.XP)
-				fmt.Println("✅ Квест завершён!")
-			}
-
-			completedQuest = quests[i]
-			found = true
-			break
-		}
-	}
-
-	if !found {
-		fmt.Println("⚠️ Квест с таким ID не найден.")
+	p, err := player.LoadPlayer()
+	if err != nil && err != player.ErrPlayerNotFound {
+		fmt.Println(i18n.T("cmd.show.err_read_player"), err)
 		return
 	}
+	cfg, _ := config.Load()
 
-	if err := storage.SaveAllQuests(quests); err != nil {
-		fmt.Println("❌ Ошибка сохранения квестов:", err)
+	w := &game.World{Player: p, Quests: quests, DayStartHour: cfg.DayStartHour, Tags: loadTags(), Effects: loadEffects(p), Curve: cfg.Curve()}
+	events, err := game.CompleteQuest(w, questID, time.Now())
+	if err != nil {
+		printCompleteError(w, questID, err)
 		return
 	}
 
-	if completedQuest.ParentID != "" {
-		checkAndCompleteParent(completedQuest.ParentID)
-	}
-}
-
-// checkAndCompleteParent проверяет, все ли дочерние квесты выполнены, и завершает родительский.
-func checkAndCompleteParent(parentID string) {
-	quests, err := storage.LoadAllQuests()
-	if err != nil {
-		fmt.Println("❌ Ошибка загрузки квестов для проверки родительского:", err)
+	if err := storage.SaveAllQuests(w.Quests); err != nil {
+		fmt.Println(i18n.T("err.save_quests"), err)
 		return
 	}
-
-	subQuestsCompleted := true
-	parentQuestIndex := -1
-
-	for i, q := range quests {
-		if q.ID == parentID {
-			parentQuestIndex = i
-			continue
-		}
-		if q.ParentID == parentID && !q.Completed {
-			subQuestsCompleted = false
-			break
+	if w.Player != nil {
+		if err := player.SavePlayer(w.Player); err != nil {
+			fmt.Println(i18n.T("cmd.complete.err_save_player"), err)
 		}
 	}
+	printEvents(events)
+}
 
-	if parentQuestIndex != -1 && subQuestsCompleted {
-		parent := &quests[parentQuestIndex]
-		if !parent.Completed {
-			parent.Completed = true
-			parent.CompletedAt = time.Now()
-			fmt.Printf("🎉 Все подзадачи выполнены! Родительский квест '%s' завершён!", parent.Title)
-			addXP(parent.XP)
-
-			if err := storage.SaveAllQuests(quests); err != nil {
-				fmt.Println("❌ Ошибка сохранения родительского квеста:", err)
-			}
+// printCompleteError объясняет, почему квест нельзя завершить.
+func printCompleteError(w *game.World, questID string, err error) {
+	index := deps.Index(w.Quests)
+	q := index[questID]
+	switch {
+	case errors.Is(err, game.ErrQuestNotFound):
+		fmt.Println(i18n.T("err.quest_not_found"))
+	case errors.Is(err, game.ErrAlreadyCompleted):
+		fmt.Println(i18n.T("cmd.complete.already_done"))
+	case errors.Is(err, game.ErrBlocked):
+		fmt.Println(i18n.T("cmd.complete.blocked"))
+		for _, b := range deps.OpenBlockers(q, index) {
+			fmt.Printf("  - %s {id: %s}\n", b.Title, b.ID)
 		}
+	case errors.Is(err, game.ErrOpenSubquests):
+		fmt.Println(i18n.T("cmd.complete.goal_direct"))
+	case errors.Is(err, game.ErrNeedsDungeon):
+		fmt.Println(i18n.T("cmd.complete.needs_dungeon", q.HP-q.Progress))
+	case errors.Is(err, ritual.ErrCooldown):
+		readyAt := ritual.AvailableAt(q, w.DayStartHour)
+		fmt.Println(i18n.T("cmd.complete.ritual_cooldown", readyAt.Format("2006-01-02 15:04")))
+	default:
+		fmt.Println(err)
 	}
 }
 
-// addXP начисляет опыт и обрабатывает повышение уровня.
-func addXP(xp int) {
-	if xp <= 0 {
-		return
-	}
-
-	canLevelUp, err := player.AddXP(xp)
-	if err != nil {
-		fmt.Println("❌ Не удалось начислить XP:", err)
-	} else {
-		fmt.Printf("✨ +%d XP!\n", xp)
-		if canLevelUp {
-			fmt.Println("🔥 Поздравляем! Вы можете повысить уровень! Запустите `magus` для выбора перка или класса.")
+// printEvents выводит результаты операции правил игры.
+func printEvents(events []game.Event) {
+	for _, e := range events {
+		switch e.Kind {
+		case game.EventQuestCompleted:
+			fmt.Println(i18n.T("cmd.complete.done"))
+		case game.EventRecurringDone:
+			fmt.Println(i18n.T("cmd.complete.recurring_done", e.Quest.Deadline.Format("2006-01-02")))
+		case game.EventParentCompleted:
+			fmt.Println(i18n.T("cmd.complete.parent_done", e.Quest.Title))
+		case game.EventQuestDamaged:
+			fmt.Println(i18n.T("cmd.complete.damage", e.Amount, e.Quest.Progress, e.Quest.HP))
+		case game.EventQuestDefeated:
+			fmt.Println(i18n.T("cmd.complete.defeated", e.Quest.Title))
+		case game.EventEarlyBonus:
+			fmt.Println(i18n.T("cmd.complete.early_bonus", e.Amount))
+		case game.EventManaRestored:
+			fmt.Println(i18n.T("cmd.complete.ritual_done", e.Amount, e.Quest.Streak))
+		case game.EventNewBestStreak:
+			fmt.Println(i18n.T("cmd.complete.ritual_best"))
+		case game.EventRitualsDone:
+			fmt.Println(i18n.T("cmd.complete.rituals_done", e.Amount))
+		case game.EventXPGained:
+			fmt.Println(i18n.T("cmd.xp.gained", e.Amount))
+		case game.EventStatTrained:
+			fmt.Println(i18n.T("stat.trained", i18n.T("stat."+e.Stat), e.Amount))
+		case game.EventLevelUpReady:
+			fmt.Println(i18n.T("cmd.xp.can_level_up", e.Amount))
+		case game.EventSkillUnlocked:
+			fmt.Println(i18n.T("skill.learned", e.Skill.Name))
+		case game.EventQuestOverdue:
+			fmt.Println(i18n.T("overdue.quest", e.Quest.Title, e.Amount))
+		case game.EventXPDecayed:
+			fmt.Println(i18n.T("overdue.xp_decayed", e.Amount, e.Quest.XP))
+		case game.EventHPLost:
+			fmt.Println(i18n.T("overdue.hp_lost", e.Amount))
 		}
 	}
 }
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"magus/effects"
+	"magus/i18n"
+	"magus/player"
+)
+
+// loadEffects вычисляет эффекты навыков и класса и записывает новые
+// максимумы HP и маны в игрока. Без игрока эффекты ничего не меняют.
+func loadEffects(p *player.Player) effects.Sheet {
+	sheet, err := effects.Load(p)
+	if err != nil {
+		fmt.Println(i18n.T("cmd.show.err_tree"), err)
+	}
+	if sheet.Apply(p) {
+		player.SavePlayer(p)
+	}
+	return sheet
+}
+
+// printEffects показывает характеристики игрока и источники надбавок.
+func printEffects(sheet effects.Sheet) {
+	fmt.Println(i18n.T("cmd.show.effects"))
+	for _, stat := range effects.Stats {
+		fmt.Printf("  %s: %s\n", effects.StatName(stat), sheet.Value(stat))
+		for _, c := range sheet.Breakdown(stat) {
+			fmt.Printf("      %s — %s\n", effects.Format(c.Stat, c.Value), c.Source)
+		}
+	}
+}
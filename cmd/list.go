@@ -1,33 +1,113 @@
 package cmd
 
 import (
+	"flag"
 	"fmt"
+	"magus/config"
+	"magus/deps"
+	"magus/game"
+	"magus/i18n"
 	"magus/player"
+	"magus/recur"
+	"magus/ritual"
+	"magus/snooze"
 	"magus/storage"
+	"magus/tree"
+	"magus/urgency"
 	"os"
 	"strings"
+	"time"
 )
 
 func List() {
+	listCmd := flag.NewFlagSet("list", flag.ExitOnError)
+	ready := listCmd.Bool("ready", false, i18n.T("cmd.list.flag_ready"))
+	sortBy := listCmd.String("sort", "", i18n.T("cmd.list.flag_sort"))
+	upcoming := listCmd.Bool("upcoming", false, i18n.T("cmd.list.flag_upcoming"))
+	listCmd.Parse(os.Args[2:])
+
+	if *sortBy != "" && *sortBy != "urgency" {
+		fmt.Println(i18n.T("cmd.list.err_sort", *sortBy))
+		os.Exit(1)
+	}
+
 	quests, err := storage.LoadAllQuests()
 	if err != nil {
-		fmt.Println("❌ Ошибка загрузки квестов:", err)
+		fmt.Println(i18n.T("err.load_quests"), err)
 		os.Exit(1)
 	}
 
+	cfg, _ := config.Load()
+	now := time.Now()
+	// Прерванные серии ритуалов проверяем до того, как пропущенные экземпляры будут заменены
+	broken := ritual.CheckStreaks(quests, now, cfg.DayStartHour)
+	changed := recur.Roll(quests, now) || len(broken) > 0
+	// За каждый новый день просрочки игрок теряет HP, а награда квеста тает
+	p, _ := player.LoadPlayer()
+	w := &game.World{Player: p, Quests: quests, DayStartHour: cfg.DayStartHour, Effects: loadEffects(p)}
+	overdue := game.ApplyOverdue(w, cfg.OverdueHP(), cfg.OverdueXPDecay(), now)
+	if len(overdue) > 0 {
+		changed = true
+		if p != nil {
+			player.SavePlayer(p)
+		}
+	}
+	// Давно выполненные квесты уходят в архив
+	archived := 0
+	if cutoff, ok := cfg.ArchiveCutoff(now); ok {
+		if quests, archived, err = storage.AutoArchive(quests, cutoff); err != nil {
+			fmt.Println(i18n.T("err.save_archive"), err)
+		}
+	}
+	if changed || archived > 0 {
+		storage.SaveAllQuests(quests)
+	}
+	for _, q := range broken {
+		fmt.Println(i18n.T("ritual.streak_broken", q.Title, q.Streak))
+	}
+	printEvents(overdue)
+	if archived > 0 {
+		fmt.Println(i18n.T("archive.moved", archived))
+	}
+
+	if *upcoming {
+		printUpcoming(quests, now)
+		return
+	}
+
 	if len(quests) == 0 {
-		fmt.Println("✨ Нет активных квестов. Время добавить новый! `magus add`")
+		fmt.Println(i18n.T("cmd.list.empty"))
 		return
 	}
 
-	fmt.Println("📜 Список квестов:")
+	// Отложенные квесты и их подзадачи ждут своей даты начала
+	hidden := snooze.Hidden(quests, now)
 
 	// Создаем карту для быстрого доступа к квестам по ID
-	questMap := make(map[string]player.Quest)
-	for _, q := range quests {
-		questMap[q.ID] = q
+	questMap := deps.Index(quests)
+
+	// При сортировке по срочности подзадачи тоже упорядочиваются внутри родителя
+	var scorer *urgency.Scorer
+	if *sortBy == "urgency" {
+		scorer = urgency.NewScorer(quests, now, urgency.WithOverrides(cfg.Urgency))
+		quests = scorer.Sort(quests)
+	} else {
+		quests = tree.Sort(quests) // Ручной порядок из TUI
 	}
 
+	if *ready {
+		// Только то, за что можно взяться прямо сейчас: без целей и блокировок
+		fmt.Println(i18n.T("cmd.list.header_ready"))
+		for _, q := range deps.Ready(quests) {
+			if q.Type != player.TypeGoal && !hidden[q.ID] {
+				printQuest(q, 0, questMap, scorer)
+			}
+		}
+		return
+	}
+
+	fmt.Println(i18n.T("cmd.list.header"))
+
 	// Создаем карту для группировки подзадач по родителям
 	subQuests := make(map[string][]player.Quest)
 	for _, q := range quests {
@@ -38,29 +118,39 @@ func List() {
 
 	// Отображаем только родительские квесты
 	for _, q := range quests {
-		if q.ParentID != "" {
+		if q.ParentID != "" || hidden[q.ID] {
 			continue // Пропускаем подзадачи, они будут отображены под родителями
 		}
 
-		printQuest(q, 0) // 0 - уровень вложенности
+		printQuest(q, 0, questMap, scorer) // 0 - уровень вложенности
 
 		// Отображаем подзадачи для текущего квеста
 		if children, ok := subQuests[q.ID]; ok {
 			for _, child := range children {
-				printQuest(child, 1) // 1 - уровень вложенности
+				if !hidden[child.ID] {
+					printQuest(child, 1, questMap, scorer) // 1 - уровень вложенности
+				}
 			}
 		}
 	}
+
+	if n := len(snooze.Upcoming(quests, now)); n > 0 {
+		fmt.Println(i18n.T("cmd.list.deferred", n))
+	}
 }
 
-func printQuest(q player.Quest, indentationLevel int) {
+func printQuest(q player.Quest, indentationLevel int, questMap map[string]player.Quest, scorer *urgency.Scorer) {
 	if q.Completed {
 		return // Не показываем выполненные квесты
 	}
 
+	blockers := deps.OpenBlockers(q, questMap)
+
 	var status string
 	// Для Ritual квестов статус всегда "активен", т.к. они повторяемые
-	if q.Type == player.TypeRitual {
+	if len(blockers) > 0 {
+		status = "⛔" // Ждет другие квесты
+	} else if q.Type == player.TypeRitual {
 		status = "💧"
 	} else if q.Progress > 0 && q.Progress < q.HP {
 		status = "⚙️" // В процессе
@@ -81,12 +171,32 @@ func printQuest(q player.Quest, indentationLevel int) {
 		details = fmt.Sprintf("(XP: %d)", q.XP)
 	case player.TypeRitual:
 		details = fmt.Sprintf("(%s)", q.RitualSubtype)
+		if q.Streak > 0 {
+			details += fmt.Sprintf(" 🔥%d", q.Streak)
+		}
+	}
+	if q.Priority != player.PriorityNone {
+		details += " " + i18n.T("cmd.list.priority", i18n.T("quest.priority."+string(q.Priority)))
+	}
+	if summary := recur.Summary(q); summary != "" {
+		details += " 🔁 " + summary
+	}
+	if len(blockers) > 0 {
+		ids := make([]string, len(blockers))
+		for i, b := range blockers {
+			ids[i] = b.ID
+		}
+		details += " " + i18n.T("cmd.list.blocked_by", strings.Join(ids, ", "))
+	}
+
+	if scorer != nil {
+		details += fmt.Sprintf(" ⚡%.1f", scorer.Score(q))
 	}
 
 	fmt.Printf("%s%s [%s] %s %s {id: %s}\n",
 		indent,
 		status,
-		strings.ToUpper(string(q.Type)),
+		strings.ToUpper(i18n.T("quest.type."+string(q.Type))),
 		q.Title,
 		details,
 		q.ID)
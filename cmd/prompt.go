@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"magus/config"
+	"magus/i18n"
+	"magus/prompt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Prompt печатает однострочный статус для PS1 и tmux.
+// Ошибки не выводятся: приглашение командной строки не должно ломаться
+// из-за отсутствующего игрока, поэтому сообщаем о них только кодом возврата.
+func Prompt() {
+	promptCmd := flag.NewFlagSet("prompt", flag.ExitOnError)
+	format := promptCmd.String("format", "", i18n.T("cmd.prompt.flag_format", strings.Join(prompt.Placeholders, " ")))
+	noEmoji := promptCmd.Bool("no-emoji", false, i18n.T("cmd.prompt.flag_no_emoji"))
+	promptCmd.Parse(os.Args[2:])
+
+	opts := prompt.Options{Format: *format, NoEmoji: *noEmoji}
+	if opts.Format == "" {
+		cfg, _ := config.Load()
+		opts.Format = cfg.PromptFormat
+	}
+
+	line, err := prompt.Line(opts, time.Now())
+	if err != nil {
+		os.Exit(1)
+	}
+	fmt.Println(line)
+}
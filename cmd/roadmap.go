@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"magus/game"
+	"magus/i18n"
 	"magus/player"
 	"magus/storage"
+	"magus/tree"
 	"os"
 	"strings"
 
@@ -23,16 +26,29 @@ func findSubQuests(questID string, allQuests []player.Quest) []player.Quest {
 	return subQuests
 }
 
+// questDepth возвращает глубину квеста относительно корня роадмапа.
+func questDepth(q player.Quest, rootID string, allQuests []player.Quest) int {
+	parents := make(map[string]string, len(allQuests))
+	for _, other := range allQuests {
+		parents[other.ID] = other.ParentID
+	}
+	depth := 0
+	for id := q.ID; id != rootID && id != "" && depth <= len(allQuests); id = parents[id] {
+		depth++
+	}
+	return depth
+}
+
 func Roadmap() {
 	if len(os.Args) < 3 {
-		fmt.Println("Usage: magus roadmap <quest_id>")
+		fmt.Println(i18n.T("cmd.roadmap.usage"))
 		return
 	}
 	questID := os.Args[2]
 
 	allQuests, err := storage.LoadAllQuests()
 	if err != nil {
-		fmt.Println("❌ Ошибка загрузки квестов:", err)
+		fmt.Println(i18n.T("err.load_quests"), err)
 		return
 	}
 
@@ -45,37 +61,32 @@ func Roadmap() {
 	}
 
 	if targetQuest == nil {
-		fmt.Println("⚠️ Квест с таким ID не найден.")
+		fmt.Println(i18n.T("err.quest_not_found"))
 		return
 	}
 
-	subQuests := findSubQuests(targetQuest.ID, allQuests)
+	// Подзадачи идут в ручном порядке, как в TUI
+	subQuests := findSubQuests(targetQuest.ID, tree.Sort(allQuests))
 	allRelatedQuests := append([]player.Quest{*targetQuest}, subQuests...)
 
-	var completedCount int
-	for _, q := range allRelatedQuests {
-		if q.Completed {
-			completedCount++
-		}
-	}
-
-	totalCount := len(allRelatedQuests)
-	progressPercentage := 0.0
-	if totalCount > 0 {
-		progressPercentage = float64(completedCount) / float64(totalCount)
+	// Прогресс считается по весу подзадач (HP или XP), а не по их количеству
+	progressMap := game.ProgressMap(allQuests)
+	progressPercentage := progressMap[targetQuest.ID].Ratio()
+	if targetQuest.Completed {
+		progressPercentage = 1
 	}
 
 	// --- Визуализация ---
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
-	fmt.Println(titleStyle.Render(fmt.Sprintf("🗺️ Роадмап для цели: %s", targetQuest.Title)))
+	fmt.Println(titleStyle.Render(i18n.T("cmd.roadmap.title", targetQuest.Title)))
 
 	// Прогресс-бар
 	p := progress.New(progress.WithDefaultGradient())
 	progressView := p.ViewAs(progressPercentage)
-	fmt.Printf("Прогресс: %s %.0f%%\n\n", progressView, progressPercentage*100)
+	fmt.Printf("%s %s %.0f%%\n\n", i18n.T("cmd.roadmap.progress"), progressView, progressPercentage*100)
 
 	// Список задач
-	fmt.Println("Подзадачи:")
+	fmt.Println(i18n.T("cmd.roadmap.subquests"))
 	for _, q := range allRelatedQuests {
 		// status := "⏳"
 		style := lipgloss.NewStyle()
@@ -85,11 +96,14 @@ func Roadmap() {
 		}
 
 		indent := ""
-		if q.ParentID != "" {
-			// Простой отступ для наглядности
-			indent = strings.Repeat("  ", strings.Count(q.ParentID, "")-1) + "└─ "
+		if depth := questDepth(q, targetQuest.ID, allQuests); depth > 0 {
+			indent = strings.Repeat("  ", depth-1) + "└─ "
 		}
 
-		fmt.Println(style.Render(fmt.Sprintf("%s%s", indent, q.Title)))
+		line := fmt.Sprintf("%s%s", indent, q.Title)
+		if sub, ok := progressMap[q.ID]; ok && q.ID != targetQuest.ID && !q.Completed {
+			line += fmt.Sprintf(" (%.0f%%)", sub.Ratio()*100)
+		}
+		fmt.Println(style.Render(line))
 	}
 }
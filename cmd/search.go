@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"magus/i18n"
+	"magus/search"
+	"magus/storage"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func Search() {
+	if len(os.Args) < 3 {
+		fmt.Println(i18n.T("cmd.search.usage"))
+		return
+	}
+
+	searchCmd := flag.NewFlagSet("search", flag.ExitOnError)
+	limit := searchCmd.Int("limit", 10, i18n.T("cmd.search.flag_limit"))
+	searchCmd.Parse(os.Args[2:])
+	query := strings.Join(searchCmd.Args(), " ")
+
+	quests, err := storage.LoadAllQuests()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_quests"), err)
+		return
+	}
+	archive, err := storage.LoadArchive()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_archive"), err)
+		return
+	}
+	notes, err := storage.LoadReflections()
+	if err != nil {
+		fmt.Println(i18n.T("cmd.search.err_reflections"), err)
+		return
+	}
+
+	results := search.Search(query, quests, archive, notes)
+	if len(results) == 0 {
+		fmt.Println(i18n.T("cmd.search.nothing", query))
+		return
+	}
+	if *limit > 0 && len(results) > *limit {
+		results = results[:*limit]
+	}
+
+	markStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	mark := func(s string) string { return markStyle.Render(s) }
+	for _, r := range results {
+		snippet := search.Highlight(r.Snippet, r.Highlights, mark)
+		switch r.Kind {
+		case search.KindQuest:
+			if r.Archived {
+				fmt.Printf("📦 %s {id: %s, %s}\n", r.Title, r.QuestID, i18n.T("cmd.search.archived"))
+				break
+			}
+			fmt.Printf("🎯 %s {id: %s}\n", r.Title, r.QuestID)
+		case search.KindReflection:
+			fmt.Printf("📓 %s {%s: %d}\n", r.Title, i18n.T("cmd.search.entry"), r.ReflectionIndex+1)
+		}
+		fmt.Printf("   %s\n", snippet)
+	}
+}
@@ -2,56 +2,100 @@ package cmd
 
 import (
 	"fmt"
+	"magus/checklist"
+	"magus/config"
+	"magus/deadline"
+	"magus/deps"
+	"magus/dungeon"
+	"magus/game"
+	"magus/i18n"
+	"magus/markdown"
 	"magus/player"
+	"magus/recur"
 	"magus/rpg"
+	"magus/snooze"
+	"magus/storage"
+	"os"
+	"strings"
+	"time"
 )
 
 func Show() {
+	// С аргументом показываем квест, без аргумента — игрока
+	if len(os.Args) > 2 {
+		showQuest(os.Args[2])
+		return
+	}
+
 	p, err := player.LoadPlayer()
 	if err != nil {
 		if err == player.ErrPlayerNotFound {
-			fmt.Println("🔮 Игрок не найден. Создайте его, запустив `magus` без аргументов.")
+			fmt.Println(i18n.T("cmd.show.no_player"))
 			return
 		}
-		fmt.Println("❌ Не удалось прочитать player.json:", err)
+		fmt.Println(i18n.T("cmd.show.err_read_player"), err)
 		return
 	}
 
-	fmt.Printf("🧙 Имя: %s\n", p.Name)
+	fmt.Println(i18n.T("cmd.show.name", p.Name))
 	if p.Class != player.ClassNone {
-		fmt.Printf("🎖️ Класс: %s\n", p.Class)
+		fmt.Println(i18n.T("cmd.show.class", rpg.ClassName(p.Class)))
+		// Пассивные бонусы класса; их вклад виден и в характеристиках ниже
+		if passives, _ := rpg.ClassPassives(p.Class); len(passives) > 0 {
+			fmt.Println(i18n.T("cmd.show.passives"))
+			for _, passive := range passives {
+				fmt.Println(i18n.T("cmd.show.passive", passive.Name, passive.Description))
+			}
+		}
 	}
-	fmt.Printf("📈 Уровень: %d\n", p.Level)
-	fmt.Printf("🔋 XP: %d / %d\n", p.XP, p.NextLevelXP)
-	fmt.Printf("✨ Очки навыков: %d\n", p.SkillPoints)
+	// Порог уровня считается по кривой опыта из настроек
+	cfg, _ := config.Load()
+	if game.SyncLevel(&game.World{Player: p, Curve: cfg.Curve()}) {
+		player.SavePlayer(p)
+	}
+	fmt.Println(i18n.T("cmd.show.level", p.Level))
+	fmt.Println(i18n.T("cmd.show.xp", p.XP, p.NextLevelXP))
+	fmt.Println(i18n.T("cmd.show.skill_points", p.SkillPoints))
+	if p.History.FocusMinutes > 0 {
+		fmt.Println(i18n.T("cmd.show.focus_total", dungeon.FormatMinutes(p.History.FocusMinutes)))
+	}
+	// Архивные квесты тоже входят в статистику
+	archive, _ := storage.LoadArchive()
+	if p.History.QuestsCompleted > 0 || len(archive) > 0 {
+		fmt.Println(i18n.T("cmd.show.quests_completed", p.History.QuestsCompleted, len(archive)))
+	}
+	// Откуда берутся максимумы, множитель XP и прочие характеристики
+	sheet := loadEffects(p)
+	fmt.Println(i18n.T("cmd.show.hp_mana", p.HP, p.MaxHP, p.Mana, p.MaxMana))
+	printEffects(sheet)
 
 	if len(p.UnlockedSkills) > 0 {
-		fmt.Println("🎁 Перки:")
+		fmt.Println(i18n.T("cmd.show.perks"))
 		skillTrees, err := rpg.LoadSkillTrees(p)
 		if err != nil {
-			fmt.Println("  Не удалось загрузить дерево навыков:", err)
+			fmt.Println(i18n.T("cmd.show.err_tree"), err)
 		} else {
-			fmt.Println("\n--- Общие навыки ---")
+			fmt.Println("\n" + i18n.T("cmd.show.common_header"))
 			if len(skillTrees.Common) == 0 {
-				fmt.Println("Нет доступных общих навыков.")
+				fmt.Println(i18n.T("cmd.show.no_common"))
 			} else {
 				for _, node := range skillTrees.Common {
 					unlocked := ""
 					if rpg.IsSkillUnlocked(p, node.ID) {
-						unlocked = "[ИЗУЧЕНО]"
+						unlocked = i18n.T("cmd.show.learned")
 					}
 					fmt.Printf("- %s %s\n  %s\n", node.Name, unlocked, node.Description)
 				}
 			}
 
-			fmt.Println("\n--- Классовые навыки ---")
+			fmt.Println("\n" + i18n.T("cmd.show.class_header"))
 			if len(skillTrees.Class) == 0 {
-				fmt.Println("Нет доступных классовых навыков.")
+				fmt.Println(i18n.T("cmd.show.no_class"))
 			} else {
 				for _, node := range skillTrees.Class {
 					unlocked := ""
 					if rpg.IsSkillUnlocked(p, node.ID) {
-						unlocked = "[ИЗУЧЕНО]"
+						unlocked = i18n.T("cmd.show.learned")
 					}
 					fmt.Printf("- %s %s\n  %s\n", node.Name, unlocked, node.Description)
 				}
@@ -59,3 +103,86 @@ func Show() {
 		}
 	}
 }
+
+// showQuest печатает подробности квеста и его фокус-сессии.
+func showQuest(questID string) {
+	quests, err := storage.LoadAllQuests()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_quests"), err)
+		return
+	}
+	index := deps.Index(quests)
+	q, ok := index[questID]
+	archived := false
+	if !ok {
+		// Квест мог уйти в архив
+		archive, _ := storage.LoadArchive()
+		q, archived = deps.Index(archive)[questID]
+		if !archived {
+			fmt.Println(i18n.T("err.quest_not_found"))
+			return
+		}
+	}
+
+	fmt.Println(i18n.T("cmd.show.quest_title", q.Title))
+	fmt.Println(i18n.T("cmd.show.quest_type", i18n.T("quest.type."+string(q.Type))))
+	if q.Completed {
+		fmt.Println(i18n.T("cmd.show.quest_completed", q.CompletedAt.Format("2006-01-02 15:04")))
+	}
+	if archived {
+		fmt.Println(i18n.T("cmd.show.quest_archived", q.ID))
+	}
+	if q.Priority != player.PriorityNone {
+		fmt.Println(i18n.T("cmd.show.quest_priority", i18n.T("quest.priority."+string(q.Priority))))
+	}
+	if q.Type == player.TypeFocus && q.HP > 0 {
+		fmt.Println(i18n.T("cmd.show.quest_hp", q.Progress, q.HP))
+	}
+	fmt.Println(i18n.T("cmd.show.quest_xp", q.XP))
+	if summary := checklist.Summary(q); summary != "" {
+		fmt.Println(i18n.T("cmd.show.quest_checklist", summary))
+	}
+	if len(q.Tags) > 0 {
+		fmt.Println(i18n.T("cmd.show.quest_tags", "#"+strings.Join(q.Tags, " #")))
+	}
+	if q.Deadline != nil {
+		fmt.Println(i18n.T("cmd.show.quest_deadline", deadline.Format(*q.Deadline, q.DeadlineTime)))
+	}
+	if snooze.Deferred(q, time.Now()) {
+		fmt.Println(i18n.T("cmd.show.quest_deferred", q.StartAt.Format("2006-01-02 15:04")))
+	}
+	if summary := recur.Summary(q); summary != "" {
+		fmt.Println("🔁 " + summary)
+	}
+	if blockers := deps.OpenBlockers(q, index); len(blockers) > 0 {
+		var titles []string
+		for _, b := range blockers {
+			titles = append(titles, b.Title)
+		}
+		fmt.Println(i18n.T("cmd.list.blocked_by", strings.Join(titles, ", ")))
+	}
+
+	if q.Description != "" {
+		fmt.Println("\n" + markdown.Render(q.Description, 0) + "\n")
+	}
+	if len(q.Notes) > 0 {
+		fmt.Println(i18n.T("cmd.show.quest_notes"))
+		for _, n := range q.Notes {
+			text := strings.ReplaceAll(strings.TrimSpace(n.Text), "\n", "\n                    ")
+			fmt.Printf("  %s  %s\n", n.Date.Format("2006-01-02 15:04"), text)
+		}
+	}
+	if len(q.Links) > 0 {
+		fmt.Println(i18n.T("cmd.show.quest_links"))
+		for _, link := range q.Links {
+			fmt.Printf("  - %s\n", link)
+		}
+	}
+
+	fmt.Println(i18n.T("cmd.show.quest_focus", dungeon.FormatMinutes(q.FocusMinutes)))
+	sessions, _ := storage.LoadSessions()
+	found, minutes := storage.QuestSessions(sessions, q.ID)
+	for i, s := range found {
+		fmt.Printf("  %s  %s\n", s.Date.Format("2006-01-02 15:04"), dungeon.FormatMinutes(minutes[i]))
+	}
+}
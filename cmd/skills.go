@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"fmt"
+	"magus/effects"
+	"magus/game"
+	"magus/i18n"
+	"magus/player"
+	"magus/rpg"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func Skills() {
+	sub := "list"
+	if len(os.Args) >= 3 {
+		sub = os.Args[2]
+	}
+
+	p, err := player.LoadPlayer()
+	if err != nil {
+		if err == player.ErrPlayerNotFound {
+			fmt.Println(i18n.T("cmd.show.no_player"))
+			return
+		}
+		fmt.Println(i18n.T("cmd.show.err_read_player"), err)
+		return
+	}
+
+	trees, err := rpg.LoadSkillTrees(p)
+	if err != nil {
+		fmt.Println(i18n.T("cmd.show.err_tree"), err)
+		return
+	}
+
+	switch sub {
+	case "list":
+		skillsList(p, trees)
+	case "tree":
+		skillsTree(p, trees)
+	case "show", "unlock":
+		if len(os.Args) < 4 {
+			fmt.Println(i18n.T("cmd.skills.usage"))
+			return
+		}
+		node, ok := trees.AllSkills()[os.Args[3]]
+		if !ok {
+			fmt.Println(i18n.T("cmd.skills.not_found", os.Args[3]))
+			return
+		}
+		if sub == "show" {
+			skillsShow(p, trees, node)
+		} else {
+			skillsUnlock(p, trees, node)
+		}
+	default:
+		fmt.Println(i18n.T("cmd.skills.usage"))
+	}
+}
+
+func skillsList(p *player.Player, trees rpg.SkillTrees) {
+	fmt.Println(i18n.T("cmd.show.skill_points", p.SkillPoints))
+	all := trees.AllSkills()
+	for _, section := range skillSections(trees) {
+		fmt.Println("\n" + section.header)
+		_, ids := rpg.BuildSkillGraph(section.skills)
+		if len(ids) == 0 {
+			fmt.Println(section.empty)
+			continue
+		}
+		for _, id := range ids {
+			node := section.skills[id]
+			fmt.Printf("%s %s %s (%s)\n", skillMarker(rpg.GetSkillStatus(p, node, all)), node.Icon, node.Name, node.ID)
+		}
+	}
+}
+
+func skillsTree(p *player.Player, trees rpg.SkillTrees) {
+	fmt.Println(i18n.T("cmd.show.skill_points", p.SkillPoints))
+	all := trees.AllSkills()
+	for _, section := range skillSections(trees) {
+		fmt.Println("\n" + section.header)
+		g, ids := rpg.BuildSkillGraph(section.skills)
+		if len(ids) == 0 {
+			fmt.Println(section.empty)
+			continue
+		}
+		fmt.Print(rpg.RenderSkillTree(g, func(node player.SkillNode) string {
+			return fmt.Sprintf("%s %s %s (%s)", skillMarker(rpg.GetSkillStatus(p, node, all)), node.Icon, node.Name, node.ID)
+		}))
+	}
+	fmt.Println("\n" + i18n.T("cmd.skills.legend"))
+}
+
+func skillsShow(p *player.Player, trees rpg.SkillTrees, node player.SkillNode) {
+	all := trees.AllSkills()
+	fmt.Printf("%s %s (%s)\n", node.Icon, node.Name, node.ID)
+	fmt.Println(i18n.T("cmd.skills.type", node.Type))
+	fmt.Println(i18n.T("cmd.skills.status", skillStatusText(rpg.GetSkillStatus(p, node, all))))
+	fmt.Println("\n" + node.Description + "\n")
+
+	if len(node.Requirements) == 0 {
+		fmt.Println(i18n.T("skill.reqs_none"))
+	} else {
+		var reqs []string
+		for _, reqID := range node.Requirements {
+			var name string
+			var met bool
+			if level, found := strings.CutPrefix(reqID, "level_"); found {
+				reqLevel, _ := strconv.Atoi(level)
+				name = i18n.T("skill.req_level", level)
+				met = p.Level >= reqLevel
+			} else {
+				name = reqID
+				if req, ok := all[reqID]; ok {
+					name = req.Name
+				}
+				met = rpg.IsSkillUnlocked(p, reqID)
+			}
+			mark := "✗"
+			if met {
+				mark = "✓"
+			}
+			reqs = append(reqs, fmt.Sprintf("%s %s", name, mark))
+		}
+		fmt.Println(i18n.T("skill.reqs") + strings.Join(reqs, ", "))
+	}
+
+	if len(node.Effects) > 0 {
+		keys := make([]string, 0, len(node.Effects))
+		for k := range node.Effects {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var described []string
+		for _, k := range keys {
+			described = append(described, effects.Describe(k, node.Effects[k]))
+		}
+		fmt.Println(i18n.T("cmd.skills.effects") + strings.Join(described, ", "))
+	}
+}
+
+func skillsUnlock(p *player.Player, trees rpg.SkillTrees, node player.SkillNode) {
+	events, err := game.UnlockSkill(&game.World{Player: p}, node, trees.AllSkills())
+	switch err {
+	case nil:
+		// Новый навык сразу меняет максимум HP и маны
+		passives, _ := rpg.ClassPassives(p.Class)
+		effects.Compute(p, trees.AllSkills(), passives).Apply(p)
+		if err := player.SavePlayer(p); err != nil {
+			fmt.Println(i18n.T("cmd.skills.err_save"), err)
+			return
+		}
+		printEvents(events)
+		fmt.Println(i18n.T("cmd.show.skill_points", p.SkillPoints))
+	case rpg.ErrSkillUnlocked:
+		fmt.Println(i18n.T("skill.already_learned"))
+	case rpg.ErrNoSkillPoints:
+		fmt.Println(i18n.T("skill.no_points"))
+	default:
+		fmt.Println(i18n.T("skill.reqs_not_met", node.Name))
+	}
+}
+
+type skillSection struct {
+	header string
+	empty  string
+	skills map[string]player.SkillNode
+}
+
+func skillSections(trees rpg.SkillTrees) []skillSection {
+	return []skillSection{
+		{i18n.T("cmd.show.common_header"), i18n.T("cmd.show.no_common"), trees.Common},
+		{i18n.T("cmd.show.class_header"), i18n.T("cmd.show.no_class"), trees.Class},
+	}
+}
+
+// skillMarker возвращает текстовую метку состояния навыка для CLI.
+func skillMarker(status rpg.SkillStatus) string {
+	switch status {
+	case rpg.SkillUnlocked:
+		return "[✓]"
+	case rpg.SkillAvailable:
+		return "[+]"
+	case rpg.SkillNoPoints:
+		return "[!]"
+	default:
+		return "[ ]"
+	}
+}
+
+func skillStatusText(status rpg.SkillStatus) string {
+	switch status {
+	case rpg.SkillUnlocked:
+		return i18n.T("skill.status_learned")
+	case rpg.SkillAvailable:
+		return i18n.T("skill.status_available")
+	case rpg.SkillNoPoints:
+		return i18n.T("skill.status_no_points")
+	default:
+		return i18n.T("skill.status_locked")
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"magus/config"
+	"magus/i18n"
+	"magus/player"
+	"magus/snooze"
+	"magus/storage"
+	"os"
+	"time"
+)
+
+// Snooze откладывает квест: magus snooze <id> <срок>, где срок — 4h, 3d,
+// 2w или ГГГГ-ММ-ДД. magus snooze <id> off возвращает квест сразу.
+func Snooze() {
+	if len(os.Args) < 4 {
+		fmt.Println(i18n.T("cmd.snooze.usage"))
+		return
+	}
+	questID, when := os.Args[2], os.Args[3]
+
+	quests, err := storage.LoadAllQuests()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_quests"), err)
+		return
+	}
+	i := -1
+	for j, q := range quests {
+		if q.ID == questID {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		fmt.Println(i18n.T("err.quest_not_found"))
+		return
+	}
+
+	now := time.Now()
+	if when == "off" {
+		snooze.Wake(&quests[i])
+	} else {
+		cfg, _ := config.Load()
+		until, err := snooze.Parse(when, now, cfg.DayStartHour)
+		if err != nil {
+			fmt.Println(i18n.T("cmd.snooze.err_duration", when))
+			return
+		}
+		snooze.Until(&quests[i], until, now)
+	}
+
+	if err := storage.SaveAllQuests(quests); err != nil {
+		fmt.Println(i18n.T("err.save_quests"), err)
+		return
+	}
+	if q := quests[i]; q.StartAt != nil {
+		fmt.Println(i18n.T("cmd.snooze.done", q.Title, q.StartAt.Format("2006-01-02 15:04")))
+	} else {
+		fmt.Println(i18n.T("cmd.snooze.woken", q.Title))
+	}
+}
+
+// printUpcoming печатает отложенные квесты в порядке даты начала.
+func printUpcoming(quests []player.Quest, now time.Time) {
+	upcoming := snooze.Upcoming(quests, now)
+	if len(upcoming) == 0 {
+		fmt.Println(i18n.T("cmd.list.upcoming_empty"))
+		return
+	}
+	fmt.Println(i18n.T("cmd.list.header_upcoming"))
+	for _, q := range upcoming {
+		fmt.Printf("  %s  %s {id: %s}\n", q.StartAt.Format("2006-01-02 15:04"), q.Title, q.ID)
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"magus/i18n"
+	"magus/storage"
+	"magus/tags"
+	"os"
+	"strings"
+)
+
+// Tags показывает дерево тегов со статистикой или переименовывает тег:
+// magus tags rename <старый> <новый>. Переименование в существующий тег
+// сливает теги.
+func Tags() {
+	if len(os.Args) > 2 {
+		if os.Args[2] != "rename" || len(os.Args) < 5 {
+			fmt.Println(i18n.T("cmd.tags.usage"))
+			return
+		}
+		renameTag(tags.Normalize(os.Args[3]), os.Args[4])
+		return
+	}
+
+	quests, err := storage.LoadAllQuests()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_quests"), err)
+		return
+	}
+	archive, _ := storage.LoadArchive()
+	registry, err := tags.Load()
+	if err != nil {
+		fmt.Println(i18n.T("cmd.tags.err_load"), err)
+	}
+
+	usage := tags.Collect(append(quests, archive...))
+	if len(usage) == 0 {
+		fmt.Println(i18n.T("cmd.tags.empty"))
+		return
+	}
+	for _, u := range usage {
+		name := u.Tag
+		if parent := tags.Parent(u.Tag); parent != "" {
+			name = u.Tag[len(parent)+1:]
+		}
+		line := fmt.Sprintf("%s#%s  %s", strings.Repeat("  ", tags.Depth(u.Tag)), name, i18n.T("tui.tags.usage", u.Open, u.Completed, u.XP))
+		own := registry[u.Tag]
+		if own.XPMultiplier > 0 {
+			line += fmt.Sprintf("  ×%g", own.XPMultiplier)
+		}
+		if own.Stat != "" {
+			line += "  " + i18n.T("tui.tags.trains", i18n.T("stat."+own.Stat))
+		}
+		fmt.Println(line)
+	}
+}
+
+// renameTag переименовывает тег в квестах, архиве и настройках тегов.
+func renameTag(old, new string) {
+	quests, err := storage.LoadAllQuests()
+	if err != nil {
+		fmt.Println(i18n.T("err.load_quests"), err)
+		return
+	}
+	archive, _ := storage.LoadArchive()
+
+	n, err := tags.Rename(quests, old, new)
+	if errors.Is(err, tags.ErrIntoItself) {
+		fmt.Println(i18n.T("tui.tags.err_into_itself", old, new))
+		return
+	}
+	archived, _ := tags.Rename(archive, old, new)
+	if n+archived == 0 {
+		fmt.Println(i18n.T("cmd.tags.not_found", old))
+		return
+	}
+
+	if err := storage.SaveAllQuests(quests); err != nil {
+		fmt.Println(i18n.T("err.save_quests"), err)
+		return
+	}
+	if archived > 0 {
+		if err := storage.SaveArchive(archive); err != nil {
+			fmt.Println(i18n.T("err.save_archive"), err)
+			return
+		}
+	}
+	if registry, err := tags.Load(); err == nil && registry.Rename(old, tags.Normalize(new)) {
+		if err := tags.Save(registry); err != nil {
+			fmt.Println(i18n.T("cmd.tags.err_save"), err)
+		}
+	}
+	fmt.Println(i18n.T("tui.tags.renamed", old, tags.Normalize(new), n+archived))
+}
+
+// loadTags загружает настройки тегов для правил игры. Без файла или при
+// ошибке теги просто не дают бонусов.
+func loadTags() tags.Registry {
+	registry, err := tags.Load()
+	if err != nil {
+		fmt.Println(i18n.T("cmd.tags.err_load"), err)
+	}
+	return registry
+}
@@ -2,12 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"magus/i18n"
 	"magus/player"
 	"time"
 )
 
 func Version() {
-	fmt.Println("🧙 Magus v0.1.0")
+	fmt.Println(i18n.T("cmd.version", "0.1.0"))
 }
 
 // isToday проверяет, является ли дата сегодняшней.
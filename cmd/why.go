@@ -2,10 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"magus/i18n"
 )
 
 func Why() {
-	fmt.Println("🧭 Зачем ты тут, маг:")
-	fmt.Println("Ты создаешь инструмент, чтобы фокусироваться и прокачивать себя в реальности.")
-	fmt.Println("Каждый квест — шаг к твоему уровню и цели. Не сдавайся.")
+	fmt.Println(i18n.T("cmd.why.title"))
+	fmt.Println(i18n.T("cmd.why.line1"))
+	fmt.Println(i18n.T("cmd.why.line2"))
 }
@@ -0,0 +1,140 @@
+// Package config хранит пользовательские настройки Magus.
+package config
+
+import (
+	"encoding/json"
+	"magus/leveling"
+	"os"
+	"time"
+)
+
+var ConfigFile = "data/config.json"
+
+// Config — настройки из data/config.json. Пустые поля означают значения по умолчанию.
+type Config struct {
+	// Language — язык интерфейса ("ru", "en"). Если пусто, берется из LANG.
+	Language string `json:"language,omitempty"`
+	// DayStartHour — час, с которого начинается новый день (0-23).
+	// Например, 4 означает, что день заканчивается в 4 утра.
+	DayStartHour int `json:"day_start_hour,omitempty"`
+	// PromptFormat — шаблон строки `magus prompt`. Если пусто, используется формат по умолчанию.
+	PromptFormat string `json:"prompt_format,omitempty"`
+	// Urgency переопределяет коэффициенты срочности, например {"due": 15, "tag.work": 2}.
+	// Полный список ключей — в пакете urgency.
+	Urgency map[string]float64 `json:"urgency,omitempty"`
+	// ArchiveAfterDays — через сколько дней выполненные квесты уходят в архив.
+	// 0 — значение по умолчанию (DefaultArchiveAfterDays), отрицательное — не архивировать.
+	ArchiveAfterDays int `json:"archive_after_days,omitempty"`
+	// ChecklistProgress — отметка пункта чек-листа на фокус-квесте наносит ему урон
+	// (доля HP на пункт), так что весь чек-лист побеждает квест.
+	ChecklistProgress bool `json:"checklist_progress,omitempty"`
+	// OverduePenalty — штраф за каждый день просрочки, начисляемый при запуске.
+	// Пустые поля — значения по умолчанию, отрицательные — штрафа нет.
+	OverduePenalty OverduePenalty `json:"overdue_penalty,omitzero"`
+	// Leveling — кривая опыта, например {"type": "polynomial", "coefficients": [50, 50]},
+	// {"type": "table", "table": [100, 250, 500]} или {"type": "custom", "formula": "100 * level^2"}.
+	// Если пусто — 100·level² (см. пакет leveling).
+	Leveling leveling.Spec `json:"leveling,omitzero"`
+}
+
+// Curve возвращает кривую опыта. Ошибочное описание заменяется кривой по умолчанию.
+func (c *Config) Curve() leveling.Curve {
+	curve, err := leveling.New(c.Leveling)
+	if err != nil {
+		return leveling.Default
+	}
+	return curve
+}
+
+// DefaultArchiveAfterDays — срок архивации выполненных квестов по умолчанию.
+const DefaultArchiveAfterDays = 7
+
+// ArchiveCutoff возвращает момент, раньше которого выполненные квесты
+// уходят в архив. false означает, что архивация отключена.
+func (c *Config) ArchiveCutoff(now time.Time) (time.Time, bool) {
+	days := c.ArchiveAfterDays
+	if days < 0 {
+		return time.Time{}, false
+	}
+	if days == 0 {
+		days = DefaultArchiveAfterDays
+	}
+	return now.AddDate(0, 0, -days), true
+}
+
+// OverduePenalty — штраф за день просрочки квеста.
+type OverduePenalty struct {
+	HP      int `json:"hp,omitempty"`       // Потеря HP игрока
+	XPDecay int `json:"xp_decay,omitempty"` // На сколько процентов уменьшается награда квеста
+}
+
+// Штраф за день просрочки по умолчанию.
+const (
+	DefaultOverdueHP      = 2
+	DefaultOverdueXPDecay = 10
+)
+
+// OverdueHP возвращает потерю HP за день просрочки.
+func (c *Config) OverdueHP() int {
+	switch hp := c.OverduePenalty.HP; {
+	case hp < 0:
+		return 0
+	case hp == 0:
+		return DefaultOverdueHP
+	default:
+		return hp
+	}
+}
+
+// OverdueXPDecay возвращает, на сколько процентов за день просрочки
+// уменьшается награда квеста.
+func (c *Config) OverdueXPDecay() int {
+	switch decay := c.OverduePenalty.XPDecay; {
+	case decay < 0:
+		return 0
+	case decay == 0:
+		return DefaultOverdueXPDecay
+	default:
+		return min(decay, 100)
+	}
+}
+
+// Default возвращает настройки по умолчанию.
+func Default() *Config {
+	return &Config{}
+}
+
+// Load загружает настройки. Если файла нет, возвращаются значения по умолчанию.
+func Load() (*Config, error) {
+	cfg := Default()
+	data, err := os.ReadFile(ConfigFile)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return Default(), err
+	}
+	if cfg.DayStartHour < 0 || cfg.DayStartHour > 23 {
+		cfg.DayStartHour = 0
+	}
+	if _, err := leveling.New(cfg.Leveling); err != nil {
+		cfg.Leveling = leveling.Spec{}
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// Save сохраняет настройки в файл.
+func Save(cfg *Config) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat("data"); os.IsNotExist(err) {
+		os.Mkdir("data", 0755)
+	}
+	return os.WriteFile(ConfigFile, data, 0644)
+}
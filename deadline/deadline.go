@@ -0,0 +1,103 @@
+// Package deadline — сроки квестов в местном часовом поясе.
+//
+// Срок задается датой ("2025-09-01") или датой со временем
+// ("2025-09-01 18:00"). Срок без времени хранится как конец дня
+// (23:59:59) и действует до полуночи. Было ли время указано явно,
+// хранится в квесте (Quest.DeadlineTime), а не выводится из часов:
+// срок "2025-09-01 00:00" наступает в полночь. Без этой отметки срок
+// ровно в полночь считается сроком без времени: так сохранялись старые
+// сроки (по UTC).
+package deadline
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"magus/utils"
+)
+
+const (
+	DateLayout     = "2006-01-02"
+	DateTimeLayout = "2006-01-02 15:04"
+)
+
+var ErrBadDeadline = errors.New("bad deadline")
+
+// Parse разбирает срок в местном часовом поясе: "ГГГГ-ММ-ДД" или
+// "ГГГГ-ММ-ДД ЧЧ:ММ" (также через "T"). timed сообщает, что время
+// указано явно.
+func Parse(s string) (t time.Time, timed bool, err error) {
+	s = strings.Replace(strings.TrimSpace(s), "T", " ", 1)
+	if t, err := time.ParseInLocation(DateTimeLayout, s, time.Local); err == nil {
+		return t, true, nil
+	}
+	t, err = time.ParseInLocation(DateLayout, s, time.Local)
+	if err != nil {
+		return time.Time{}, false, ErrBadDeadline
+	}
+	return EndOfDay(t), false, nil
+}
+
+// EndOfDay возвращает срок без времени на дату t.
+func EndOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, t.Location())
+}
+
+// DateOnly сообщает, что срок задан без времени. timed — время указано
+// явно (см. Parse); без этой отметки срок без времени узнается по концу
+// дня или полуночи старых сохранений.
+func DateOnly(t time.Time, timed bool) bool {
+	if timed {
+		return false
+	}
+	h, m, s := t.Clock()
+	return h == 0 && m == 0 && s == 0 || h == 23 && m == 59 && s == 59
+}
+
+// Due возвращает момент, когда квест становится просроченным. Срок без
+// времени действует до полуночи, следующей за его датой.
+func Due(t time.Time, timed bool) time.Time {
+	if !DateOnly(t, timed) {
+		return t
+	}
+	loc := t.Location()
+	if loc == time.UTC {
+		// Старый формат: дата сохранялась как полночь по UTC
+		loc = time.Local
+	}
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+}
+
+// Overdue сообщает, что срок t прошел к моменту now.
+func Overdue(t time.Time, timed bool, now time.Time) bool {
+	return !now.Before(Due(t, timed))
+}
+
+// Format возвращает срок в том виде, в котором его вводят: дату или
+// дату со временем по местному времени.
+func Format(t time.Time, timed bool) string {
+	if DateOnly(t, timed) {
+		return t.Format(DateLayout)
+	}
+	return t.In(time.Local).Format(DateTimeLayout)
+}
+
+// OverdueDays возвращает число игровых дней просрочки, начавшихся после
+// момента since и не позже now. Нулевой since считает дни с самого срока.
+func OverdueDays(t time.Time, timed bool, since, now time.Time, dayStartHour int) int {
+	from := Due(t, timed)
+	if since.After(from) {
+		from = since.Add(time.Nanosecond) // Начало дня ровно в since уже учтено
+	}
+	day := utils.DayStart(from, dayStartHour)
+	if day.Before(from) {
+		day = day.AddDate(0, 0, 1)
+	}
+
+	days := 0
+	for ; !day.After(now); day = day.AddDate(0, 0, 1) {
+		days++
+	}
+	return days
+}
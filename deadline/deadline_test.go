@@ -0,0 +1,93 @@
+package deadline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAndFormat(t *testing.T) {
+	d, timed, err := Parse("2025-09-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timed || !DateOnly(d, timed) || Format(d, timed) != "2025-09-01" {
+		t.Errorf("date-only deadline = %v (%q)", d, Format(d, timed))
+	}
+	if want := time.Date(2025, 9, 2, 0, 0, 0, 0, time.Local); !Due(d, timed).Equal(want) {
+		t.Errorf("Due() = %v, want local midnight %v", Due(d, timed), want)
+	}
+
+	for _, in := range []string{"2025-09-01 18:30", "2025-09-01T18:30"} {
+		d, timed, err := Parse(in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", in, err)
+		}
+		if !timed || DateOnly(d, timed) || Format(d, timed) != "2025-09-01 18:30" || !Due(d, timed).Equal(d) {
+			t.Errorf("Parse(%q) = %v (%q)", in, d, Format(d, timed))
+		}
+	}
+
+	if _, _, err := Parse("завтра"); err != ErrBadDeadline {
+		t.Errorf("err = %v, want ErrBadDeadline", err)
+	}
+}
+
+func TestExplicitMidnight(t *testing.T) {
+	d, timed, err := Parse("2026-10-20 00:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !timed || DateOnly(d, timed) || Format(d, timed) != "2026-10-20 00:00" {
+		t.Errorf("explicit midnight treated as date-only: %q", Format(d, timed))
+	}
+	if !Due(d, timed).Equal(d) {
+		t.Errorf("Due() = %v, want %v", Due(d, timed), d)
+	}
+	if !Overdue(d, timed, d.Add(time.Minute)) {
+		t.Error("explicit midnight not overdue after it passed")
+	}
+}
+
+func TestLegacyUTCDeadline(t *testing.T) {
+	legacy := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	if !DateOnly(legacy, false) || Format(legacy, false) != "2025-09-01" {
+		t.Errorf("legacy deadline formatted as %q", Format(legacy, false))
+	}
+	if want := time.Date(2025, 9, 2, 0, 0, 0, 0, time.Local); !Due(legacy, false).Equal(want) {
+		t.Errorf("Due(legacy) = %v, want %v", Due(legacy, false), want)
+	}
+}
+
+func TestOverdueDays(t *testing.T) {
+	d := time.Date(2025, 9, 1, 23, 59, 59, 0, time.Local)
+	at := func(day, hour int) time.Time { return time.Date(2025, 9, day, hour, 0, 0, 0, time.Local) }
+
+	if Overdue(d, false, at(1, 23)) || OverdueDays(d, false, time.Time{}, at(1, 23), 0) != 0 {
+		t.Error("deadline overdue before the end of its day")
+	}
+	if got := OverdueDays(d, false, time.Time{}, at(2, 1), 0); got != 1 {
+		t.Errorf("first overdue day = %d, want 1", got)
+	}
+	// Граница дня в 4 утра: до нее идет еще день срока
+	if got := OverdueDays(d, false, time.Time{}, at(2, 1), 4); got != 0 {
+		t.Errorf("overdue days before 04:00 = %d, want 0", got)
+	}
+	if got := OverdueDays(d, false, time.Time{}, at(4, 12), 0); got != 3 {
+		t.Errorf("overdue days = %d, want 3", got)
+	}
+	// Дни, за которые штраф уже начислен, не считаются повторно
+	if got := OverdueDays(d, false, at(3, 9), at(4, 12), 0); got != 1 {
+		t.Errorf("new overdue days = %d, want 1", got)
+	}
+	if got := OverdueDays(d, false, at(4, 12), at(4, 18), 0); got != 0 {
+		t.Errorf("same day counted twice: %d", got)
+	}
+
+	timed := time.Date(2025, 9, 1, 18, 0, 0, 0, time.Local)
+	if !Overdue(timed, true, at(1, 19)) {
+		t.Error("timed deadline not overdue after its time")
+	}
+	if got := OverdueDays(timed, true, time.Time{}, at(2, 0), 0); got != 1 {
+		t.Errorf("timed overdue days = %d, want 1", got)
+	}
+}
@@ -0,0 +1,139 @@
+// Package deps хранит связи «заблокирован» между квестами.
+//
+// Квест может ждать любые другие квесты, не только родителя: связи
+// образуют направленный ациклический граф, ребро ведет от блокирующего
+// квеста к заблокированному. Квест заблокирован, пока хотя бы один из
+// его блокирующих квестов не выполнен.
+package deps
+
+import (
+	"errors"
+	"magus/player"
+
+	"github.com/dominikbraun/graph"
+)
+
+var (
+	ErrQuestNotFound = errors.New("quest not found")
+	ErrSelfBlock     = errors.New("quest can't block itself")
+	ErrCycle         = errors.New("dependency would create a cycle")
+	ErrNotBlocked    = errors.New("quest is not blocked by this quest")
+)
+
+// Хеш-функция для player.Quest, необходимая для библиотеки graph.
+func questHash(q player.Quest) string {
+	return q.ID
+}
+
+// Build строит граф зависимостей. Ссылки на несуществующие квесты и
+// ребра, замыкающие цикл (например, после ручной правки файла), пропускаются.
+func Build(quests []player.Quest) graph.Graph[string, player.Quest] {
+	g := graph.New(questHash, graph.Directed(), graph.PreventCycles())
+	for _, q := range quests {
+		_ = g.AddVertex(q)
+	}
+	for _, q := range quests {
+		for _, blockerID := range q.BlockedBy {
+			_ = g.AddEdge(blockerID, q.ID)
+		}
+	}
+	return g
+}
+
+// Index возвращает квесты по ID.
+func Index(quests []player.Quest) map[string]player.Quest {
+	index := make(map[string]player.Quest, len(quests))
+	for _, q := range quests {
+		index[q.ID] = q
+	}
+	return index
+}
+
+// OpenBlockers возвращает невыполненные квесты, которые блокируют q.
+func OpenBlockers(q player.Quest, index map[string]player.Quest) []player.Quest {
+	var open []player.Quest
+	for _, id := range q.BlockedBy {
+		if blocker, ok := index[id]; ok && !blocker.Completed {
+			open = append(open, blocker)
+		}
+	}
+	return open
+}
+
+// IsBlocked сообщает, что квест ждет невыполненные квесты.
+func IsBlocked(q player.Quest, index map[string]player.Quest) bool {
+	return len(OpenBlockers(q, index)) > 0
+}
+
+// Ready возвращает невыполненные и незаблокированные квесты в исходном порядке.
+func Ready(quests []player.Quest) []player.Quest {
+	index := Index(quests)
+	var ready []player.Quest
+	for _, q := range quests {
+		if !q.Completed && !IsBlocked(q, index) {
+			ready = append(ready, q)
+		}
+	}
+	return ready
+}
+
+// AddBlocker добавляет связь «questID ждет blockerID», не допуская циклов.
+func AddBlocker(quests []player.Quest, questID, blockerID string) error {
+	if questID == blockerID {
+		return ErrSelfBlock
+	}
+	i := find(quests, questID)
+	if i < 0 || find(quests, blockerID) < 0 {
+		return ErrQuestNotFound
+	}
+
+	err := Build(quests).AddEdge(blockerID, questID)
+	switch {
+	case errors.Is(err, graph.ErrEdgeAlreadyExists):
+		return nil
+	case errors.Is(err, graph.ErrEdgeCreatesCycle):
+		return ErrCycle
+	case err != nil:
+		return err
+	}
+	quests[i].BlockedBy = append(quests[i].BlockedBy, blockerID)
+	return nil
+}
+
+// RemoveBlocker удаляет связь «questID ждет blockerID».
+func RemoveBlocker(quests []player.Quest, questID, blockerID string) error {
+	i := find(quests, questID)
+	if i < 0 {
+		return ErrQuestNotFound
+	}
+	for j, id := range quests[i].BlockedBy {
+		if id == blockerID {
+			quests[i].BlockedBy = append(quests[i].BlockedBy[:j], quests[i].BlockedBy[j+1:]...)
+			return nil
+		}
+	}
+	return ErrNotBlocked
+}
+
+// Prune убирает ссылки на удаленные квесты.
+func Prune(quests []player.Quest) {
+	index := Index(quests)
+	for i := range quests {
+		var kept []string
+		for _, id := range quests[i].BlockedBy {
+			if _, ok := index[id]; ok {
+				kept = append(kept, id)
+			}
+		}
+		quests[i].BlockedBy = kept
+	}
+}
+
+func find(quests []player.Quest, id string) int {
+	for i, q := range quests {
+		if q.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,77 @@
+package deps
+
+import (
+	"errors"
+	"magus/player"
+	"testing"
+)
+
+func testQuests() []player.Quest {
+	return []player.Quest{
+		{ID: "design", Title: "Design"},
+		{ID: "build", Title: "Build"},
+		{ID: "ship", Title: "Ship"},
+	}
+}
+
+func TestAddBlockerPreventsCycles(t *testing.T) {
+	quests := testQuests()
+	if err := AddBlocker(quests, "build", "design"); err != nil {
+		t.Fatalf("AddBlocker(build, design) error = %v", err)
+	}
+	if err := AddBlocker(quests, "ship", "build"); err != nil {
+		t.Fatalf("AddBlocker(ship, build) error = %v", err)
+	}
+	// Повторная связь не дублируется
+	if err := AddBlocker(quests, "ship", "build"); err != nil || len(quests[2].BlockedBy) != 1 {
+		t.Errorf("duplicate AddBlocker: err = %v, BlockedBy = %v", err, quests[2].BlockedBy)
+	}
+
+	if err := AddBlocker(quests, "design", "ship"); !errors.Is(err, ErrCycle) {
+		t.Errorf("AddBlocker(design, ship) error = %v, want ErrCycle", err)
+	}
+	if err := AddBlocker(quests, "design", "design"); !errors.Is(err, ErrSelfBlock) {
+		t.Errorf("AddBlocker(design, design) error = %v, want ErrSelfBlock", err)
+	}
+	if err := AddBlocker(quests, "design", "missing"); !errors.Is(err, ErrQuestNotFound) {
+		t.Errorf("AddBlocker(design, missing) error = %v, want ErrQuestNotFound", err)
+	}
+}
+
+func TestReady(t *testing.T) {
+	quests := testQuests()
+	AddBlocker(quests, "build", "design")
+	AddBlocker(quests, "ship", "build")
+
+	ready := Ready(quests)
+	if len(ready) != 1 || ready[0].ID != "design" {
+		t.Fatalf("Ready() = %v, want only design", ready)
+	}
+
+	// Выполненный блокирующий квест больше не держит
+	quests[0].Completed = true
+	ready = Ready(quests)
+	if len(ready) != 1 || ready[0].ID != "build" {
+		t.Errorf("Ready() after design = %v, want only build", ready)
+	}
+}
+
+func TestRemoveAndPrune(t *testing.T) {
+	quests := testQuests()
+	AddBlocker(quests, "ship", "build")
+	AddBlocker(quests, "ship", "design")
+
+	if err := RemoveBlocker(quests, "ship", "build"); err != nil {
+		t.Fatalf("RemoveBlocker() error = %v", err)
+	}
+	if err := RemoveBlocker(quests, "ship", "build"); !errors.Is(err, ErrNotBlocked) {
+		t.Errorf("second RemoveBlocker() error = %v, want ErrNotBlocked", err)
+	}
+
+	// Удаляем design — ссылка на него должна исчезнуть
+	quests = quests[1:]
+	Prune(quests)
+	if len(quests[1].BlockedBy) != 0 {
+		t.Errorf("BlockedBy after Prune = %v, want empty", quests[1].BlockedBy)
+	}
+}
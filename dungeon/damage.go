@@ -0,0 +1,60 @@
+package dungeon
+
+import (
+	"magus/player"
+	"magus/recur"
+	"time"
+)
+
+const (
+	// DistractionPenalty — штраф к урону за каждое реальное отвлечение.
+	DistractionPenalty = 10
+	// MaxDistractionPenalty ограничивает суммарный штраф за отвлечения.
+	MaxDistractionPenalty = 50
+)
+
+// Damage возвращает урон, который квест получает за minutes минут фокуса:
+// минута — единица урона, bonus (надбавка навыков в процентах, см. пакет
+// effects) увеличивает его, а каждое отвлечение уменьшает. Если время было,
+// урон не меньше 1.
+func Damage(minutes, distractions, bonus int) int {
+	if minutes <= 0 {
+		return 0
+	}
+
+	penalty := distractions * DistractionPenalty
+	if penalty > MaxDistractionPenalty {
+		penalty = MaxDistractionPenalty
+	}
+	percent := 100 - penalty + bonus
+
+	damage := minutes * percent / 100
+	if damage < 1 {
+		damage = 1
+	}
+	return damage
+}
+
+// Attack наносит фокус-квесту урон и возвращает фактический урон (не больше
+// оставшегося HP) и признак победы. Побежденный квест завершается;
+// повторяющийся квест вместо этого переносится на следующий срок
+// с полным HP. Квесты без HP урон не получают.
+func Attack(q *player.Quest, damage int, now time.Time) (int, bool) {
+	if q.Type != player.TypeFocus || q.HP <= 0 || q.Completed || damage <= 0 {
+		return 0, false
+	}
+
+	if remaining := q.HP - q.Progress; damage > remaining {
+		damage = remaining
+	}
+	q.Progress += damage
+	if q.Progress < q.HP {
+		return damage, false
+	}
+
+	if !recur.Complete(q, now) {
+		q.Completed = true
+		q.CompletedAt = now
+	}
+	return damage, true
+}
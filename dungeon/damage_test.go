@@ -0,0 +1,49 @@
+package dungeon
+
+import (
+	"magus/player"
+	"testing"
+	"time"
+)
+
+func TestDamage(t *testing.T) {
+	tests := []struct {
+		minutes, distractions, bonus int
+		want                         int
+	}{
+		{25, 0, 0, 25},
+		{25, 0, 10, 27}, // +10% от навыков
+		{20, 2, 0, 16},  // −20% за два отвлечения
+		{20, 9, 0, 10},  // Штраф не больше 50%
+		{1, 9, 0, 1},    // Хотя бы единица урона
+		{0, 0, 10, 0},
+	}
+	for _, tt := range tests {
+		if got := Damage(tt.minutes, tt.distractions, tt.bonus); got != tt.want {
+			t.Errorf("Damage(%d, %d, %d) = %d, want %d", tt.minutes, tt.distractions, tt.bonus, got, tt.want)
+		}
+	}
+}
+
+func TestAttack(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	q := player.Quest{ID: "q", Type: player.TypeFocus, HP: 40, Progress: 30}
+
+	if dealt, defeated := Attack(&q, 5, now); dealt != 5 || defeated || q.Progress != 35 {
+		t.Fatalf("Attack(5) = %d, %v; progress %d", dealt, defeated, q.Progress)
+	}
+	if dealt, defeated := Attack(&q, 25, now); dealt != 5 || !defeated || !q.Completed {
+		t.Errorf("Attack(25) = %d, %v; completed %v", dealt, defeated, q.Completed)
+	}
+
+	// Повторяющийся квест после победы возвращается с полным HP
+	weekly := player.Quest{ID: "w", Type: player.TypeFocus, HP: 10, Recurrence: "FREQ=WEEKLY", CreatedAt: now}
+	if _, defeated := Attack(&weekly, 10, now); !defeated || weekly.Completed || weekly.Progress != 0 {
+		t.Errorf("recurring Attack: defeated %v, completed %v, progress %d", defeated, weekly.Completed, weekly.Progress)
+	}
+
+	goal := player.Quest{ID: "g", Type: player.TypeGoal, HP: 10}
+	if dealt, _ := Attack(&goal, 10, now); dealt != 0 {
+		t.Errorf("Attack(goal) dealt %d, want 0", dealt)
+	}
+}
@@ -0,0 +1,42 @@
+package dungeon
+
+import (
+	"magus/i18n"
+	"time"
+)
+
+// SessionDurations — доступные длительности фокус-сессии.
+var SessionDurations = []time.Duration{
+	15 * time.Minute,
+	25 * time.Minute,
+	45 * time.Minute,
+}
+
+// ManaCost возвращает стоимость фокус-сессии в мане: 1 мана за 5 минут.
+func ManaCost(d time.Duration) int {
+	return int(d.Minutes() / 5)
+}
+
+// Split делит минуты сессии поровну между n квестами.
+// Остаток от деления достается первым квестам.
+func Split(minutes, n int) []int {
+	if n <= 0 {
+		return nil
+	}
+	parts := make([]int, n)
+	for i := range parts {
+		parts[i] = minutes / n
+		if i < minutes%n {
+			parts[i]++
+		}
+	}
+	return parts
+}
+
+// FormatMinutes возвращает время в виде «1 ч 25 мин».
+func FormatMinutes(minutes int) string {
+	if minutes < 60 {
+		return i18n.T("time.minutes", minutes)
+	}
+	return i18n.T("time.hours_minutes", minutes/60, minutes%60)
+}
@@ -0,0 +1,315 @@
+// Package effects — движок эффектов: производные характеристики игрока
+// (максимум HP и маны, множители XP, урон по фокус-квестам, шанс отвлечения,
+// стоимость сессий и очки навыков за уровень) из изученных навыков дерева,
+// рангов базовых навыков и пассивных бонусов класса (data/classes.json).
+// Каждая характеристика хранит, откуда взялась ее надбавка.
+package effects
+
+import (
+	"errors"
+	"fmt"
+	"magus/dungeon"
+	"magus/i18n"
+	"magus/player"
+	"magus/rpg"
+	"math"
+	"sort"
+	"time"
+)
+
+// Stat — производная характеристика игрока.
+type Stat string
+
+const (
+	StatMaxHP       Stat = "max_hp"
+	StatMaxMana     Stat = "max_mana"
+	StatXP          Stat = "xp"
+	StatDamage      Stat = "damage"
+	StatDistraction Stat = "distraction"
+	StatManaCost    Stat = "mana_cost"
+	StatFocusXP     Stat = "focus_xp"     // Доля к XP за фокус-квесты и фокус-сессии
+	StatGoalXP      Stat = "goal_xp"      // Доля к XP за цели
+	StatRitualsXP   Stat = "rituals_xp"   // XP, когда выполнены все ритуалы дня
+	StatSkillPoints Stat = "skill_points" // Дополнительные очки навыков за уровень
+)
+
+// Stats перечисляет характеристики в порядке показа.
+var Stats = []Stat{
+	StatMaxHP, StatMaxMana, StatXP, StatFocusXP, StatGoalXP, StatRitualsXP,
+	StatDamage, StatDistraction, StatManaCost, StatSkillPoints,
+}
+
+const (
+	// BaseDistraction — шанс атаки на концентрацию за тик данжа без навыков.
+	BaseDistraction = 0.5
+	// MinDistraction — шанс атаки, ниже которого навыки его не опускают.
+	MinDistraction = 0.05
+	// MinManaCost — доля стоимости сессии, дешевле которой она не становится.
+	MinManaCost = 0.25
+)
+
+// effect — характеристика, на которую действует ключ эффекта, и знак действия.
+type effect struct {
+	stat Stat
+	sign float64
+}
+
+// keys сопоставляет ключи эффектов из skill_tree.json с характеристиками.
+// Боевые ключи из старого дерева навыков переведены на правила фокус-сессий.
+var keys = map[string]effect{
+	"HP_MOD":           {StatMaxHP, 1},
+	"MANA_MOD":         {StatMaxMana, 1},
+	"STAMINA_MOD":      {StatMaxMana, 1}, // Выносливость — запас маны на фокус-сессии
+	"XP_MOD":           {StatXP, 1},
+	"DAMAGE_MOD":       {StatDamage, 1},
+	"FIRE_DAMAGE_MOD":  {StatDamage, 1},
+	"ARMOR_PEN_MOD":    {StatDamage, 1},
+	"CRIT_DAMAGE_MOD":  {StatDamage, 1},
+	"DISTRACTION_MOD":  {StatDistraction, 1},
+	"EVASION_CHANCE":   {StatDistraction, -1}, // Уклонение от атак на концентрацию
+	"MANA_COST_MOD":    {StatManaCost, 1},
+	"FOCUS_XP_MOD":     {StatFocusXP, 1},
+	"GOAL_XP_MOD":      {StatGoalXP, 1},
+	"DAILY_RITUALS_XP": {StatRitualsXP, 1},
+	"SKILL_POINTS_MOD": {StatSkillPoints, 1}, // 0.5 — одно очко за каждый второй уровень
+}
+
+// RankEffects — эффекты одного ранга базовых навыков (Player.Skills).
+var RankEffects = map[string]map[string]float64{
+	player.SkillStrength:     {"DAMAGE_MOD": 0.05},
+	player.SkillFocus:        {"DAMAGE_MOD": 0.03, "DISTRACTION_MOD": -0.02},
+	player.SkillVitality:     {"HP_MOD": 0.02},
+	player.SkillIntelligence: {"MANA_MOD": 0.02},
+	player.SkillLearning:     {"XP_MOD": 0.01},
+	player.SkillEfficiency:   {"MANA_COST_MOD": -0.02},
+}
+
+// Contribution — надбавка к характеристике от одного источника.
+type Contribution struct {
+	Source string // Имя навыка, ранга или класса на текущем языке
+	Stat   Stat
+	Value  float64 // Доля: 0.1 — это +10% (см. Format)
+}
+
+// Sheet — производные характеристики игрока. Нулевое значение ничего
+// не меняет, поэтому правила работают и без игрока.
+type Sheet struct {
+	Bonus         map[Stat]float64
+	Contributions []Contribution
+}
+
+// Compute собирает эффекты игрока: изученные навыки из nodes, ранги базовых
+// навыков и пассивные бонусы класса. Навыки, которых нет в nodes, ничего не дают.
+func Compute(p *player.Player, nodes map[string]player.SkillNode, passives []rpg.Passive) Sheet {
+	s := Sheet{Bonus: make(map[Stat]float64)}
+	if p == nil {
+		return s
+	}
+
+	for _, id := range p.UnlockedSkills {
+		if node, ok := nodes[id]; ok {
+			s.add(node.Name, node.Effects, 1)
+		}
+	}
+
+	ranks := make([]string, 0, len(p.Skills))
+	for id := range p.Skills {
+		ranks = append(ranks, id)
+	}
+	sort.Strings(ranks)
+	for _, id := range ranks {
+		if rank := p.Skills[id]; rank > 0 {
+			s.add(i18n.T("effects.rank", i18n.T("skill.rank."+id), rank), RankEffects[id], float64(rank))
+		}
+	}
+
+	for _, passive := range passives {
+		s.add(i18n.T("effects.class", rpg.ClassName(p.Class), passive.Name), passive.Effects, 1)
+	}
+	return s
+}
+
+// Load вычисляет эффекты игрока по дереву навыков из data/skill_tree.json
+// и бонусам класса из data/classes.json. Без дерева действуют только ранги
+// и класс.
+func Load(p *player.Player) (Sheet, error) {
+	if p == nil {
+		return Compute(nil, nil, nil), nil
+	}
+	trees, treeErr := rpg.LoadSkillTrees(p)
+	passives, classErr := rpg.ClassPassives(p.Class)
+	return Compute(p, trees.AllSkills(), passives), errors.Join(treeErr, classErr)
+}
+
+// add добавляет эффекты источника, умноженные на times (ранг навыка).
+func (s *Sheet) add(source string, effs map[string]float64, times float64) {
+	names := make([]string, 0, len(effs))
+	for key := range effs {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+	for _, key := range names {
+		e, ok := keys[key]
+		if !ok || effs[key] == 0 {
+			continue
+		}
+		value := effs[key] * e.sign * times
+		s.Bonus[e.stat] += value
+		s.Contributions = append(s.Contributions, Contribution{Source: source, Stat: e.stat, Value: value})
+	}
+}
+
+// Breakdown возвращает источники надбавки к характеристике.
+func (s Sheet) Breakdown(stat Stat) []Contribution {
+	var result []Contribution
+	for _, c := range s.Contributions {
+		if c.Stat == stat {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// MaxHP возвращает максимум HP игрока.
+func (s Sheet) MaxHP() int {
+	return max(scale(player.BaseHP, s.Bonus[StatMaxHP]), 1)
+}
+
+// MaxMana возвращает максимум маны игрока.
+func (s Sheet) MaxMana() int {
+	return max(scale(player.BaseMana, s.Bonus[StatMaxMana]), 0)
+}
+
+// XP применяет к награде множитель опыта.
+func (s Sheet) XP(xp int) int {
+	return max(scale(xp, s.Bonus[StatXP]), 0)
+}
+
+// QuestXP применяет к награде за квест типа t общий множитель опыта и
+// надбавку за этот тип. Ритуалы получают только общий множитель.
+func (s Sheet) QuestXP(t player.QuestType, xp int) int {
+	bonus := s.Bonus[StatXP]
+	switch t {
+	case player.TypeFocus:
+		bonus += s.Bonus[StatFocusXP]
+	case player.TypeGoal:
+		bonus += s.Bonus[StatGoalXP]
+	}
+	return max(scale(xp, bonus), 0)
+}
+
+// RitualsXP возвращает XP, который приносит выполнение всех ритуалов
+// игрового дня.
+func (s Sheet) RitualsXP() int {
+	return max(int(math.Round(s.Bonus[StatRitualsXP])), 0)
+}
+
+// SkillPoints возвращает очки навыков за достижение уровня level.
+// Дробная надбавка копится: при 0.5 лишнее очко дают четные уровни.
+func (s Sheet) SkillPoints(level int) int {
+	b := s.Bonus[StatSkillPoints]
+	extra := math.Floor(float64(level)*b+1e-9) - math.Floor(float64(level-1)*b+1e-9)
+	return max(player.SkillPointsPerLevel+int(extra), 0)
+}
+
+// DamageBonus возвращает надбавку к урону по фокус-квестам в процентах.
+func (s Sheet) DamageBonus() int {
+	return int(math.Round(s.Bonus[StatDamage] * 100))
+}
+
+// DistractionChance возвращает шанс атаки на концентрацию за тик данжа
+// в процентах.
+func (s Sheet) DistractionChance() int {
+	chance := math.Max(BaseDistraction+s.Bonus[StatDistraction], MinDistraction)
+	return int(math.Round(math.Min(chance, 1) * 100))
+}
+
+// ManaCost возвращает стоимость фокус-сессии длительностью d в мане.
+// Дробная стоимость округляется вниз, чтобы скидка была заметна.
+func (s Sheet) ManaCost(d time.Duration) int {
+	cost := dungeon.ManaCost(d)
+	if cost == 0 || s.Bonus[StatManaCost] == 0 {
+		return cost
+	}
+	mult := math.Max(1+s.Bonus[StatManaCost], MinManaCost)
+	return max(int(math.Floor(float64(cost)*mult+1e-9)), 1)
+}
+
+// Apply записывает максимум HP и маны в игрока. Текущие значения не
+// превышают новый максимум. Возвращает true, если что-то изменилось.
+func (s Sheet) Apply(p *player.Player) bool {
+	if p == nil {
+		return false
+	}
+	before := [4]int{p.HP, p.MaxHP, p.Mana, p.MaxMana}
+	p.MaxHP, p.MaxMana = s.MaxHP(), s.MaxMana()
+	p.HP = min(p.HP, p.MaxHP)
+	p.Mana = min(p.Mana, p.MaxMana)
+	return before != [4]int{p.HP, p.MaxHP, p.Mana, p.MaxMana}
+}
+
+// StatName возвращает название характеристики на текущем языке.
+func StatName(stat Stat) string {
+	return i18n.T("effects.stat." + string(stat))
+}
+
+// Value возвращает итоговое значение характеристики для показа.
+func (s Sheet) Value(stat Stat) string {
+	switch stat {
+	case StatMaxHP:
+		return fmt.Sprint(s.MaxHP())
+	case StatMaxMana:
+		return fmt.Sprint(s.MaxMana())
+	case StatXP:
+		return fmt.Sprintf("×%.2f", 1+s.Bonus[StatXP])
+	case StatFocusXP, StatGoalXP:
+		return fmt.Sprintf("×%.2f", 1+s.Bonus[StatXP]+s.Bonus[stat])
+	case StatRitualsXP:
+		return fmt.Sprintf("%d XP", s.RitualsXP())
+	case StatDamage:
+		return Percent(s.Bonus[StatDamage])
+	case StatDistraction:
+		return fmt.Sprintf("%d%%", s.DistractionChance())
+	case StatManaCost:
+		d := dungeon.SessionDurations[len(dungeon.SessionDurations)/2]
+		return i18n.T("effects.mana_cost", s.ManaCost(d), int(d.Minutes()))
+	case StatSkillPoints:
+		return fmt.Sprintf("%g", player.SkillPointsPerLevel+s.Bonus[StatSkillPoints])
+	}
+	return ""
+}
+
+// Format возвращает надбавку к характеристике для показа: долю в процентах,
+// а XP за ритуалы и очки навыков — числом.
+func Format(stat Stat, v float64) string {
+	switch stat {
+	case StatRitualsXP:
+		return fmt.Sprintf("%+g XP", v)
+	case StatSkillPoints:
+		return fmt.Sprintf("%+g", v)
+	}
+	return Percent(v)
+}
+
+// Percent возвращает долю в виде «+10%».
+func Percent(v float64) string {
+	return fmt.Sprintf("%+g%%", math.Round(v*1000)/10)
+}
+
+// Describe возвращает эффект навыка на текущем языке, например
+// «Макс. HP +10%». Неизвестные ключи показываются как есть.
+func Describe(key string, value float64) string {
+	e, ok := keys[key]
+	if !ok {
+		return fmt.Sprintf("%s %+g", key, value)
+	}
+	return StatName(e.stat) + " " + Format(e.stat, value*e.sign)
+}
+
+// scale увеличивает value на долю bonus с округлением.
+func scale(value int, bonus float64) int {
+	if bonus == 0 {
+		return value
+	}
+	return int(math.Round(float64(value) * (1 + bonus)))
+}
@@ -0,0 +1,88 @@
+package effects
+
+import (
+	"magus/player"
+	"magus/rpg"
+	"testing"
+	"time"
+)
+
+func TestZeroSheetIsNeutral(t *testing.T) {
+	var s Sheet
+	if s.MaxHP() != player.BaseHP || s.MaxMana() != player.BaseMana || s.XP(50) != 50 {
+		t.Errorf("MaxHP = %d, MaxMana = %d, XP(50) = %d", s.MaxHP(), s.MaxMana(), s.XP(50))
+	}
+	if s.DamageBonus() != 0 || s.DistractionChance() != 50 || s.ManaCost(25*time.Minute) != 5 {
+		t.Errorf("damage = %d, distraction = %d, cost = %d", s.DamageBonus(), s.DistractionChance(), s.ManaCost(25*time.Minute))
+	}
+}
+
+func TestCompute(t *testing.T) {
+	nodes := map[string]player.SkillNode{
+		"hp_1":    {ID: "hp_1", Name: "HP", Effects: map[string]float64{"HP_MOD": 0.1}},
+		"evasion": {ID: "evasion", Name: "Evasion", Effects: map[string]float64{"EVASION_CHANCE": 0.05, "UNKNOWN": 1}},
+	}
+	p := &player.Player{
+		Class:          player.ClassWarrior,
+		UnlockedSkills: []string{"hp_1", "evasion", "missing"},
+		Skills: map[string]int{
+			player.SkillVitality:   5,
+			player.SkillFocus:      10,
+			player.SkillLearning:   10,
+			player.SkillEfficiency: 10,
+		},
+	}
+	passives := []rpg.Passive{{ID: "endurance", Name: "Endurance", Effects: map[string]float64{"HP_MOD": 0.1}}}
+	s := Compute(p, nodes, passives)
+
+	// Навык, ранги «Живучести» и бонус воина — по +10%
+	if got := s.MaxHP(); got != 130 {
+		t.Errorf("MaxHP = %d, want 130", got)
+	}
+	if n := len(s.Breakdown(StatMaxHP)); n != 3 {
+		t.Errorf("MaxHP breakdown has %d sources, want 3", n)
+	}
+	if got := s.XP(100); got != 110 {
+		t.Errorf("XP(100) = %d, want 110", got)
+	}
+	// 50% − 20% за «Концентрацию» − 5% за уклонение
+	if got := s.DistractionChance(); got != 25 {
+		t.Errorf("DistractionChance = %d, want 25", got)
+	}
+	if got := s.DamageBonus(); got != 30 {
+		t.Errorf("DamageBonus = %d, want 30", got)
+	}
+	if got := s.ManaCost(25 * time.Minute); got != 4 {
+		t.Errorf("ManaCost(25m) = %d, want 4", got)
+	}
+
+	p.HP, p.Mana = 200, 200
+	if !s.Apply(p) || p.MaxHP != 130 || p.HP != 130 || p.Mana != player.BaseMana {
+		t.Errorf("after Apply: HP %d/%d, mana %d/%d", p.HP, p.MaxHP, p.Mana, p.MaxMana)
+	}
+}
+
+func TestClassStats(t *testing.T) {
+	s := Compute(&player.Player{}, nil, []rpg.Passive{{
+		Name:    "Test",
+		Effects: map[string]float64{"FOCUS_XP_MOD": 0.15, "SKILL_POINTS_MOD": 0.5, "DAILY_RITUALS_XP": 20},
+	}})
+
+	if got := s.QuestXP(player.TypeFocus, 100); got != 115 {
+		t.Errorf("QuestXP(focus) = %d, want 115", got)
+	}
+	if got := s.QuestXP(player.TypeGoal, 100); got != 100 {
+		t.Errorf("QuestXP(goal) = %d, want 100", got)
+	}
+	if got := s.RitualsXP(); got != 20 {
+		t.Errorf("RitualsXP = %d, want 20", got)
+	}
+	for level, want := range map[int]int{2: 11, 3: 10, 4: 11} {
+		if got := s.SkillPoints(level); got != want {
+			t.Errorf("SkillPoints(%d) = %d, want %d", level, got, want)
+		}
+	}
+	if got := Format(StatSkillPoints, 0.5); got != "+0.5" {
+		t.Errorf("Format(skill points) = %q", got)
+	}
+}
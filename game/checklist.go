@@ -0,0 +1,51 @@
+package game
+
+import (
+	"magus/checklist"
+	"magus/dungeon"
+	"magus/player"
+	"time"
+)
+
+// ToggleCheck переключает пункт i чек-листа квеста. Если progress включен,
+// отметка пункта на фокус-квесте наносит ему урон — долю HP, так что все
+// пункты вместе побеждают квест; снятая отметка возвращает эту долю.
+func ToggleCheck(w *World, questID string, i int, progress bool, now time.Time) ([]Event, error) {
+	qi := w.find(questID)
+	if qi < 0 {
+		return nil, ErrQuestNotFound
+	}
+	q := &w.Quests[qi]
+	wasCompleted := q.Completed
+	done, err := checklist.Toggle(q, i)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := EventItemUnchecked
+	if done {
+		kind = EventItemChecked
+	}
+	events := []Event{{Kind: kind, Quest: *q, Amount: i}}
+	if !progress || q.Type != player.TypeFocus || q.HP <= 0 || wasCompleted {
+		return events, nil
+	}
+
+	checked, total := checklist.Count(*q)
+	if !done {
+		q.Progress -= checklist.Share(q.HP, checked+1, total)
+		if q.Progress < 0 {
+			q.Progress = 0
+		}
+		return events, nil
+	}
+
+	dealt, defeated := dungeon.Attack(q, checklist.Share(q.HP, checked, total), now)
+	if dealt > 0 {
+		events = append(events, Event{Kind: EventQuestDamaged, Quest: *q, Amount: dealt})
+	}
+	if defeated {
+		events = append(events, w.defeat(qi, now)...)
+	}
+	return w.levelUp(events), nil
+}
@@ -0,0 +1,52 @@
+package game
+
+import (
+	"magus/player"
+	"testing"
+)
+
+func TestToggleCheckDefeatsFocusQuest(t *testing.T) {
+	w := newWorld(player.Quest{
+		ID: "q", Type: player.TypeFocus, HP: 10, XP: 20,
+		Checklist: []player.ChecklistItem{{Text: "a"}, {Text: "b"}, {Text: "c"}},
+	})
+
+	if _, err := ToggleCheck(w, "q", 0, true, now); err != nil {
+		t.Fatalf("ToggleCheck: %v", err)
+	}
+	if got := w.Quests[0].Progress; got != 3 {
+		t.Errorf("progress after one item = %d, want 3", got)
+	}
+
+	// Снятая отметка возвращает долю HP
+	ToggleCheck(w, "q", 0, true, now)
+	if got := w.Quests[0].Progress; got != 0 {
+		t.Errorf("progress after unchecking = %d, want 0", got)
+	}
+
+	var events []Event
+	for i := range w.Quests[0].Checklist {
+		events, _ = ToggleCheck(w, "q", i, true, now)
+	}
+	if !w.Quests[0].Completed || !Has(events, EventQuestDefeated) {
+		t.Fatalf("quest not defeated by full checklist: %+v", w.Quests[0])
+	}
+	if got := Total(events, EventXPGained); got != 20 {
+		t.Errorf("XP for defeat = %d, want 20", got)
+	}
+}
+
+func TestToggleCheckWithoutProgress(t *testing.T) {
+	w := newWorld(player.Quest{
+		ID: "q", Type: player.TypeFocus, HP: 10,
+		Checklist: []player.ChecklistItem{{Text: "a"}},
+	})
+
+	events, err := ToggleCheck(w, "q", 0, false, now)
+	if err != nil || !Has(events, EventItemChecked) {
+		t.Fatalf("ToggleCheck = %+v, %v", events, err)
+	}
+	if w.Quests[0].Progress != 0 || w.Quests[0].Completed {
+		t.Errorf("quest changed without progress option: %+v", w.Quests[0])
+	}
+}
@@ -0,0 +1,190 @@
+// Package game — единые правила игры для CLI и TUI: завершение квестов
+// и ритуалов, автозавершение целей, итоги фокус-сессий, чек-листы,
+// штрафы за просрочку, опыт, характеристики и навыки.
+//
+// Операции меняют состояние World в памяти и возвращают события, которые
+// интерфейсы только отображают. Загрузка и сохранение остаются за вызывающим.
+package game
+
+import (
+	"errors"
+	"magus/effects"
+	"magus/leveling"
+	"magus/player"
+	"magus/tags"
+)
+
+var (
+	ErrQuestNotFound    = errors.New("quest not found")
+	ErrAlreadyCompleted = errors.New("quest already completed")
+	ErrBlocked          = errors.New("quest is blocked by other quests")
+	ErrNeedsDungeon     = errors.New("focus quest must be defeated in a focus session")
+	ErrOpenSubquests    = errors.New("goal has unfinished subquests")
+	ErrNotRitual        = errors.New("quest is not a ritual")
+)
+
+// World — состояние, над которым работают правила.
+type World struct {
+	Player       *player.Player // nil, если игрок еще не создан: награды не начисляются
+	Quests       []player.Quest
+	DayStartHour int            // Граница игрового дня для ритуалов
+	Tags         tags.Registry  // Настройки тегов: множители XP и тренируемые характеристики
+	Effects      effects.Sheet  // Эффекты навыков и класса: множитель XP, урон, стоимость сессий
+	Curve        leveling.Curve // Кривая опыта из настроек; nil — leveling.Default
+}
+
+// EventKind — тип события.
+type EventKind string
+
+const (
+	EventQuestCompleted  EventKind = "quest_completed"  // Квест завершен; Amount — награда с учетом тегов
+	EventRecurringDone   EventKind = "recurring_done"   // Экземпляр повторяющегося квеста засчитан
+	EventParentCompleted EventKind = "parent_completed" // Цель завершилась вместе с последней подзадачей; Amount — награда
+	EventEarlyBonus      EventKind = "early_bonus"      // Amount — бонус XP за цель, закрытую до срока
+	EventXPGained        EventKind = "xp_gained"        // Amount — полученный XP
+	EventLevelUpReady    EventKind = "level_up_ready"   // Amount — на сколько уровней хватает опыта
+	EventManaRestored    EventKind = "mana_restored"    // Amount — восстановленная мана
+	EventStreak          EventKind = "streak"           // Amount — длина серии ритуала
+	EventNewBestStreak   EventKind = "new_best_streak"  // Серия побила рекорд
+	EventHPLost          EventKind = "hp_lost"          // Amount — потерянное HP игрока
+	EventQuestDamaged    EventKind = "quest_damaged"    // Amount — урон квесту за сессию
+	EventQuestDefeated   EventKind = "quest_defeated"   // Фокус-квест побежден в сессии
+	EventSkillUnlocked   EventKind = "skill_unlocked"   // Навык изучен
+	EventItemChecked     EventKind = "item_checked"     // Amount — номер отмеченного пункта чек-листа
+	EventItemUnchecked   EventKind = "item_unchecked"   // Amount — номер пункта, с которого снята отметка
+	EventQuestOverdue    EventKind = "quest_overdue"    // Amount — новые дни просрочки квеста
+	EventXPDecayed       EventKind = "xp_decayed"       // Amount — на сколько уменьшилась награда квеста
+	EventOverdueTracked  EventKind = "overdue_tracked"  // Квест из старых данных: просрочка учитывается с этого момента
+	EventStatTrained     EventKind = "stat_trained"     // Stat — характеристика, Amount — ее новое значение
+	EventRitualsDone     EventKind = "rituals_done"     // Все ритуалы дня выполнены; Amount — бонус XP класса
+	EventLeveledUp       EventKind = "leveled_up"       // Amount — новый уровень
+	EventSkillPoints     EventKind = "skill_points"     // Amount — очки навыков за уровень
+)
+
+// Event — результат операции. Quest содержит состояние квеста после события.
+type Event struct {
+	Kind   EventKind
+	Quest  player.Quest
+	Skill  player.SkillNode
+	Stat   string
+	Amount int
+}
+
+// Total суммирует Amount событий одного типа.
+func Total(events []Event, kind EventKind) int {
+	total := 0
+	for _, e := range events {
+		if e.Kind == kind {
+			total += e.Amount
+		}
+	}
+	return total
+}
+
+// Has сообщает, есть ли среди событий событие типа kind.
+func Has(events []Event, kind EventKind) bool {
+	for _, e := range events {
+		if e.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// find возвращает индекс квеста или -1.
+func (w *World) find(id string) int {
+	for i, q := range w.Quests {
+		if q.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// grantXP начисляет опыт за квест типа t с учетом множителей XP навыков
+// и класса.
+func (w *World) grantXP(xp int, t player.QuestType) []Event {
+	if w.Player == nil || xp <= 0 {
+		return nil
+	}
+	xp = w.Effects.QuestXP(t, xp)
+	w.Player.GainXP(xp)
+	return []Event{{Kind: EventXPGained, Amount: xp}}
+}
+
+// questXP возвращает награду за квест с учетом тегов, навыков и класса.
+func (w *World) questXP(q player.Quest) int {
+	return w.Effects.QuestXP(q.Type, w.Tags.XP(q.Tags, q.XP))
+}
+
+// reward начисляет награду за квест: XP с учетом множителей тегов и
+// по единице каждой характеристики, которую тренируют теги.
+func (w *World) reward(q player.Quest) []Event {
+	events := w.grantXP(w.Tags.XP(q.Tags, q.XP), q.Type)
+	if w.Player == nil {
+		return events
+	}
+	for _, name := range w.Tags.Affinities(q.Tags) {
+		if stat := w.Player.Stats.Stat(name); stat != nil {
+			*stat++
+			events = append(events, Event{Kind: EventStatTrained, Quest: q, Stat: name, Amount: *stat})
+		}
+	}
+	return events
+}
+
+// levelUp добавляет событие о новых уровнях, если опыта уже хватает.
+func (w *World) levelUp(events []Event) []Event {
+	if w.Player == nil || !Has(events, EventXPGained) {
+		return events
+	}
+	if n := PendingLevels(w); n > 0 {
+		events = append(events, Event{Kind: EventLevelUpReady, Amount: n})
+	}
+	return events
+}
+
+// curve возвращает кривую опыта мира.
+func (w *World) curve() leveling.Curve {
+	if w.Curve == nil {
+		return leveling.Default
+	}
+	return w.Curve
+}
+
+// SyncLevel пересчитывает порог следующего уровня по кривой опыта: кривая
+// могла измениться в настройках. Возвращает true, если порог изменился.
+func SyncLevel(w *World) bool {
+	if w.Player == nil {
+		return false
+	}
+	next := w.curve().Next(w.Player.Level)
+	changed := w.Player.NextLevelXP != next
+	w.Player.NextLevelXP = next
+	return changed
+}
+
+// PendingLevels возвращает, на сколько уровней игроку уже хватает опыта.
+func PendingLevels(w *World) int {
+	if w.Player == nil {
+		return 0
+	}
+	SyncLevel(w)
+	return leveling.Pending(w.curve(), w.Player.Level, w.Player.XP)
+}
+
+// LevelUp повышает уровень игрока на один, если опыта хватает, и начисляет
+// очки навыков с учетом бонусов класса (см. effects.Sheet.SkillPoints).
+// Когда опыта хватает на несколько уровней, вызывается для каждого.
+func LevelUp(w *World) []Event {
+	p := w.Player
+	if p == nil {
+		return nil
+	}
+	SyncLevel(w)
+	points := w.Effects.SkillPoints(p.Level + 1)
+	if !p.LevelUp(w.curve().Next(p.Level+1), points) {
+		return nil
+	}
+	return []Event{{Kind: EventLeveledUp, Amount: p.Level}, {Kind: EventSkillPoints, Amount: points}}
+}
@@ -0,0 +1,288 @@
+package game
+
+import (
+	"errors"
+	"magus/effects"
+	"magus/leveling"
+	"magus/player"
+	"magus/ritual"
+	"magus/rpg"
+	"magus/storage"
+	"magus/tags"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+func newWorld(quests ...player.Quest) *World {
+	p := &player.Player{Level: 1, NextLevelXP: 100, MaxMana: 30, Skills: map[string]int{}}
+	return &World{Player: p, Quests: quests}
+}
+
+func TestCompleteQuestCompletesParent(t *testing.T) {
+	w := newWorld(
+		player.Quest{ID: "goal", Type: player.TypeGoal, XP: 30},
+		player.Quest{ID: "a", ParentID: "goal", Type: player.TypeFocus, XP: 10},
+		player.Quest{ID: "b", ParentID: "goal", Type: player.TypeFocus, XP: 10},
+	)
+
+	if _, err := CompleteQuest(w, "goal", now); !errors.Is(err, ErrOpenSubquests) {
+		t.Fatalf("CompleteQuest(goal) error = %v, want ErrOpenSubquests", err)
+	}
+	if _, err := CompleteQuest(w, "a", now); err != nil {
+		t.Fatalf("CompleteQuest(a) error = %v", err)
+	}
+	events, err := CompleteQuest(w, "b", now)
+	if err != nil {
+		t.Fatalf("CompleteQuest(b) error = %v", err)
+	}
+
+	if !Has(events, EventParentCompleted) || !w.Quests[0].Completed {
+		t.Errorf("goal was not auto-completed: %+v", events)
+	}
+	if got := Total(events, EventXPGained); got != 40 {
+		t.Errorf("XP from last subquest = %d, want 40", got)
+	}
+	if w.Player.XP != 50 || w.Player.History.QuestsCompleted != 3 {
+		t.Errorf("player XP %d, completed %d; want 50 and 3", w.Player.XP, w.Player.History.QuestsCompleted)
+	}
+	if _, err := CompleteQuest(w, "a", now); !errors.Is(err, ErrAlreadyCompleted) {
+		t.Errorf("second CompleteQuest(a) error = %v, want ErrAlreadyCompleted", err)
+	}
+}
+
+func TestCompleteQuestRules(t *testing.T) {
+	w := newWorld(
+		player.Quest{ID: "boss", Type: player.TypeFocus, HP: 50, XP: 120},
+		player.Quest{ID: "wait", Type: player.TypeFocus, BlockedBy: []string{"boss"}},
+		player.Quest{ID: "water", Type: player.TypeRitual},
+		player.Quest{ID: "quick", Type: player.TypeFocus, XP: 120},
+	)
+	w.Player.Mana = 28
+
+	if _, err := CompleteQuest(w, "boss", now); !errors.Is(err, ErrNeedsDungeon) {
+		t.Errorf("CompleteQuest(boss) error = %v, want ErrNeedsDungeon", err)
+	}
+	if _, err := CompleteQuest(w, "wait", now); !errors.Is(err, ErrBlocked) {
+		t.Errorf("CompleteQuest(wait) error = %v, want ErrBlocked", err)
+	}
+	if _, err := CompleteQuest(w, "missing", now); !errors.Is(err, ErrQuestNotFound) {
+		t.Errorf("CompleteQuest(missing) error = %v, want ErrQuestNotFound", err)
+	}
+
+	// Ритуал восстанавливает ману не выше максимума
+	events, err := CompleteQuest(w, "water", now)
+	if err != nil || Total(events, EventManaRestored) != ritual.BaseMana || w.Player.Mana != 30 {
+		t.Errorf("ritual: events %+v, err %v, mana %d", events, err, w.Player.Mana)
+	}
+	if _, err := CompleteRitual(w, "water", now); !errors.Is(err, ritual.ErrCooldown) {
+		t.Errorf("second ritual error = %v, want ErrCooldown", err)
+	}
+
+	events, _ = CompleteQuest(w, "quick", now)
+	if !Has(events, EventLevelUpReady) {
+		t.Errorf("expected EventLevelUpReady, got %+v", events)
+	}
+}
+
+func TestRestoredQuestNotRewardedTwice(t *testing.T) {
+	w := newWorld(
+		player.Quest{ID: "goal", Type: player.TypeGoal, XP: 30},
+		player.Quest{ID: "a", ParentID: "goal", Type: player.TypeFocus, XP: 40},
+	)
+	if _, err := CompleteQuest(w, "a", now); err != nil {
+		t.Fatalf("CompleteQuest(a) error = %v", err)
+	}
+	xp, completed := w.Player.XP, w.Player.History.QuestsCompleted
+
+	// Архив → восстановление → повторное выполнение не приносит опыт
+	restored, _, err := storage.Unarchive(w.Quests, "a")
+	if err != nil {
+		t.Fatalf("Unarchive: %v", err)
+	}
+	w.Quests = restored
+	events, err := CompleteQuest(w, "a", now)
+	if err != nil {
+		t.Fatalf("CompleteQuest(restored) error = %v", err)
+	}
+	if !w.Quests[0].Completed || !w.Quests[1].Completed {
+		t.Errorf("restored quests not completed again: %+v", w.Quests)
+	}
+	if w.Player.XP != xp || w.Player.History.QuestsCompleted != completed || Has(events, EventXPGained) {
+		t.Errorf("player XP %d, completed %d after restore; want %d and %d", w.Player.XP, w.Player.History.QuestsCompleted, xp, completed)
+	}
+}
+
+func TestCloseGoals(t *testing.T) {
+	w := newWorld(
+		player.Quest{ID: "goal", Type: player.TypeGoal, XP: 30},
+		player.Quest{ID: "done", ParentID: "goal", Type: player.TypeFocus, Completed: true},
+		player.Quest{ID: "empty", Type: player.TypeGoal},
+	)
+	events := CloseGoals(w, "goal", now)
+	if !w.Quests[0].Completed || !Has(events, EventParentCompleted) || w.Player.XP != 30 {
+		t.Errorf("goal with done subquests: completed %v, XP %d, events %+v", w.Quests[0].Completed, w.Player.XP, events)
+	}
+	if events := CloseGoals(w, "empty", now); events != nil || w.Quests[2].Completed {
+		t.Errorf("empty goal was completed: %+v", events)
+	}
+}
+
+func TestTagRewards(t *testing.T) {
+	w := newWorld(
+		player.Quest{ID: "run", Type: player.TypeFocus, XP: 20, Tags: []string{"sport/running"}},
+		player.Quest{ID: "dishes", Type: player.TypeFocus, XP: 20, Tags: []string{"chores"}},
+	)
+	w.Tags = tags.Registry{
+		"sport":  {XPMultiplier: 1.5, Stat: "strength"},
+		"chores": {XPMultiplier: 0.5, Stat: "unknown"},
+	}
+
+	events, err := CompleteQuest(w, "run", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Total(events, EventXPGained); got != 30 || events[0].Amount != 30 {
+		t.Errorf("XP for #sport/running = %d (event %d), want 30", got, events[0].Amount)
+	}
+	if !Has(events, EventStatTrained) || w.Player.Stats.Strength != 1 {
+		t.Errorf("strength = %d, events %+v", w.Player.Stats.Strength, events)
+	}
+
+	events, _ = CompleteQuest(w, "dishes", now)
+	if got := Total(events, EventXPGained); got != 10 {
+		t.Errorf("XP for #chores = %d, want 10", got)
+	}
+	if Has(events, EventStatTrained) {
+		t.Error("unknown stat was trained")
+	}
+}
+
+func TestSkillEffects(t *testing.T) {
+	w := newWorld(player.Quest{ID: "run", Type: player.TypeFocus, XP: 20, Tags: []string{"sport"}})
+	w.Tags = tags.Registry{"sport": {XPMultiplier: 1.5}}
+	w.Player.Skills[player.SkillLearning] = 10 // +10% XP
+	w.Effects = effects.Compute(w.Player, nil, nil)
+
+	events, err := CompleteQuest(w, "run", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Total(events, EventXPGained); got != 33 || events[0].Amount != 33 {
+		t.Errorf("XP with tag and skill = %d (event %d), want 33", got, events[0].Amount)
+	}
+}
+
+func TestClassPassives(t *testing.T) {
+	passive := func(effs map[string]float64) []rpg.Passive {
+		return []rpg.Passive{{ID: "test", Name: "Test", Effects: effs}}
+	}
+
+	// Маг: надбавка к XP только за фокус-квесты
+	w := newWorld(
+		player.Quest{ID: "focus", Type: player.TypeFocus, XP: 20},
+		player.Quest{ID: "goal", Type: player.TypeGoal, XP: 20},
+	)
+	w.Effects = effects.Compute(w.Player, nil, passive(map[string]float64{"FOCUS_XP_MOD": 0.15}))
+	events, _ := CompleteQuest(w, "focus", now)
+	if got := Total(events, EventXPGained); got != 23 {
+		t.Errorf("focus XP = %d, want 23", got)
+	}
+	events, _ = CompleteQuest(w, "goal", now)
+	if got := Total(events, EventXPGained); got != 20 {
+		t.Errorf("goal XP = %d, want 20", got)
+	}
+
+	// Воин: бонус за последний ритуал дня, один раз
+	w = newWorld(
+		player.Quest{ID: "water", Type: player.TypeRitual},
+		player.Quest{ID: "stretch", Type: player.TypeRitual, CooldownHours: 1},
+	)
+	w.Effects = effects.Compute(w.Player, nil, passive(map[string]float64{"DAILY_RITUALS_XP": 20}))
+	if events, _ := CompleteRitual(w, "water", now); Has(events, EventRitualsDone) {
+		t.Error("bonus before all rituals are done")
+	}
+	events, _ = CompleteRitual(w, "stretch", now)
+	if !Has(events, EventRitualsDone) || w.Player.XP != 20 {
+		t.Errorf("XP = %d, events %+v", w.Player.XP, events)
+	}
+	if events, _ := CompleteRitual(w, "stretch", now.Add(2*time.Hour)); Has(events, EventRitualsDone) {
+		t.Error("bonus granted twice in one day")
+	}
+
+	// Разбойник: лишнее очко навыков на четных уровнях
+	w = newWorld()
+	w.Effects = effects.Compute(w.Player, nil, passive(map[string]float64{"SKILL_POINTS_MOD": 0.5}))
+	w.Player.XP = 100
+	events = LevelUp(w)
+	if w.Player.Level != 2 || Total(events, EventSkillPoints) != player.SkillPointsPerLevel+1 {
+		t.Errorf("level %d, events %+v", w.Player.Level, events)
+	}
+	w.Player.XP = w.Player.NextLevelXP
+	if events = LevelUp(w); Total(events, EventSkillPoints) != player.SkillPointsPerLevel {
+		t.Errorf("level 3 events %+v", events)
+	}
+	if events = LevelUp(w); events != nil {
+		t.Errorf("level up without XP: %+v", events)
+	}
+}
+
+func TestLevelCurve(t *testing.T) {
+	w := newWorld(player.Quest{ID: "epic", Type: player.TypeGoal, XP: 700})
+	w.Curve = leveling.Table{100, 200, 300}
+
+	// 700 XP хватает на три уровня: 100 + 200 + 300
+	events, _ := CompleteQuest(w, "epic", now)
+	if got := Total(events, EventLevelUpReady); got != 3 {
+		t.Fatalf("pending levels = %d, events %+v", got, events)
+	}
+	levels := 0
+	for LevelUp(w) != nil {
+		levels++
+	}
+	if levels != 3 || w.Player.Level != 4 || w.Player.XP != 100 || w.Player.NextLevelXP != 400 {
+		t.Errorf("after %d level-ups: level %d, XP %d/%d", levels, w.Player.Level, w.Player.XP, w.Player.NextLevelXP)
+	}
+	if w.Player.SkillPoints != 3*player.SkillPointsPerLevel {
+		t.Errorf("skill points = %d", w.Player.SkillPoints)
+	}
+
+	// Новая кривая в настройках меняет порог уже достигнутого уровня
+	w.Curve = leveling.Polynomial{50}
+	if !SyncLevel(w) || w.Player.NextLevelXP != 50 || PendingLevels(w) != 2 {
+		t.Errorf("after sync: XP %d/%d, pending %d", w.Player.XP, w.Player.NextLevelXP, PendingLevels(w))
+	}
+}
+
+func TestFinishSession(t *testing.T) {
+	w := newWorld(
+		player.Quest{ID: "goal", Type: player.TypeGoal, XP: 20},
+		player.Quest{ID: "boss", ParentID: "goal", Type: player.TypeFocus, HP: 30, Progress: 20, XP: 40},
+	)
+
+	events := FinishSession(w, Session{
+		Duration:     25 * time.Minute,
+		Success:      true,
+		Attacks:      1,
+		Distractions: 2,
+		Quests:       []storage.QuestTime{{QuestID: "boss", Minutes: 25}},
+	}, now)
+
+	// 50 XP за время без бонуса (отвлечений больше, чем атак) + 40 за босса + 20 за цель
+	if got := Total(events, EventXPGained); got != 110 {
+		t.Errorf("XP = %d, want 110", got)
+	}
+	if got := Total(events, EventHPLost); got != DistractionHPLoss {
+		t.Errorf("HP lost = %d, want %d", got, DistractionHPLoss)
+	}
+	if got := Total(events, EventQuestDamaged); got != 10 {
+		t.Errorf("damage = %d, want 10 (capped by remaining HP)", got)
+	}
+	if !w.Quests[1].Completed || !w.Quests[0].Completed {
+		t.Error("expected the boss and its goal to be completed")
+	}
+	if w.Quests[1].FocusMinutes != 25 || w.Player.History.FocusMinutes != 25 {
+		t.Errorf("focus minutes: quest %d, player %d", w.Quests[1].FocusMinutes, w.Player.History.FocusMinutes)
+	}
+}
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"magus/deadline"
+	"magus/player"
+	"time"
+)
+
+// EarlyGoalBonus — бонус к XP цели (в процентах), если она завершена до срока.
+const EarlyGoalBonus = 20
+
+// Progress — прогресс квеста по его поддереву.
+type Progress struct {
+	Done  float64
+	Total float64
+}
+
+// Ratio возвращает долю выполненного от 0 до 1.
+func (p Progress) Ratio() float64 {
+	if p.Total <= 0 {
+		return 0
+	}
+	return p.Done / p.Total
+}
+
+// Weight — вес квеста в прогрессе цели: HP фокус-квеста, иначе XP, иначе 1.
+func Weight(q player.Quest) float64 {
+	switch {
+	case q.HP > 0:
+		return float64(q.HP)
+	case q.XP > 0:
+		return float64(q.XP)
+	}
+	return 1
+}
+
+// ProgressMap считает прогресс всех квестов, у которых есть подзадачи.
+// Прогресс складывается рекурсивно: каждая подзадача без своих подзадач
+// вносит свой вес (см. Weight), фокус-квест — пропорционально нанесенному
+// урону. Квесты-не-цели с подзадачами учитывают и собственный вес.
+func ProgressMap(quests []player.Quest) map[string]Progress {
+	children := make(map[string][]player.Quest)
+	for _, q := range quests {
+		if q.ParentID != "" {
+			children[q.ParentID] = append(children[q.ParentID], q)
+		}
+	}
+
+	result := make(map[string]Progress)
+	var walk func(q player.Quest, seen map[string]bool) Progress
+	walk = func(q player.Quest, seen map[string]bool) Progress {
+		if p, ok := result[q.ID]; ok {
+			return p
+		}
+		kids := children[q.ID]
+		if len(kids) == 0 || seen[q.ID] {
+			return leafProgress(q)
+		}
+		seen[q.ID] = true
+
+		var p Progress
+		if q.Type != player.TypeGoal {
+			p = leafProgress(q)
+		}
+		for _, kid := range kids {
+			kp := walk(kid, seen)
+			p.Done += kp.Done
+			p.Total += kp.Total
+		}
+		if q.Completed {
+			p.Done = p.Total
+		}
+		result[q.ID] = p
+		return p
+	}
+	for _, q := range quests {
+		if len(children[q.ID]) > 0 {
+			walk(q, map[string]bool{})
+		}
+	}
+	return result
+}
+
+// leafProgress — прогресс квеста без подзадач.
+func leafProgress(q player.Quest) Progress {
+	w := Weight(q)
+	switch {
+	case q.Completed:
+		return Progress{Done: w, Total: w}
+	case q.Type == player.TypeFocus && q.HP > 0:
+		return Progress{Done: w * float64(q.Progress) / float64(q.HP), Total: w}
+	}
+	return Progress{Total: w}
+}
+
+// onTime сообщает, что квест завершается не позже срока. Срок без времени
+// действует до конца этого дня.
+func onTime(q player.Quest, now time.Time) bool {
+	return q.Deadline != nil && !deadline.Overdue(*q.Deadline, q.DeadlineTime, now)
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"magus/player"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestProgressMap(t *testing.T) {
+	quests := []player.Quest{
+		{ID: "root", Type: player.TypeGoal},
+		{ID: "done", ParentID: "root", Type: player.TypeFocus, HP: 30, Completed: true},
+		{ID: "half", ParentID: "root", Type: player.TypeFocus, HP: 20, Progress: 10},
+		{ID: "sub", ParentID: "root", Type: player.TypeGoal},
+		{ID: "leaf", ParentID: "sub", Type: player.TypeGoal, XP: 50},
+	}
+
+	progress := ProgressMap(quests)
+	// (30 + 10 + 0) из (30 + 20 + 50)
+	if got := progress["root"].Ratio(); math.Abs(got-0.4) > 1e-9 {
+		t.Errorf("root progress = %v, want 0.4", got)
+	}
+	if got := progress["sub"]; got.Done != 0 || got.Total != 50 {
+		t.Errorf("sub progress = %+v, want 0/50", got)
+	}
+	if _, ok := progress["leaf"]; ok {
+		t.Error("quests without subquests should not be in the map")
+	}
+}
+
+func TestEarlyGoalBonus(t *testing.T) {
+	deadline := time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local)
+	w := newWorld(
+		player.Quest{ID: "goal", Type: player.TypeGoal, XP: 50, Deadline: &deadline},
+		player.Quest{ID: "task", ParentID: "goal", Type: player.TypeFocus, XP: 10},
+	)
+
+	// Срок без времени действует до конца дня
+	events, _ := CompleteQuest(w, "task", deadline.Add(20*time.Hour))
+	if got := Total(events, EventEarlyBonus); got != 10 {
+		t.Errorf("early bonus = %d, want 10", got)
+	}
+
+	late := newWorld(
+		player.Quest{ID: "goal", Type: player.TypeGoal, XP: 50, Deadline: &deadline},
+	)
+	if events, _ := CompleteQuest(late, "goal", deadline.AddDate(0, 0, 2)); Has(events, EventEarlyBonus) {
+		t.Error("late goal should not get a bonus")
+	}
+}
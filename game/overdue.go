@@ -0,0 +1,57 @@
+package game
+
+import (
+	"magus/deadline"
+	"magus/player"
+	"magus/snooze"
+	"time"
+)
+
+// ApplyOverdue начисляет штраф за дни просрочки, наступившие с прошлого
+// начисления: за каждый день игрок теряет hpPerDay HP, а награда квеста
+// уменьшается на xpDecay процентов. Ритуалы и повторяющиеся квесты не
+// штрафуются — у пропусков там свои правила, — как и отложенные квесты.
+//
+// Новые квесты получают PenaltyAt при создании. У квестов из старых данных
+// его нет: для них учет начинается с now без штрафа за прошлые дни, иначе
+// первый запуск после обновления списал бы всю накопленную просрочку.
+func ApplyOverdue(w *World, hpPerDay, xpDecay int, now time.Time) []Event {
+	hidden := snooze.Hidden(w.Quests, now)
+	var events []Event
+	for i := range w.Quests {
+		q := &w.Quests[i]
+		if q.Completed || q.Deadline == nil || q.Type == player.TypeRitual || q.Recurrence != "" || hidden[q.ID] {
+			continue
+		}
+		if q.PenaltyAt == nil {
+			penaltyAt := now
+			q.PenaltyAt = &penaltyAt
+			events = append(events, Event{Kind: EventOverdueTracked, Quest: *q})
+			continue
+		}
+		days := deadline.OverdueDays(*q.Deadline, q.DeadlineTime, *q.PenaltyAt, now, w.DayStartHour)
+		if days == 0 {
+			continue
+		}
+		penaltyAt := now
+		q.PenaltyAt = &penaltyAt
+
+		xp := q.XP
+		for d := 0; d < days; d++ {
+			q.XP -= q.XP * xpDecay / 100
+		}
+		events = append(events, Event{Kind: EventQuestOverdue, Quest: *q, Amount: days})
+		if lost := xp - q.XP; lost > 0 {
+			events = append(events, Event{Kind: EventXPDecayed, Quest: *q, Amount: lost})
+		}
+
+		if w.Player != nil {
+			// HP не уходит ниже нуля
+			if hpLoss := min(days*hpPerDay, w.Player.HP); hpLoss > 0 {
+				w.Player.HP -= hpLoss
+				events = append(events, Event{Kind: EventHPLost, Quest: *q, Amount: hpLoss})
+			}
+		}
+	}
+	return events
+}
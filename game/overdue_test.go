@@ -0,0 +1,78 @@
+package game
+
+import (
+	"magus/player"
+	"testing"
+	"time"
+)
+
+func TestApplyOverdue(t *testing.T) {
+	due := time.Date(2024, 5, 10, 23, 59, 59, 0, time.Local)
+	created := due.AddDate(0, 0, -7) // Новые квесты учитывают просрочку с создания
+	w := newWorld(
+		player.Quest{ID: "late", Type: player.TypeFocus, XP: 100, Deadline: &due, PenaltyAt: &created},
+		player.Quest{ID: "ritual", Type: player.TypeRitual, Deadline: &due},
+		player.Quest{ID: "done", Type: player.TypeFocus, XP: 100, Deadline: &due, Completed: true},
+	)
+	w.Player.HP = 50
+
+	// Третий день просрочки
+	now := time.Date(2024, 5, 13, 9, 0, 0, 0, time.Local)
+	events := ApplyOverdue(w, 5, 10, now)
+	if got := Total(events, EventQuestOverdue); got != 3 {
+		t.Fatalf("overdue days = %d, want 3", got)
+	}
+	if w.Player.HP != 35 || Total(events, EventHPLost) != 15 {
+		t.Errorf("player HP = %d, want 35", w.Player.HP)
+	}
+	// 100 → 90 → 81 → 73
+	if w.Quests[0].XP != 73 || Total(events, EventXPDecayed) != 27 {
+		t.Errorf("quest XP = %d, want 73", w.Quests[0].XP)
+	}
+
+	// Повторный запуск в тот же день ничего не начисляет
+	if events := ApplyOverdue(w, 5, 10, now.Add(time.Hour)); len(events) != 0 {
+		t.Errorf("penalty applied twice: %+v", events)
+	}
+	events = ApplyOverdue(w, 5, 10, now.AddDate(0, 0, 1))
+	if Total(events, EventQuestOverdue) != 1 || w.Player.HP != 30 {
+		t.Errorf("next day: events %+v, HP %d", events, w.Player.HP)
+	}
+}
+
+func TestApplyOverdueKeepsHPNonNegative(t *testing.T) {
+	due := time.Date(2024, 5, 10, 18, 0, 0, 0, time.Local)
+	created := due.AddDate(0, 0, -1)
+	w := newWorld(player.Quest{ID: "late", Type: player.TypeGoal, XP: 50, Deadline: &due, PenaltyAt: &created})
+	w.Player.HP = 3
+
+	ApplyOverdue(w, 5, 0, time.Date(2024, 5, 12, 12, 0, 0, 0, time.Local))
+	if w.Player.HP != 0 {
+		t.Errorf("player HP = %d, want 0", w.Player.HP)
+	}
+	if w.Quests[0].XP != 50 {
+		t.Errorf("XP decayed with zero decay: %d", w.Quests[0].XP)
+	}
+}
+
+func TestApplyOverdueLegacyQuests(t *testing.T) {
+	// Квест из старых данных просрочен на месяц, но PenaltyAt у него нет
+	due := time.Date(2024, 4, 10, 23, 59, 59, 0, time.Local)
+	w := newWorld(player.Quest{ID: "old", Type: player.TypeFocus, XP: 100, Deadline: &due})
+	w.Player.HP = 50
+
+	now := time.Date(2024, 5, 13, 9, 0, 0, 0, time.Local)
+	events := ApplyOverdue(w, 5, 10, now)
+	if !Has(events, EventOverdueTracked) || Has(events, EventQuestOverdue) {
+		t.Fatalf("first run after upgrade: %+v", events)
+	}
+	if w.Player.HP != 50 || w.Quests[0].XP != 100 || w.Quests[0].PenaltyAt == nil {
+		t.Errorf("backlog charged: HP %d, XP %d", w.Player.HP, w.Quests[0].XP)
+	}
+
+	// Дальше штрафуются только новые дни
+	events = ApplyOverdue(w, 5, 10, now.AddDate(0, 0, 1))
+	if Total(events, EventQuestOverdue) != 1 || w.Player.HP != 45 || w.Quests[0].XP != 90 {
+		t.Errorf("next day: events %+v, HP %d, XP %d", events, w.Player.HP, w.Quests[0].XP)
+	}
+}
@@ -0,0 +1,166 @@
+package game
+
+import (
+	"magus/agenda"
+	"magus/deps"
+	"magus/player"
+	"magus/recur"
+	"magus/ritual"
+	"time"
+)
+
+// CompleteQuest завершает квест по правилам его типа:
+//   - ритуал выполняется через CompleteRitual;
+//   - фокус-квест с HP побеждается только в фокус-сессии;
+//   - цель завершается, когда выполнены все ее подзадачи;
+//   - у повторяющегося квеста засчитывается экземпляр, срок переносится.
+//
+// Когда выполнена последняя подзадача, цель-родитель завершается сама.
+func CompleteQuest(w *World, questID string, now time.Time) ([]Event, error) {
+	i := w.find(questID)
+	if i < 0 {
+		return nil, ErrQuestNotFound
+	}
+	q := w.Quests[i]
+
+	switch {
+	case q.Completed:
+		return nil, ErrAlreadyCompleted
+	case deps.IsBlocked(q, deps.Index(w.Quests)):
+		return nil, ErrBlocked
+	case q.Type == player.TypeRitual:
+		return CompleteRitual(w, questID, now)
+	case q.Type == player.TypeFocus && q.HP > 0:
+		return nil, ErrNeedsDungeon
+	case q.Type == player.TypeGoal && hasOpenChildren(w.Quests, q.ID):
+		return nil, ErrOpenSubquests
+	}
+
+	var events []Event
+	if recur.Complete(&w.Quests[i], now) {
+		events = append(events, Event{Kind: EventRecurringDone, Quest: w.Quests[i]})
+		events = append(events, w.reward(q)...)
+		return w.levelUp(events), nil
+	}
+
+	events = append(events, w.complete(i, now, EventQuestCompleted)...)
+	events = append(events, w.completeParents(q.ParentID, now)...)
+	return w.levelUp(events), nil
+}
+
+// CompleteRitual выполняет ритуал: учитывает перезарядку, восстанавливает
+// ману (не выше максимума) и продлевает серию. Последний ритуал игрового
+// дня приносит бонус XP класса (см. effects.Sheet.RitualsXP). При
+// перезарядке возвращает ritual.ErrCooldown.
+func CompleteRitual(w *World, questID string, now time.Time) ([]Event, error) {
+	i := w.find(questID)
+	if i < 0 {
+		return nil, ErrQuestNotFound
+	}
+	if w.Quests[i].Type != player.TypeRitual {
+		return nil, ErrNotRitual
+	}
+
+	pending := w.pendingRituals(now)
+	reward, err := ritual.Complete(&w.Quests[i], now, w.DayStartHour)
+	if err != nil {
+		return nil, err
+	}
+	q := w.Quests[i]
+
+	if w.Player != nil {
+		w.Player.Mana += reward.Mana
+		if w.Player.Mana > w.Player.MaxMana {
+			w.Player.Mana = w.Player.MaxMana
+		}
+	}
+	events := []Event{{Kind: EventManaRestored, Quest: q, Amount: reward.Mana}}
+	if reward.Streak > 1 {
+		events = append(events, Event{Kind: EventStreak, Quest: q, Amount: reward.Streak})
+	}
+	if reward.NewBest {
+		events = append(events, Event{Kind: EventNewBestStreak, Quest: q, Amount: reward.Streak})
+	}
+
+	// Бонус дается один раз: когда закрыт последний из ожидавших ритуалов
+	if bonus := w.Effects.RitualsXP(); bonus > 0 && w.Player != nil && pending[q.ID] && len(w.pendingRituals(now)) == 0 {
+		events = append(events, Event{Kind: EventRitualsDone, Quest: q, Amount: w.Effects.QuestXP(player.TypeRitual, bonus)})
+		events = append(events, w.grantXP(bonus, player.TypeRitual)...)
+	}
+	return w.levelUp(events), nil
+}
+
+// pendingRituals возвращает ID ритуалов, которые еще ждут выполнения
+// в игровой день now (см. agenda.Build).
+func (w *World) pendingRituals(now time.Time) map[string]bool {
+	pending := make(map[string]bool)
+	for _, q := range agenda.Build(w.Quests, nil, nil, now, w.DayStartHour).Rituals {
+		pending[q.ID] = true
+	}
+	return pending
+}
+
+// complete закрывает квест с индексом i и начисляет его XP.
+func (w *World) complete(i int, now time.Time, kind EventKind) []Event {
+	q := &w.Quests[i]
+	q.Completed = true
+	q.CompletedAt = now
+	q.Progress = q.HP // Заполняем прогресс при завершении
+	if q.Rewarded {
+		// Квест из архива: награда за него уже выдана
+		return []Event{{Kind: kind, Quest: *q}}
+	}
+	if w.Player != nil {
+		w.Player.History.QuestsCompleted++
+	}
+	events := append([]Event{{Kind: kind, Quest: *q, Amount: w.questXP(*q)}}, w.reward(*q)...)
+
+	// Цель, закрытая до срока, приносит бонус
+	if q.Type == player.TypeGoal && onTime(*q, now) {
+		if bonus := q.XP * EarlyGoalBonus / 100; bonus > 0 && w.Player != nil {
+			events = append(events, Event{Kind: EventEarlyBonus, Quest: *q, Amount: w.Effects.QuestXP(q.Type, bonus)})
+			events = append(events, w.grantXP(bonus, q.Type)...)
+		}
+	}
+	return events
+}
+
+// completeParents завершает цели вверх по иерархии, у которых не осталось
+// невыполненных подзадач.
+func (w *World) completeParents(parentID string, now time.Time) []Event {
+	var events []Event
+	for parentID != "" {
+		i := w.find(parentID)
+		if i < 0 {
+			break
+		}
+		parent := w.Quests[i]
+		if parent.Completed || parent.Type != player.TypeGoal || hasOpenChildren(w.Quests, parent.ID) {
+			break
+		}
+		events = append(events, w.complete(i, now, EventParentCompleted)...)
+		parentID = parent.ParentID
+	}
+	return events
+}
+
+// CloseGoals завершает цель parentID и цели над ней, если после переноса
+// подзадач в ней остались только выполненные. Цель без подзадач остается
+// открытой.
+func CloseGoals(w *World, parentID string, now time.Time) []Event {
+	for _, q := range w.Quests {
+		if q.ParentID == parentID && parentID != "" {
+			return w.levelUp(w.completeParents(parentID, now))
+		}
+	}
+	return nil
+}
+
+func hasOpenChildren(quests []player.Quest, parentID string) bool {
+	for _, q := range quests {
+		if q.ParentID == parentID && !q.Completed {
+			return true
+		}
+	}
+	return false
+}
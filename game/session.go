@@ -0,0 +1,83 @@
+package game
+
+import (
+	"magus/dungeon"
+	"magus/player"
+	"magus/storage"
+	"time"
+)
+
+const (
+	// SessionXPPerMinute — XP за минуту фокус-сессии.
+	SessionXPPerMinute = 2
+	// CleanSessionBonus — бонус за сессию, в которой игрок не отвлекался сверх атак.
+	CleanSessionBonus = 25
+	// DistractionHPLoss — потеря HP игрока за каждое отвлечение сверх атак.
+	DistractionHPLoss = 5
+)
+
+// Session — итоги фокус-сессии, которые передает интерфейс.
+type Session struct {
+	Duration     time.Duration
+	Success      bool                // Сессия дошла до конца
+	Attacks      int                 // Атаки на концентрацию во время сессии
+	Distractions int                 // Реальные отвлечения, которые отметил игрок
+	Quests       []storage.QuestTime // Минуты сессии по квестам
+}
+
+// FinishSession подводит итоги сессии: начисляет XP за время, снимает HP
+// за лишние отвлечения, записывает минуты квестам и наносит им урон.
+// Побежденные квесты завершаются и приносят свой XP.
+func FinishSession(w *World, s Session, now time.Time) []Event {
+	minutes := int(s.Duration.Minutes())
+	var events []Event
+
+	xp := minutes * SessionXPPerMinute
+	if s.Success && s.Distractions <= s.Attacks {
+		xp += CleanSessionBonus
+	}
+	events = append(events, w.grantXP(xp, player.TypeFocus)...)
+
+	if w.Player != nil {
+		if hpLoss := (s.Distractions - s.Attacks) * DistractionHPLoss; hpLoss > 0 {
+			w.Player.HP -= hpLoss
+			events = append(events, Event{Kind: EventHPLost, Amount: hpLoss})
+		}
+		w.Player.History.FocusMinutes += minutes
+	}
+
+	for _, qt := range s.Quests {
+		i := w.find(qt.QuestID)
+		if i < 0 {
+			continue
+		}
+		w.Quests[i].FocusMinutes += qt.Minutes
+
+		damage := dungeon.Damage(qt.Minutes, s.Distractions, w.Effects.DamageBonus())
+		dealt, defeated := dungeon.Attack(&w.Quests[i], damage, now)
+		if dealt > 0 {
+			events = append(events, Event{Kind: EventQuestDamaged, Quest: w.Quests[i], Amount: dealt})
+		}
+		if defeated {
+			events = append(events, w.defeat(i, now)...)
+		}
+	}
+	return w.levelUp(events)
+}
+
+// defeat начисляет награду за побежденный фокус-квест с индексом i.
+func (w *World) defeat(i int, now time.Time) []Event {
+	q := w.Quests[i]
+	events := []Event{{Kind: EventQuestDefeated, Quest: q}}
+	if !q.Rewarded {
+		if w.Player != nil {
+			w.Player.History.QuestsCompleted++
+		}
+		events[0].Amount = w.questXP(q)
+		events = append(events, w.reward(q)...)
+	}
+	if q.Completed {
+		events = append(events, w.completeParents(q.ParentID, now)...)
+	}
+	return events
+}
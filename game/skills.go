@@ -0,0 +1,18 @@
+package game
+
+import (
+	"magus/player"
+	"magus/rpg"
+)
+
+// UnlockSkill изучает навык за очко навыков. Ошибки — из пакета rpg
+// (rpg.ErrSkillUnlocked, rpg.ErrNoSkillPoints, rpg.ErrRequirementsNotMet).
+func UnlockSkill(w *World, node player.SkillNode, skillTree map[string]player.SkillNode) ([]Event, error) {
+	if w.Player == nil {
+		return nil, player.ErrPlayerNotFound
+	}
+	if err := rpg.UnlockSkill(w.Player, node, skillTree); err != nil {
+		return nil, err
+	}
+	return []Event{{Kind: EventSkillUnlocked, Skill: node}}, nil
+}
@@ -0,0 +1,556 @@
+package i18n
+
+// en — английский каталог сообщений.
+var en = map[string]string{
+	// Общие сообщения
+	"main.err_tui":             "Failed to start the TUI",
+	"main.unknown_command":     "Unknown command:",
+	"err.load_quests":          "❌ Failed to load quests:",
+	"err.save_quests":          "❌ Failed to save quests:",
+	"err.load_archive":         "❌ Failed to load the archive:",
+	"err.save_archive":         "❌ Failed to save the archive:",
+	"err.quest_not_found":      "⚠️ No quest with this ID.",
+	"quest.type.focus":         "Focus",
+	"quest.type.ritual":        "Ritual",
+	"quest.type.goal":          "Goal",
+	"quest.ritual.restoration": "restoration",
+	"quest.ritual.maintenance": "maintenance",
+	"quest.priority.low":       "low",
+	"quest.priority.medium":    "medium",
+	"quest.priority.high":      "high",
+	"time.minutes":             "%d min",
+	"time.hours_minutes":       "%dh %dm",
+
+	// Классы
+	"class.mage":         "Mage",
+	"class.mage.desc":    "Master of deep work: stronger in focus sessions.",
+	"class.warrior":      "Warrior",
+	"class.warrior.desc": "Warrior of discipline: thrives on daily rituals.",
+	"class.rogue":        "Rogue",
+	"class.rogue.desc":   "Trickster: grows skills faster and gets distracted less.",
+
+	// rpg
+	"rpg.err_read_tree":     "failed to read data/skill_tree.json",
+	"rpg.err_parse_tree":    "failed to parse data/skill_tree.json",
+	"rpg.err_read_classes":  "failed to read data/classes.json",
+	"rpg.err_parse_classes": "failed to parse data/classes.json",
+	"rpg.err_level_req":     "Failed to parse level requirement: %s",
+
+	// Навыки (общие для CLI и TUI)
+	"skill.already_learned":  "✅ Skill already learned.",
+	"skill.no_points":        "❗ Not enough skill points.",
+	"skill.reqs_not_met":     "🔒 Requirements for '%s' are not met.",
+	"skill.learned":          "✨ Skill '%s' learned!",
+	"skill.status_learned":   "LEARNED",
+	"skill.status_available": "AVAILABLE",
+	"skill.status_no_points": "NOT ENOUGH POINTS",
+	"skill.status_locked":    "LOCKED",
+	"skill.reqs_none":        "Requirements: none",
+	"skill.req_level":        "Level %s",
+	"skill.reqs":             "Requires: ",
+
+	// Характеристики
+	"stat.strength":     "Strength",
+	"stat.endurance":    "Endurance",
+	"stat.intelligence": "Intelligence",
+	"stat.focus":        "Focus",
+	"stat.charisma":     "Charisma",
+	"stat.willpower":    "Willpower",
+	"stat.discipline":   "Discipline",
+	"stat.trained":      "💪 %s +1 (now %d)",
+
+	// Базовые навыки с рангами
+	"skill.rank.discipline":   "Discipline",
+	"skill.rank.vitality":     "Vitality",
+	"skill.rank.intelligence": "Intelligence",
+	"skill.rank.focus":        "Focus",
+	"skill.rank.learning":     "Learning",
+	"skill.rank.regeneration": "Regeneration",
+	"skill.rank.strength":     "Strength",
+	"skill.rank.fortitude":    "Fortitude",
+	"skill.rank.efficiency":   "Efficiency",
+
+	// Эффекты навыков и класса (пакет effects)
+	"effects.stat.max_hp":       "Max HP",
+	"effects.stat.max_mana":     "Max mana",
+	"effects.stat.xp":           "XP multiplier",
+	"effects.stat.damage":       "Damage to focus quests",
+	"effects.stat.distraction":  "Distraction chance in the dungeon",
+	"effects.stat.mana_cost":    "Session cost",
+	"effects.stat.focus_xp":     "Focus XP",
+	"effects.stat.goal_xp":      "Goal XP",
+	"effects.stat.rituals_xp":   "Bonus for all daily rituals",
+	"effects.stat.skill_points": "Skill points per level",
+	"effects.mana_cost":         "%d mana per %d min",
+	"effects.rank":              "%s ×%d",
+	"effects.class":             "%s: %s",
+
+	// magus add
+	"cmd.add.usage":                "Usage: magus add \"quest title\" [--type=focus] [--xp=10] [--parent=ID] [--tags=\"tag1,tag2\"] [--deadline=\"YYYY-MM-DD [HH:MM]\"] [--every=\"FREQ=WEEKLY;BYDAY=MO\"] [--blocked-by=ID1,ID2] [--priority=high] [--template=name] [--start=3d]",
+	"cmd.add.flag_type":            "Quest type (focus, ritual, goal)",
+	"cmd.add.flag_xp":              "XP reward for the quest",
+	"cmd.add.flag_parent":          "Parent quest ID",
+	"cmd.add.flag_tags":            "Comma-separated tags, nested with / (e.g., \"work/backend,home\")",
+	"cmd.add.flag_deadline":        "Deadline: YYYY-MM-DD or \"YYYY-MM-DD HH:MM\" (local time)",
+	"cmd.add.flag_every":           "Repeat schedule (RRULE), e.g. \"FREQ=WEEKLY;BYDAY=MO\"",
+	"cmd.add.flag_blocked_by":      "Comma-separated IDs of quests that must be done first",
+	"cmd.add.flag_priority":        "Priority: high, medium, low (or h, m, l)",
+	"cmd.add.flag_template":        "Create a quest tree from a template in data/templates",
+	"cmd.add.flag_start":           "Defer the quest until a start date: 3d, 2w, 4h or YYYY-MM-DD",
+	"cmd.add.err_priority":         "❌ Unknown priority %q. Use high, medium or low.",
+	"cmd.add.err_type":             "❌ Unknown quest type %q. Use focus, ritual or goal.",
+	"cmd.add.err_every":            "❌ Invalid repeat schedule:",
+	"cmd.add.err_template":         "❌ Template error:",
+	"cmd.add.err_start":            "❌ Unknown start date %q. Examples: 3d, 2w, 4h, 2025-09-01.",
+	"cmd.add.template_not_found":   "❌ Template %q not found in %s. Available templates:",
+	"cmd.add.err_deadline":         "❌ Unknown deadline. Use YYYY-MM-DD or \"YYYY-MM-DD HH:MM\":",
+	"cmd.add.err_load_player_perk": "❌ Failed to load the player to apply perks:",
+	"cmd.add.perk_planning":        "✨ 'Planning' perk: +%d XP to the parent quest!",
+	"cmd.add.err_save":             "❌ Failed to save the quest:",
+	"cmd.add.added":                "🗒️ Quest added:",
+	"cmd.add.subquest_of":          "   (Subquest of %s)",
+	"cmd.add.template_added":       "🗂️ Template %q: added %d quests",
+
+	// magus complete
+	"cmd.complete.usage":           "Usage: magus complete <quest_id>",
+	"cmd.complete.already_done":    "⚠️ The quest is already completed.",
+	"cmd.complete.blocked":         "⛔ The quest is waiting for other quests:",
+	"cmd.complete.goal_direct":     "⚠️ A goal can only be completed once all its subquests are done.",
+	"cmd.complete.needs_dungeon":   "⚔ Focus quests are defeated in focus sessions: %d HP left. Run `magus` and enter the dungeon.",
+	"cmd.complete.ritual_done":     "💧 Ritual done: +%d mana. Streak: %d",
+	"cmd.complete.done":            "✅ Quest completed!",
+	"cmd.complete.recurring_done":  "🔁 Done! Next time: %s",
+	"cmd.complete.parent_done":     "🎉 All subquests done! Parent quest '%s' is completed!",
+	"cmd.complete.early_bonus":     "⏰ Goal finished before the deadline: +%d bonus XP!",
+	"cmd.complete.damage":          "⚔ The quest took %d damage (HP: %d/%d).",
+	"cmd.complete.defeated":        "🏆 Quest '%s' defeated!",
+	"cmd.complete.ritual_cooldown": "⏳ The ritual is on cooldown until %s.",
+	"cmd.complete.ritual_best":     "🏆 New best streak!",
+	"cmd.complete.rituals_done":    "🛡️ All rituals of the day done: +%d class bonus XP!",
+	"cmd.complete.err_save_player": "❌ Failed to save the player:",
+	"cmd.xp.err_add":               "❌ Failed to add XP:",
+	"cmd.xp.gained":                "✨ +%d XP!",
+	"cmd.xp.can_level_up":          "🔥 Congratulations! You have enough XP for %d new level(s). Run `magus` to choose your skills.",
+
+	// magus list
+	"cmd.list.empty":           "✨ No active quests. Time to add one! `magus add`",
+	"cmd.list.header_ready":    "📜 Ready to work on:",
+	"cmd.list.flag_ready":      "Show only unblocked quests",
+	"cmd.list.blocked_by":      "⛔ waiting for: %s",
+	"cmd.list.priority":        "⚑ %s",
+	"cmd.list.flag_sort":       "Order: urgency — most urgent first (default is file order)",
+	"cmd.list.err_sort":        "❌ Unknown sort order %q. Use urgency.",
+	"cmd.list.header":          "📜 Quests:",
+	"cmd.list.flag_upcoming":   "Show deferred quests",
+	"cmd.list.header_upcoming": "💤 Upcoming quests:",
+	"cmd.list.upcoming_empty":  "💤 No deferred quests.",
+	"cmd.list.deferred":        "💤 Deferred quests: %d (magus list --upcoming)",
+
+	// magus roadmap
+	"cmd.roadmap.usage":     "Usage: magus roadmap <quest_id>",
+	"cmd.roadmap.title":     "🗺️ Roadmap for goal: %s",
+	"cmd.roadmap.progress":  "Progress:",
+	"cmd.roadmap.subquests": "Subquests:",
+
+	// magus block / unblock
+	"cmd.block.usage":         "Usage: magus block <quest_id> <id_of_quest_to_do_first>",
+	"cmd.block.done":          "🔗 Quest %s now waits for %s.",
+	"cmd.block.self":          "⚠️ A quest can't wait for itself.",
+	"cmd.block.cycle":         "⚠️ This link would create a cycle: the quests would wait for each other forever.",
+	"cmd.unblock.usage":       "Usage: magus unblock <quest_id> <blocking_quest_id>",
+	"cmd.unblock.done":        "🔓 Quest %s no longer waits for %s.",
+	"cmd.unblock.not_blocked": "⚠️ The quest doesn't wait for that quest.",
+
+	// magus snooze
+	"cmd.snooze.usage":        "Usage: magus snooze <quest_id> <3d | 2w | 4h | YYYY-MM-DD | off>",
+	"cmd.snooze.done":         "💤 Quest '%s' snoozed until %s.",
+	"cmd.snooze.woken":        "⏰ Quest '%s' is back in the list.",
+	"cmd.snooze.err_duration": "⚠️ Unknown duration %q. Examples: 3d, 2w, 4h, 2025-09-01 or off.",
+
+	// magus tags
+	"cmd.tags.usage":     "Usage: magus tags [rename <tag> <new_name>]",
+	"cmd.tags.empty":     "🏷️ No tags yet.",
+	"cmd.tags.not_found": "⚠️ Tag #%s not found.",
+	"cmd.tags.err_load":  "Error loading tag settings:",
+	"cmd.tags.err_save":  "Error saving tag settings:",
+
+	// magus archive
+	"archive.moved":         "📦 Completed quests moved to the archive: %d.",
+	"cmd.archive.usage":     "Usage: magus archive [restore <quest_id>]",
+	"cmd.archive.empty":     "📦 The archive is empty.",
+	"cmd.archive.header":    "📦 Archive (%d):",
+	"cmd.archive.not_found": "⚠️ No archived quest with that ID.",
+	"cmd.archive.restored":  "♻️ Quest '%s' restored (quests brought back: %d).",
+
+	// magus check
+	"cmd.check.usage":     "Usage: magus check <quest_id> [add <text> | toggle <n> | remove <n>]",
+	"cmd.check.empty":     "☐ Quest '%s' has no checklist. Add an item: magus check <id> add <text>",
+	"cmd.check.header":    "☑ Checklist of '%s' (%s):",
+	"cmd.check.err_empty": "⚠️ Item text can't be empty.",
+	"cmd.check.err_item":  "⚠️ No such item. Item numbers: 1–%d.",
+
+	// magus search
+	"cmd.search.usage":           "Usage: magus search [--limit=10] <query>",
+	"cmd.search.flag_limit":      "Maximum number of results",
+	"cmd.search.err_reflections": "❌ Failed to load the reflection journal:",
+	"cmd.search.nothing":         "🔍 Nothing found for “%s”.",
+	"cmd.search.entry":           "entry",
+	"cmd.search.archived":        "archived",
+
+	// Строка статуса (magus prompt)
+	"prompt.format":            "Lv{level} ❤{hp}/{max_hp} 💧{mana}/{max_mana} ⏳{due} due",
+	"prompt.format_plain":      "Lv{level} HP {hp}/{max_hp} MP {mana}/{max_mana} due: {due}",
+	"cmd.prompt.flag_format":   "Line template. Placeholders: %s",
+	"cmd.prompt.flag_no_emoji": "Do not print emoji",
+
+	// План на день (magus agenda и экран "Сегодня")
+	"cmd.agenda.header":  "📅 Agenda for %s (day starts at %s)",
+	"cmd.agenda.empty":   "🌿 All done for today.",
+	"agenda.rituals":     "💧 Rituals for today:",
+	"agenda.due":         "⏳ Due today or overdue:",
+	"agenda.due_today":   "today",
+	"agenda.overdue":     "overdue",
+	"agenda.in_progress": "⚙️ Focus quests in progress:",
+	"agenda.plan":        "🗡️ Suggested focus sessions (mana: %d):",
+	"agenda.plan_empty":  "Nothing to plan or not enough mana.",
+	"agenda.session":     "%d min — %s (-%d mana)",
+	"agenda.mana_left":   "Mana left: %d",
+
+	// Повторяющиеся квесты
+	"recur.daily":          "every day",
+	"recur.every_n_days":   "every %d days",
+	"recur.weekly":         "weekly",
+	"recur.every_n_weeks":  "every %d weeks",
+	"recur.times_week":     "%d times a week",
+	"recur.monthly":        "monthly",
+	"recur.every_n_months": "every %d months",
+	"recur.times_month":    "%d times a month",
+	"recur.last_day":       "last day",
+	"recur.next":           "next: %s",
+	"recur.weekday.mo":     "Mon",
+	"recur.weekday.tu":     "Tue",
+	"recur.weekday.we":     "Wed",
+	"recur.weekday.th":     "Thu",
+	"recur.weekday.fr":     "Fri",
+	"recur.weekday.sa":     "Sat",
+	"recur.weekday.su":     "Sun",
+
+	// Серии ритуалов
+	"ritual.streak_broken": "💔 The '%s' ritual streak (%d) is broken.",
+
+	// Штрафы за просрочку
+	"overdue.quest":      "⏰ Quest '%s' is overdue (new overdue days: %d).",
+	"overdue.xp_decayed": "   📉 Reward reduced by %d XP, %d XP left.",
+	"overdue.hp_lost":    "   💔 Lost %d HP.",
+
+	// magus show
+	"cmd.show.no_player":        "🔮 No player found. Create one by running `magus` without arguments.",
+	"cmd.show.err_read_player":  "❌ Failed to read player.json:",
+	"cmd.show.name":             "🧙 Name: %s",
+	"cmd.show.class":            "🎖️ Class: %s",
+	"cmd.show.passives":         "Class bonuses:",
+	"cmd.show.passive":          "  • %s — %s",
+	"cmd.show.level":            "📈 Level: %d",
+	"cmd.show.xp":               "🔋 XP: %d / %d",
+	"cmd.show.skill_points":     "✨ Skill points: %d",
+	"cmd.show.perks":            "🎁 Perks:",
+	"cmd.show.err_tree":         "  Failed to load the skill tree:",
+	"cmd.show.common_header":    "--- Common skills ---",
+	"cmd.show.no_common":        "No common skills available.",
+	"cmd.show.class_header":     "--- Class skills ---",
+	"cmd.show.no_class":         "No class skills available.",
+	"cmd.show.learned":          "[LEARNED]",
+	"cmd.show.focus_total":      "⏱ Time in focus: %s",
+	"cmd.show.quests_completed": "✅ Quests completed: %d (archived: %d)",
+	"cmd.show.hp_mana":          "❤ HP: %d/%d  💧 Mana: %d/%d",
+	"cmd.show.effects":          "📊 Stats:",
+	"cmd.show.quest_title":      "📜 %s",
+	"cmd.show.quest_type":       "Type: %s",
+	"cmd.show.quest_completed":  "✅ Completed: %s",
+	"cmd.show.quest_archived":   "📦 Archived. Restore with: magus archive restore %s",
+	"cmd.show.quest_priority":   "⚑ Priority: %s",
+	"cmd.show.quest_hp":         "HP: %d/%d",
+	"cmd.show.quest_xp":         "🔋 XP: %d",
+	"cmd.show.quest_checklist":  "☑ Checklist: %s",
+	"cmd.show.quest_notes":      "📝 Notes:",
+	"cmd.show.quest_links":      "🔗 Links:",
+	"cmd.show.quest_tags":       "🏷️ Tags: %s",
+	"cmd.show.quest_deadline":   "⏳ Deadline: %s",
+	"cmd.show.quest_deferred":   "💤 Deferred until: %s",
+	"cmd.show.quest_focus":      "⏱ Time in focus: %s",
+
+	// magus skills
+	"cmd.skills.usage":     "Usage: magus skills [list | tree | show <id> | unlock <id>]",
+	"cmd.skills.not_found": "⚠️ Skill '%s' is not in the common tree or your class tree.",
+	"cmd.skills.type":      "Type: %s",
+	"cmd.skills.status":    "Status: %s",
+	"cmd.skills.effects":   "Effects: ",
+	"cmd.skills.err_save":  "❌ Failed to save the player:",
+	"cmd.skills.legend":    "[✓] learned  [+] available  [!] not enough points  [ ] locked",
+
+	// magus why / version
+	"cmd.why.title": "🧭 Why you are here, mage:",
+	"cmd.why.line1": "You are building a tool to focus and level yourself up in real life.",
+	"cmd.why.line2": "Every quest is a step toward your level and your goal. Don't give up.",
+	"cmd.version":   "🧙 Magus v%s",
+
+	// TUI: общие поля
+	"tui.initializing":          "Initializing...",
+	"tui.field.title":           "Title",
+	"tui.field.type":            "Type",
+	"tui.field.subtype":         "Subtype",
+	"tui.field.tags":            "Tags (comma-separated, nested with /)",
+	"tui.field.deadline":        "Deadline (YYYY-MM-DD [HH:MM])",
+	"tui.field.recurrence":      "Repeat (RRULE, empty — no repeat)",
+	"tui.field.description":     "Description (Markdown)",
+	"tui.field.links":           "Links and files (comma-separated)",
+	"tui.field.note":            "New note (notes so far: %d)",
+	"tui.field.template":        "Template (←/→)",
+	"tui.deadline.overdue":      "(Overdue)",
+	"tui.deadline.days_left":    "(%d d left)",
+	"tui.deadline.hours_left":   "(%d h %02d min left)",
+	"tui.deadline.minutes_left": "(%d min left)",
+
+	// TUI: главный экран
+	"tui.home.player":        "Player: %s (Level: %d)",
+	"tui.home.class":         "Class: %s",
+	"tui.home.hp":            "HP: %d / %d",
+	"tui.home.mana":          "Mana: %d / %d",
+	"tui.home.gold":          "Gold: %d",
+	"tui.home.skills":        "Skills: %d",
+	"tui.home.skill_points":  "Skill points: %d",
+	"tui.home.focus_time":    "In focus: %s",
+	"tui.home.xp":            "📈 XP: %d / %d",
+	"tui.home.menu_quests":   "Active quests",
+	"tui.home.menu_agenda":   "Today",
+	"tui.home.menu_upcoming": "Upcoming",
+	"tui.home.menu_skills":   "Skill tree",
+	"tui.home.menu_effects":  "Stats",
+	"tui.home.menu_dungeon":  "Enter the dungeon",
+	"tui.home.menu_search":   "Search",
+	"tui.home.menu_journal":  "Journal",
+	"tui.home.menu_exit":     "Exit",
+	"tui.effects.title":      "📊 Stats",
+	"tui.effects.base":       "base value",
+	"tui.effects.help":       "q/esc — back",
+
+	// TUI: создание игрока и выбор класса
+	"tui.create.placeholder": "Your hero's name",
+	"tui.create.welcome":     "Welcome to Magus!\n\nLet's create your character.\n\n%s\n\nPress Enter to begin.",
+	"tui.class.title":        "⚔️ Time to choose your path!",
+	"tui.class.prompt":       "Choose a class:",
+	"tui.class.help":         "Press 'enter' to choose. This choice can't be changed.",
+	"tui.class.err_load":     "Failed to load class bonuses: %v",
+	// TUI: список квестов
+	"tui.quests.title":             "Active quests",
+	"tui.quests.key_add":           "add",
+	"tui.quests.key_delete":        "delete",
+	"tui.quests.key_expand":        "expand",
+	"tui.quests.key_block":         "wait for / clear",
+	"tui.quests.key_priority":      "priority",
+	"tui.quests.key_sort":          "by urgency",
+	"tui.quests.key_snooze":        "snooze",
+	"tui.quests.key_upcoming":      "upcoming",
+	"tui.quests.key_completed":     "completed",
+	"tui.quests.key_checklist":     "checklist",
+	"tui.quests.key_details":       "details",
+	"tui.quests.key_tags":          "tags",
+	"tui.quests.key_mark":          "mark",
+	"tui.quests.key_bulk_tag":      "tag marked",
+	"tui.quests.key_bulk_deadline": "deadline for marked",
+	"tui.quests.key_bulk_move":     "move",
+	"tui.quests.key_bulk_dungeon":  "to dungeon",
+	"tui.quests.key_undo":          "undo",
+	"tui.quests.key_reorder":       "up/down",
+	"tui.quests.key_indent":        "indent/outdent",
+	"tui.quests.order_urgency":     "Order can only be changed without urgency sorting (o)",
+	"tui.quests.indent_none":       "There is no sibling above to indent the quest under",
+	"tui.quests.indented":          "📂 '%s' is now a subquest of '%s'",
+	"tui.quests.outdented":         "📂 '%s' moved one level up",
+	"tui.quests.outdent_top":       "The quest is already at the top level",
+
+	// Массовые операции в списке квестов
+	"tui.bulk.title_marked":           "Active quests · marked: %d",
+	"tui.bulk.title_range":            "Active quests · range: %d (V or space to keep)",
+	"tui.bulk.prompt_add_tag":         "Tag for %d quests (enter to continue, esc to cancel)",
+	"tui.bulk.prompt_remove_tag":      "Tag to remove from %d quests (enter to continue, esc to cancel)",
+	"tui.bulk.prompt_deadline":        "Deadline for %d quests: YYYY-MM-DD [HH:MM], empty to clear",
+	"tui.bulk.pick_parent":            "Where to move %d quests: enter — under the selected one, 0 — top level, esc — cancel",
+	"tui.bulk.confirm_complete":       "Complete %d quests? y — yes, n — no",
+	"tui.bulk.confirm_delete":         "Delete %d quests with their subquests? y — yes, n — no",
+	"tui.bulk.confirm_add_tag":        "Add #%s to %d quests? y — yes, n — no",
+	"tui.bulk.confirm_remove_tag":     "Remove #%s from %d quests? y — yes, n — no",
+	"tui.bulk.confirm_deadline":       "Set deadline %s for %d quests? y — yes, n — no",
+	"tui.bulk.confirm_clear_deadline": "Clear the deadline of %d quests? y — yes, n — no",
+	"tui.bulk.confirm_move":           "Move %d quests under '%s'? y — yes, n — no",
+	"tui.bulk.top_level":              "top level",
+	"tui.bulk.cancelled":              "Operation cancelled",
+	"tui.bulk.err_cycle":              "❗ Can't move quests under '%s': it is one of them or their subquest",
+	"tui.bulk.err_parent_done":        "❗ '%s' is already completed: only completed quests can be moved under it",
+	"tui.bulk.completed":              "✅ Quests completed: %d, +%d XP.",
+	"tui.bulk.skipped":                "Skipped: %d.",
+	"tui.bulk.deleted":                "🗑️ Quests deleted with subquests: %d.",
+	"tui.bulk.tag_added":              "🏷️ #%s added to quests: %d.",
+	"tui.bulk.tag_removed":            "🏷️ #%s removed from quests: %d.",
+	"tui.bulk.deadline_set":           "⏳ Deadline %s set for quests: %d.",
+	"tui.bulk.deadline_cleared":       "⏳ Deadline cleared for quests: %d.",
+	"tui.bulk.moved":                  "📂 Quests moved: %d.",
+	"tui.bulk.no_focus":               "No unfinished focus quests among the marked ones",
+	"tui.bulk.nothing_to_undo":        "Nothing to undo",
+	"tui.bulk.undo_stale":             "Quests changed after the operation — it can no longer be undone",
+	"tui.bulk.undone":                 "↩️ The last bulk operation was undone",
+	"tui.quests.deleted":              "🗑️ Quest '%s' and all its subquests deleted.",
+	"tui.quests.already_done":         "✅ Quest already completed",
+	"tui.quests.focus_in_dungeon":     "❗ This quest is done in a focus session (dungeon)",
+	"tui.quests.blocked_by":           "⛔ waiting for: %s",
+	"tui.quests.is_blocked":           "⛔ Finish these first: %s",
+	"tui.quests.link_pick":            "What must be done before “%s”? b/enter — pick, esc — cancel",
+	"tui.quests.link_cancelled":       "No link added",
+	"tui.quests.link_self":            "⚠️ A quest can't wait for itself",
+	"tui.quests.link_cycle":           "⚠️ This link would create a cycle",
+	"tui.quests.link_error":           "❌ Failed to add the link: %v",
+	"tui.quests.linked":               "🔗 Now waiting for “%s”",
+	"tui.quests.unlinked":             "🔓 All blockers removed from “%s”",
+	"tui.quests.priority":             "⚑ %s",
+	"tui.quests.priority_set":         "⚑ Priority of “%s”: %s",
+	"tui.quests.priority_cleared":     "Priority of “%s” cleared",
+	"tui.quests.sort_urgency":         "⚡ Most urgent first",
+	"tui.quests.sort_file":            "Manual order",
+	"tui.quests.completed_shown":      "Showing completed quests",
+	"tui.quests.completed_hidden":     "Completed quests hidden",
+	"tui.quests.goal_has_children":    "❗ Complete all subquests of goal '%s' first",
+	"tui.quests.parent_done":          "🎉 Goal '%s' completed! +%d XP",
+	"tui.quests.early_bonus":          "⏰ Ahead of the deadline: +%d XP!",
+	"tui.quests.ritual_mana":          "💧 +%d mana for ritual '%s'",
+	"tui.quests.recurring_done":       "🔁 '%s' done, next time: %s",
+	"tui.quests.ritual_cooldown":      "⏳ '%s' is on cooldown until %s",
+	"tui.ritual.streak":               "🔥 Streak: %d.",
+	"tui.ritual.new_best":             "🏆 New best!",
+	"tui.quests.rituals_done":         "🛡️ All daily rituals: +%d XP!",
+	"tui.ritual.streak_card":          "🔥 %d (best %d)",
+	"tui.ritual.cooldown":             "✓ again at %s",
+	"tui.quests.xp_gained":            "✨ +%d XP for quest '%s'!",
+	"tui.quests.level_no_skills":      "🔮 New level! No skills are available to learn yet.",
+
+	// TUI: добавление и редактирование квеста
+	"tui.add.title":                   "📝 New quest",
+	"tui.add.placeholder_title":       "Quest title",
+	"tui.add.placeholder_tags":        "work,home",
+	"tui.add.placeholder_deadline":    "YYYY-MM-DD HH:MM",
+	"tui.add.placeholder_recurrence":  "FREQ=WEEKLY;BYDAY=MO",
+	"tui.add.placeholder_description": "Details, steps, links — in Markdown",
+	"tui.add.placeholder_links":       "https://…, ~/docs/plan.md",
+	"tui.add.err_recurrence":          "Invalid repeat schedule: %v",
+	"tui.add.err_template":            "Template error: %v",
+	"tui.add.err_deadline":            "Unknown deadline %q: use YYYY-MM-DD or YYYY-MM-DD HH:MM",
+	"tui.add.no_template":             "— no template —",
+	"tui.add.template_name":           "Name ({{name}} in the template)",
+	"tui.add.template_due":            "in %d d",
+	"tui.add.save_button":             "[ Save ]",
+	"tui.add.help":                    "ctrl+s - save, tab - next field, esc - cancel",
+	"tui.edit.title":                  "Edit quest",
+	"tui.edit.help":                   "Enter - save (Ctrl+S in description and note), Tab - next field, Esc - cancel",
+	"tui.edit.placeholder_note":       "What's new?",
+
+	// TUI: теги
+	"tui.tags.title":           "Manage tags",
+	"tui.tags.placeholder":     "New tag name",
+	"tui.tags.empty":           "You have no tags yet.",
+	"tui.tags.rename_to":       "Rename to: ",
+	"tui.tags.color_to":        "Color (#RRGGBB or ANSI number, empty for none): ",
+	"tui.tags.multiplier_to":   "XP multiplier (empty for none): ",
+	"tui.tags.usage":           "%d open · %d done · %d XP",
+	"tui.tags.trains":          "💪 %s",
+	"tui.tags.merging":         "← merging",
+	"tui.tags.renamed":         "🏷️ #%s → #%s (quests: %d)",
+	"tui.tags.err_multiplier":  "⚠️ The multiplier must be a positive number: %q.",
+	"tui.tags.err_into_itself": "⚠️ Cannot move #%s into itself (#%s).",
+	"tui.tags.merge_help":      "Pick the tag to merge #%s into and press 'm' or enter; esc cancels.",
+	"tui.tags.help":            "Navigation: ↑/↓, 'r' - rename, 'm' - merge, 'd' - delete (with nested), 'c' - color, 'x' - XP multiplier, 's' - stat, 'q' - back.",
+
+	// TUI: поиск и журнал
+	"tui.search.title":       "🔍 Search",
+	"tui.search.placeholder": "Title, tag or note text",
+	"tui.search.empty":       "Type a query to search quests and the journal.",
+	"tui.search.no_results":  "Nothing found.",
+	"tui.search.reflection":  "Journal: %s",
+	"tui.search.archived":    "Archive: %s",
+	"tui.search.restored":    "♻️ Quest '%s' restored from the archive",
+	"tui.search.err_restore": "Failed to restore the quest: %v",
+
+	// Quest checklist
+	"tui.checklist.title":       "☑ %s",
+	"tui.checklist.placeholder": "New item",
+	"tui.checklist.empty":       "No items yet. Press a to add one.",
+	"tui.checklist.err_empty":   "Item text can't be empty",
+	"tui.checklist.help":        "↑/↓: select | space: check | a: add | d: delete | esc: back",
+	"tui.checklist.help_adding": "enter: add | esc: cancel",
+
+	// Отложенные квесты
+	"tui.snooze.title":        "💤 Snooze “%s”",
+	"tui.snooze.err_duration": "Unknown duration %q",
+	"tui.snooze.help":         "3d, 2w, 4h or YYYY-MM-DD | enter: snooze | esc: cancel",
+	"tui.upcoming.title":      "💤 Upcoming quests",
+	"tui.upcoming.empty":      "No deferred quests. Press s in the quest list to snooze one.",
+	"tui.upcoming.help":       "↑/↓: select | w: bring back now | s: snooze again | esc: back",
+
+	// Quest details
+	"tui.detail.help":           "↑/↓: scroll | e: edit | x: checklist | esc: back",
+	"tui.detail.no_description": "No description. Press e to add one.",
+	"tui.detail.notes":          "📝 Notes",
+	"tui.detail.links":          "🔗 Links",
+	"tui.detail.deferred":       "💤 from %s",
+	"tui.search.help":           "↑/↓: select | enter: open or restore from the archive | esc: back",
+	"tui.journal.title":         "📓 Reflection journal",
+	"tui.journal.empty":         "No entries yet. They appear after focus sessions.",
+	"tui.journal.meta":          "Duration: %s · +%d XP · -%d HP",
+	"tui.journal.help":          "↑/↓: select entry | q/esc: back",
+	"tui.agenda.title":          "📅 Today, %s",
+	"tui.agenda.help":           "↑/↓: select | enter: open quest | p: dungeon with plan | q/esc: back",
+
+	// TUI: подземелье
+	"tui.prep.minutes":                   "%d minutes",
+	"tui.prep.duration_desc":             "Focus session length · %d mana",
+	"tui.prep.duration":                  "Duration",
+	"tui.prep.quests":                    "Quests for the session",
+	"tui.prep.no_mana":                   "Not enough mana! Need %d, you have %d.",
+	"tui.prep.start":                     "[ Start ]",
+	"tui.prep.start_focused":             "> Start <",
+	"tui.prep.help":                      "tab: switch focus | space: pick quest | s: by urgency | enter: start | q/esc: back",
+	"tui.dungeon.time_left":              "Time left: ",
+	"tui.dungeon.focus":                  "You are in the dungeon. Focus on your task.",
+	"tui.dungeon.attacks":                "Attacks on focus: %d",
+	"tui.dungeon.help":                   "Press 'q' or 'esc' to finish early.",
+	"tui.dungeon.confirm_exit":           "Are you sure you want to abort the focus session?",
+	"tui.dungeon.confirm_exit_note":      "Progress will not be counted.",
+	"tui.summary.title":                  "Session report",
+	"tui.summary.stats":                  "Duration: %s\nAttacks on focus: %d",
+	"tui.summary.distraction_prompt":     "How many times were you actually distracted?",
+	"tui.summary.reflection_placeholder": "What got done? What got in the way?",
+	"tui.summary.notes":                  "Session notes:",
+	"tui.summary.finish":                 "[ Finish ]",
+	"tui.summary.finish_focused":         "> Finish <",
+	"tui.summary.split":                  "Split %d min between quests:",
+	"tui.summary.quest_time":             "⏱ %d min → %s",
+	"tui.summary.split_invalid":          "Enter a number of minutes for “%s”",
+	"tui.summary.split_mismatch":         "%d min assigned, but the session lasted %d min",
+	"tui.summary.damage":                 "⚔ Damage to quests:",
+	"tui.summary.damage_hp":              "HP %d/%d (−%d)",
+	"tui.summary.defeated":               "⚔ Quest “%s” defeated! +%d XP",
+
+	// TUI: навыки и повышение уровня
+	"tui.skills.err_load":   "Failed to load the skill tree.",
+	"tui.skills.help":       "Navigation: ↑↓ | Tab: switch view | Enter: learn | q: back",
+	"tui.skills.common":     "Common skills",
+	"tui.skills.class":      "Class skills: %s",
+	"tui.skills.title":      "🧠 Skill tree (%s) | Points: %d",
+	"tui.skills.empty":      "No skills available in this category.",
+	"tui.skills.choose":     "Choose a skill...",
+	"tui.levelup.err_tree":  "🔮 New level! The skill tree failed to load: %v",
+	"tui.levelup.no_skills": "Nothing to learn at this level — your skill points are kept.",
+	"tui.levelup.title":     "🔥 Congratulations! Level %d!",
+	"tui.levelup.more":      "⏳ Enough XP for %d more level(s) — you'll pick a skill for each.",
+	"tui.levelup.prompt":    "Choose a skill to learn:",
+	"tui.levelup.help":      "Press 'enter' to choose.",
+	"tui.levelup.help_next": "Press 'enter' to continue.",
+}
@@ -0,0 +1,90 @@
+// Package i18n содержит каталоги сообщений интерфейса и выбор языка.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Lang — код языка интерфейса.
+type Lang string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+)
+
+// DefaultLang используется, если язык не задан ни в конфиге, ни в окружении.
+const DefaultLang = RU
+
+var catalogs = map[Lang]map[string]string{
+	RU: ru,
+	EN: en,
+}
+
+var current = DefaultLang
+
+// SetLang переключает язык интерфейса. Неизвестные языки игнорируются.
+func SetLang(l Lang) {
+	if _, ok := catalogs[l]; ok {
+		current = l
+	}
+}
+
+// Current возвращает текущий язык интерфейса.
+func Current() Lang {
+	return current
+}
+
+// Detect выбирает язык: сначала ключ из конфига, затем LC_ALL, LC_MESSAGES и LANG.
+func Detect(configured string) Lang {
+	if l, ok := parse(configured); ok {
+		return l
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			if l, ok := parse(v); ok {
+				return l
+			}
+			break // Переменная задана, но язык не поддерживается
+		}
+	}
+	return DefaultLang
+}
+
+// parse разбирает значения вида "en", "en_US.UTF-8" или "ru-RU".
+func parse(s string) (Lang, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return "", false
+	}
+	if i := strings.IndexAny(s, "_-.@"); i >= 0 {
+		s = s[:i]
+	}
+	l := Lang(s)
+	_, ok := catalogs[l]
+	return l, ok
+}
+
+// T возвращает перевод сообщения по ключу. Если переданы аргументы,
+// перевод используется как формат для fmt.Sprintf.
+// При отсутствии перевода используется русский каталог, затем сам ключ.
+func T(key string, args ...any) string {
+	msg, ok := catalogs[current][key]
+	if !ok {
+		if msg, ok = catalogs[DefaultLang][key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Has сообщает, есть ли ключ в каталоге текущего языка.
+func Has(key string) bool {
+	_, ok := catalogs[current][key]
+	return ok
+}
@@ -0,0 +1,136 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var verbRe = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+// TestCatalogsInSync проверяет, что у всех языков одинаковые ключи и форматные глаголы.
+func TestCatalogsInSync(t *testing.T) {
+	for lang, catalog := range catalogs {
+		if lang == DefaultLang {
+			continue
+		}
+		for key, base := range catalogs[DefaultLang] {
+			msg, ok := catalog[key]
+			if !ok {
+				t.Errorf("%s: missing key %q", lang, key)
+				continue
+			}
+			want := verbRe.FindAllString(base, -1)
+			got := verbRe.FindAllString(msg, -1)
+			if len(want) != len(got) {
+				t.Errorf("%s: key %q has verbs %v, want %v", lang, key, got, want)
+				continue
+			}
+			for i := range want {
+				if want[i] != got[i] {
+					t.Errorf("%s: key %q has verbs %v, want %v", lang, key, got, want)
+					break
+				}
+			}
+		}
+		for key := range catalog {
+			if _, ok := catalogs[DefaultLang][key]; !ok {
+				t.Errorf("%s: extra key %q not in %s catalog", lang, key, DefaultLang)
+			}
+		}
+	}
+}
+
+// TestUsedKeysExist проверяет, что каждый ключ-литерал, переданный в
+// i18n.T или i18n.Has где-либо в репозитории, есть в каталоге.
+func TestUsedKeysExist(t *testing.T) {
+	fset := token.NewFileSet()
+	err := filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && strings.HasPrefix(d.Name(), ".") && path != ".." {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		inPackage := file.Name.Name == "i18n"
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 || !isCatalogCall(call.Fun, inPackage) {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			key, _ := strconv.Unquote(lit.Value)
+			if _, ok := catalogs[DefaultLang][key]; !ok {
+				t.Errorf("%s: key %q is not in the catalog", fset.Position(lit.Pos()), key)
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// isCatalogCall сообщает, что fun — это i18n.T или i18n.Has (внутри
+// пакета i18n — просто T или Has).
+func isCatalogCall(fun ast.Expr, inPackage bool) bool {
+	switch f := fun.(type) {
+	case *ast.SelectorExpr:
+		pkg, ok := f.X.(*ast.Ident)
+		return ok && pkg.Name == "i18n" && (f.Sel.Name == "T" || f.Sel.Name == "Has")
+	case *ast.Ident:
+		return inPackage && (f.Name == "T" || f.Name == "Has")
+	}
+	return false
+}
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		configured string
+		lang       string
+		want       Lang
+	}{
+		{"en", "ru_RU.UTF-8", EN},
+		{"", "en_US.UTF-8", EN},
+		{"", "ru_RU.UTF-8", RU},
+		{"", "de_DE.UTF-8", DefaultLang},
+		{"", "", DefaultLang},
+		{"klingon", "en_GB", EN},
+	}
+	for _, c := range cases {
+		t.Setenv("LC_ALL", "")
+		t.Setenv("LC_MESSAGES", "")
+		t.Setenv("LANG", c.lang)
+		if got := Detect(c.configured); got != c.want {
+			t.Errorf("Detect(%q) with LANG=%q = %q, want %q", c.configured, c.lang, got, c.want)
+		}
+	}
+}
+
+func TestTFallback(t *testing.T) {
+	defer SetLang(Current())
+	SetLang(EN)
+	if got := T("cmd.xp.gained", 5); got != "✨ +5 XP!" {
+		t.Errorf("unexpected translation: %q", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("missing key should fall back to itself, got %q", got)
+	}
+}
@@ -0,0 +1,556 @@
+package i18n
+
+// ru — русский каталог сообщений. Он же служит запасным для отсутствующих переводов.
+var ru = map[string]string{
+	// Общие сообщения
+	"main.err_tui":             "Ошибка при запуске TUI",
+	"main.unknown_command":     "Неизвестная команда:",
+	"err.load_quests":          "❌ Ошибка загрузки квестов:",
+	"err.save_quests":          "❌ Ошибка сохранения квестов:",
+	"err.load_archive":         "❌ Ошибка загрузки архива:",
+	"err.save_archive":         "❌ Ошибка сохранения архива:",
+	"err.quest_not_found":      "⚠️ Квест с таким ID не найден.",
+	"quest.type.focus":         "Фокус",
+	"quest.type.ritual":        "Ритуал",
+	"quest.type.goal":          "Цель",
+	"quest.ritual.restoration": "восстановление",
+	"quest.ritual.maintenance": "поддержание",
+	"quest.priority.low":       "низкий",
+	"quest.priority.medium":    "средний",
+	"quest.priority.high":      "высокий",
+	"time.minutes":             "%d мин",
+	"time.hours_minutes":       "%d ч %d мин",
+
+	// Классы
+	"class.mage":         "Маг",
+	"class.mage.desc":    "Мастер глубокой работы: сильнее в фокус-сессиях.",
+	"class.warrior":      "Воин",
+	"class.warrior.desc": "Воин дисциплины: держится на ежедневных ритуалах.",
+	"class.rogue":        "Разбойник",
+	"class.rogue.desc":   "Ловкач: быстрее растет в навыках и реже отвлекается.",
+
+	// rpg
+	"rpg.err_read_tree":     "не удалось прочитать файл data/skill_tree.json",
+	"rpg.err_parse_tree":    "ошибка парсинга data/skill_tree.json",
+	"rpg.err_read_classes":  "не удалось прочитать файл data/classes.json",
+	"rpg.err_parse_classes": "ошибка парсинга data/classes.json",
+	"rpg.err_level_req":     "Ошибка парсинга требования к уровню: %s",
+
+	// Навыки (общие для CLI и TUI)
+	"skill.already_learned":  "✅ Навык уже изучен.",
+	"skill.no_points":        "❗ Недостаточно очков навыков.",
+	"skill.reqs_not_met":     "🔒 Требования для '%s' не выполнены.",
+	"skill.learned":          "✨ Навык '%s' изучен!",
+	"skill.status_learned":   "ИЗУЧЕНО",
+	"skill.status_available": "ДОСТУПНО",
+	"skill.status_no_points": "НЕ ХВАТАЕТ ОЧКОВ",
+	"skill.status_locked":    "ЗАБЛОКИРОВАНО",
+	"skill.reqs_none":        "Требования: нет",
+	"skill.req_level":        "Уровень %s",
+	"skill.reqs":             "Требует: ",
+
+	// Характеристики
+	"stat.strength":     "Сила",
+	"stat.endurance":    "Выносливость",
+	"stat.intelligence": "Интеллект",
+	"stat.focus":        "Концентрация",
+	"stat.charisma":     "Харизма",
+	"stat.willpower":    "Сила воли",
+	"stat.discipline":   "Дисциплина",
+	"stat.trained":      "💪 %s +1 (теперь %d)",
+
+	// Базовые навыки с рангами
+	"skill.rank.discipline":   "Дисциплина",
+	"skill.rank.vitality":     "Живучесть",
+	"skill.rank.intelligence": "Интеллект",
+	"skill.rank.focus":        "Концентрация",
+	"skill.rank.learning":     "Обучаемость",
+	"skill.rank.regeneration": "Регенерация",
+	"skill.rank.strength":     "Сила",
+	"skill.rank.fortitude":    "Стойкость",
+	"skill.rank.efficiency":   "Эффективность",
+
+	// Эффекты навыков и класса (пакет effects)
+	"effects.stat.max_hp":       "Макс. HP",
+	"effects.stat.max_mana":     "Макс. мана",
+	"effects.stat.xp":           "Множитель XP",
+	"effects.stat.damage":       "Урон по фокус-квестам",
+	"effects.stat.distraction":  "Шанс отвлечения в данже",
+	"effects.stat.mana_cost":    "Стоимость сессии",
+	"effects.stat.focus_xp":     "XP за фокус",
+	"effects.stat.goal_xp":      "XP за цели",
+	"effects.stat.rituals_xp":   "Бонус за все ритуалы дня",
+	"effects.stat.skill_points": "Очки навыков за уровень",
+	"effects.mana_cost":         "%d маны за %d мин",
+	"effects.rank":              "%s ×%d",
+	"effects.class":             "%s: %s",
+
+	// magus add
+	"cmd.add.usage":                "Usage: magus add \"название задачи\" [--type=focus] [--xp=10] [--parent=ID] [--tags=\"tag1,tag2\"] [--deadline=\"YYYY-MM-DD [HH:MM]\"] [--every=\"FREQ=WEEKLY;BYDAY=MO\"] [--blocked-by=ID1,ID2] [--priority=high] [--template=name] [--start=3d]",
+	"cmd.add.flag_type":            "Тип квеста (focus, ritual, goal)",
+	"cmd.add.flag_xp":              "Количество XP за квест",
+	"cmd.add.flag_parent":          "ID родительского квеста",
+	"cmd.add.flag_tags":            "Теги через запятую, вложенные через / (e.g., \"работа/бэкенд,дом\")",
+	"cmd.add.flag_deadline":        "Дедлайн: YYYY-MM-DD или \"YYYY-MM-DD HH:MM\" (местное время)",
+	"cmd.add.flag_every":           "Расписание повтора (RRULE), например \"FREQ=WEEKLY;BYDAY=MO\"",
+	"cmd.add.flag_blocked_by":      "ID квестов через запятую, которые нужно выполнить раньше",
+	"cmd.add.flag_priority":        "Приоритет: high, medium, low (или h, m, l)",
+	"cmd.add.flag_template":        "Создать дерево квестов по шаблону из data/templates",
+	"cmd.add.flag_start":           "Отложить квест до даты начала: 3d, 2w, 4h или ГГГГ-ММ-ДД",
+	"cmd.add.err_priority":         "❌ Неизвестный приоритет %q. Допустимо: high, medium, low.",
+	"cmd.add.err_type":             "❌ Неизвестный тип квеста %q. Допустимо: focus, ritual, goal.",
+	"cmd.add.err_every":            "❌ Ошибка в расписании повтора:",
+	"cmd.add.err_template":         "❌ Ошибка в шаблоне:",
+	"cmd.add.err_start":            "❌ Непонятная дата начала %q. Примеры: 3d, 2w, 4h, 2025-09-01.",
+	"cmd.add.template_not_found":   "❌ Шаблон %q не найден в %s. Доступные шаблоны:",
+	"cmd.add.err_deadline":         "❌ Непонятный дедлайн. Используйте формат YYYY-MM-DD или \"YYYY-MM-DD HH:MM\":",
+	"cmd.add.err_load_player_perk": "❌ Ошибка загрузки игрока для применения перка:",
+	"cmd.add.perk_planning":        "✨ Перк 'Планирование': +%d XP к родительскому квесту!",
+	"cmd.add.err_save":             "❌ Ошибка сохранения квеста:",
+	"cmd.add.added":                "🗒️ Добавлен квест:",
+	"cmd.add.subquest_of":          "   (Подзадача для квеста %s)",
+	"cmd.add.template_added":       "🗂️ По шаблону %q добавлено квестов: %d",
+
+	// magus complete
+	"cmd.complete.usage":           "Usage: magus complete <quest_id>",
+	"cmd.complete.already_done":    "⚠️ Квест уже выполнен.",
+	"cmd.complete.blocked":         "⛔ Квест ждет выполнения других квестов:",
+	"cmd.complete.goal_direct":     "⚠️ Цель можно завершить, только когда выполнены все ее подзадачи.",
+	"cmd.complete.needs_dungeon":   "⚔ Фокус-квест побеждается в фокус-сессии: осталось %d HP. Запустите `magus` и отправляйтесь в данж.",
+	"cmd.complete.ritual_done":     "💧 Ритуал выполнен: +%d маны. Серия: %d",
+	"cmd.complete.done":            "✅ Квест завершён!",
+	"cmd.complete.recurring_done":  "🔁 Выполнено! Следующий раз: %s",
+	"cmd.complete.parent_done":     "🎉 Все подзадачи выполнены! Родительский квест '%s' завершён!",
+	"cmd.complete.early_bonus":     "⏰ Цель закрыта до срока: +%d XP бонуса!",
+	"cmd.complete.damage":          "⚔ Квест получил %d урона (HP: %d/%d).",
+	"cmd.complete.defeated":        "🏆 Квест '%s' побежден!",
+	"cmd.complete.ritual_cooldown": "⏳ Ритуал на перезарядке до %s.",
+	"cmd.complete.ritual_best":     "🏆 Новый рекорд серии!",
+	"cmd.complete.rituals_done":    "🛡️ Все ритуалы дня выполнены: +%d XP бонуса класса!",
+	"cmd.complete.err_save_player": "❌ Ошибка сохранения игрока:",
+	"cmd.xp.err_add":               "❌ Не удалось начислить XP:",
+	"cmd.xp.gained":                "✨ +%d XP!",
+	"cmd.xp.can_level_up":          "🔥 Поздравляем! Опыта хватает на новых уровней: %d. Запустите `magus`, чтобы выбрать навыки.",
+
+	// magus list
+	"cmd.list.empty":           "✨ Нет активных квестов. Время добавить новый! `magus add`",
+	"cmd.list.header_ready":    "📜 Можно делать прямо сейчас:",
+	"cmd.list.flag_ready":      "Показать только незаблокированные квесты",
+	"cmd.list.blocked_by":      "⛔ ждет: %s",
+	"cmd.list.priority":        "⚑ %s",
+	"cmd.list.flag_sort":       "Порядок: urgency — по срочности (по умолчанию — как в файле)",
+	"cmd.list.err_sort":        "❌ Неизвестная сортировка %q. Допустимо: urgency.",
+	"cmd.list.header":          "📜 Список квестов:",
+	"cmd.list.flag_upcoming":   "Показать отложенные квесты",
+	"cmd.list.header_upcoming": "💤 Предстоящие квесты:",
+	"cmd.list.upcoming_empty":  "💤 Отложенных квестов нет.",
+	"cmd.list.deferred":        "💤 Отложено квестов: %d (magus list --upcoming)",
+
+	// magus roadmap
+	"cmd.roadmap.usage":     "Usage: magus roadmap <quest_id>",
+	"cmd.roadmap.title":     "🗺️ Роадмап для цели: %s",
+	"cmd.roadmap.progress":  "Прогресс:",
+	"cmd.roadmap.subquests": "Подзадачи:",
+
+	// magus block / unblock
+	"cmd.block.usage":         "Usage: magus block <quest_id> <id_квеста_который_нужно_сделать_раньше>",
+	"cmd.block.done":          "🔗 Квест %s теперь ждет %s.",
+	"cmd.block.self":          "⚠️ Квест не может ждать сам себя.",
+	"cmd.block.cycle":         "⚠️ Такая связь замкнет цикл: квесты будут ждать друг друга вечно.",
+	"cmd.unblock.usage":       "Usage: magus unblock <quest_id> <blocking_quest_id>",
+	"cmd.unblock.done":        "🔓 Квест %s больше не ждет %s.",
+	"cmd.unblock.not_blocked": "⚠️ Квест не ждет этот квест.",
+
+	// magus snooze
+	"cmd.snooze.usage":        "Usage: magus snooze <quest_id> <3d | 2w | 4h | ГГГГ-ММ-ДД | off>",
+	"cmd.snooze.done":         "💤 Квест '%s' отложен до %s.",
+	"cmd.snooze.woken":        "⏰ Квест '%s' снова в списке.",
+	"cmd.snooze.err_duration": "⚠️ Непонятный срок %q. Примеры: 3d, 2w, 4h, 2025-09-01 или off.",
+
+	// magus tags
+	"cmd.tags.usage":     "Usage: magus tags [rename <тег> <новое_имя>]",
+	"cmd.tags.empty":     "🏷️ Тегов пока нет.",
+	"cmd.tags.not_found": "⚠️ Тег #%s не найден.",
+	"cmd.tags.err_load":  "Ошибка загрузки настроек тегов:",
+	"cmd.tags.err_save":  "Ошибка сохранения настроек тегов:",
+
+	// magus archive
+	"archive.moved":         "📦 Выполненных квестов убрано в архив: %d.",
+	"cmd.archive.usage":     "Usage: magus archive [restore <quest_id>]",
+	"cmd.archive.empty":     "📦 Архив пуст.",
+	"cmd.archive.header":    "📦 Архив (%d):",
+	"cmd.archive.not_found": "⚠️ В архиве нет квеста с таким ID.",
+	"cmd.archive.restored":  "♻️ Квест '%s' восстановлен (квестов вернулось: %d).",
+
+	// magus check
+	"cmd.check.usage":     "Usage: magus check <quest_id> [add <текст> | toggle <n> | remove <n>]",
+	"cmd.check.empty":     "☐ У квеста '%s' нет чек-листа. Добавить пункт: magus check <id> add <текст>",
+	"cmd.check.header":    "☑ Чек-лист '%s' (%s):",
+	"cmd.check.err_empty": "⚠️ Текст пункта не может быть пустым.",
+	"cmd.check.err_item":  "⚠️ Нет такого пункта. Номера пунктов: 1–%d.",
+
+	// magus search
+	"cmd.search.usage":           "Usage: magus search [--limit=10] <запрос>",
+	"cmd.search.flag_limit":      "Максимальное число результатов",
+	"cmd.search.err_reflections": "❌ Ошибка загрузки журнала рефлексии:",
+	"cmd.search.nothing":         "🔍 По запросу «%s» ничего не найдено.",
+	"cmd.search.entry":           "запись",
+	"cmd.search.archived":        "в архиве",
+
+	// Строка статуса (magus prompt)
+	"prompt.format":            "Ур{level} ❤{hp}/{max_hp} 💧{mana}/{max_mana} ⏳{due} к сроку",
+	"prompt.format_plain":      "Ур{level} HP {hp}/{max_hp} MP {mana}/{max_mana} срок: {due}",
+	"cmd.prompt.flag_format":   "Шаблон строки. Плейсхолдеры: %s",
+	"cmd.prompt.flag_no_emoji": "Не выводить эмодзи",
+
+	// План на день (magus agenda и экран "Сегодня")
+	"cmd.agenda.header":  "📅 План на %s (день начинается в %s)",
+	"cmd.agenda.empty":   "🌿 На сегодня всё сделано.",
+	"agenda.rituals":     "💧 Ритуалы на сегодня:",
+	"agenda.due":         "⏳ Дедлайн сегодня или просрочено:",
+	"agenda.due_today":   "сегодня",
+	"agenda.overdue":     "просрочено",
+	"agenda.in_progress": "⚙️ Начатые фокус-квесты:",
+	"agenda.plan":        "🗡️ Предлагаемые фокус-сессии (мана: %d):",
+	"agenda.plan_empty":  "Нечего планировать или не хватает маны.",
+	"agenda.session":     "%d мин — %s (-%d маны)",
+	"agenda.mana_left":   "Останется маны: %d",
+
+	// Повторяющиеся квесты
+	"recur.daily":          "каждый день",
+	"recur.every_n_days":   "каждые %d дн.",
+	"recur.weekly":         "еженедельно",
+	"recur.every_n_weeks":  "раз в %d нед.",
+	"recur.times_week":     "%d р. в неделю",
+	"recur.monthly":        "ежемесячно",
+	"recur.every_n_months": "раз в %d мес.",
+	"recur.times_month":    "%d р. в месяц",
+	"recur.last_day":       "последний день",
+	"recur.next":           "далее: %s",
+	"recur.weekday.mo":     "пн",
+	"recur.weekday.tu":     "вт",
+	"recur.weekday.we":     "ср",
+	"recur.weekday.th":     "чт",
+	"recur.weekday.fr":     "пт",
+	"recur.weekday.sa":     "сб",
+	"recur.weekday.su":     "вс",
+
+	// Серии ритуалов
+	"ritual.streak_broken": "💔 Серия ритуала '%s' (%d) прервалась.",
+
+	// Штрафы за просрочку
+	"overdue.quest":      "⏰ Квест '%s' просрочен (новых дней просрочки: %d).",
+	"overdue.xp_decayed": "   📉 Награда уменьшилась на %d XP, осталось %d XP.",
+	"overdue.hp_lost":    "   💔 Потеряно %d HP.",
+
+	// magus show
+	"cmd.show.no_player":        "🔮 Игрок не найден. Создайте его, запустив `magus` без аргументов.",
+	"cmd.show.err_read_player":  "❌ Не удалось прочитать player.json:",
+	"cmd.show.name":             "🧙 Имя: %s",
+	"cmd.show.class":            "🎖️ Класс: %s",
+	"cmd.show.passives":         "Бонусы класса:",
+	"cmd.show.passive":          "  • %s — %s",
+	"cmd.show.level":            "📈 Уровень: %d",
+	"cmd.show.xp":               "🔋 XP: %d / %d",
+	"cmd.show.skill_points":     "✨ Очки навыков: %d",
+	"cmd.show.perks":            "🎁 Перки:",
+	"cmd.show.err_tree":         "  Не удалось загрузить дерево навыков:",
+	"cmd.show.common_header":    "--- Общие навыки ---",
+	"cmd.show.no_common":        "Нет доступных общих навыков.",
+	"cmd.show.class_header":     "--- Классовые навыки ---",
+	"cmd.show.no_class":         "Нет доступных классовых навыков.",
+	"cmd.show.learned":          "[ИЗУЧЕНО]",
+	"cmd.show.focus_total":      "⏱ Время в фокусе: %s",
+	"cmd.show.quests_completed": "✅ Выполнено квестов: %d (в архиве: %d)",
+	"cmd.show.hp_mana":          "❤ HP: %d/%d  💧 Мана: %d/%d",
+	"cmd.show.effects":          "📊 Характеристики:",
+	"cmd.show.quest_title":      "📜 %s",
+	"cmd.show.quest_type":       "Тип: %s",
+	"cmd.show.quest_completed":  "✅ Выполнен: %s",
+	"cmd.show.quest_archived":   "📦 В архиве. Вернуть: magus archive restore %s",
+	"cmd.show.quest_priority":   "⚑ Приоритет: %s",
+	"cmd.show.quest_hp":         "HP: %d/%d",
+	"cmd.show.quest_xp":         "🔋 XP: %d",
+	"cmd.show.quest_checklist":  "☑ Чек-лист: %s",
+	"cmd.show.quest_notes":      "📝 Заметки:",
+	"cmd.show.quest_links":      "🔗 Ссылки:",
+	"cmd.show.quest_tags":       "🏷️ Теги: %s",
+	"cmd.show.quest_deadline":   "⏳ Срок: %s",
+	"cmd.show.quest_deferred":   "💤 Отложен до: %s",
+	"cmd.show.quest_focus":      "⏱ Время в фокусе: %s",
+
+	// magus skills
+	"cmd.skills.usage":     "Usage: magus skills [list | tree | show <id> | unlock <id>]",
+	"cmd.skills.not_found": "⚠️ Навык '%s' не найден в общем дереве и дереве вашего класса.",
+	"cmd.skills.type":      "Тип: %s",
+	"cmd.skills.status":    "Статус: %s",
+	"cmd.skills.effects":   "Эффекты: ",
+	"cmd.skills.err_save":  "❌ Не удалось сохранить игрока:",
+	"cmd.skills.legend":    "[✓] изучен  [+] доступен  [!] не хватает очков  [ ] заблокирован",
+
+	// magus why / version
+	"cmd.why.title": "🧭 Зачем ты тут, маг:",
+	"cmd.why.line1": "Ты создаешь инструмент, чтобы фокусироваться и прокачивать себя в реальности.",
+	"cmd.why.line2": "Каждый квест — шаг к твоему уровню и цели. Не сдавайся.",
+	"cmd.version":   "🧙 Magus v%s",
+
+	// TUI: общие поля
+	"tui.initializing":          "Загрузка...",
+	"tui.field.title":           "Название",
+	"tui.field.type":            "Тип",
+	"tui.field.subtype":         "Подтип",
+	"tui.field.tags":            "Теги (через запятую, вложенные через /)",
+	"tui.field.deadline":        "Дедлайн (ГГГГ-ММ-ДД [ЧЧ:ММ])",
+	"tui.field.recurrence":      "Повтор (RRULE, пусто — без повтора)",
+	"tui.field.description":     "Описание (Markdown)",
+	"tui.field.links":           "Ссылки и файлы (через запятую)",
+	"tui.field.note":            "Новая заметка (заметок: %d)",
+	"tui.field.template":        "Шаблон (←/→)",
+	"tui.deadline.overdue":      "(Просрочено)",
+	"tui.deadline.days_left":    "(осталось %d д)",
+	"tui.deadline.hours_left":   "(осталось %d ч %02d мин)",
+	"tui.deadline.minutes_left": "(осталось %d мин)",
+
+	// TUI: главный экран
+	"tui.home.player":        "Игрок: %s (Уровень: %d)",
+	"tui.home.class":         "Класс: %s",
+	"tui.home.hp":            "HP: %d / %d",
+	"tui.home.mana":          "Мана: %d / %d",
+	"tui.home.gold":          "Золото: %d",
+	"tui.home.skills":        "Навыки: %d",
+	"tui.home.skill_points":  "Очки навыков: %d",
+	"tui.home.focus_time":    "В фокусе: %s",
+	"tui.home.xp":            "📈 XP: %d / %d",
+	"tui.home.menu_quests":   "Активные квесты",
+	"tui.home.menu_agenda":   "Сегодня",
+	"tui.home.menu_upcoming": "Предстоящие",
+	"tui.home.menu_skills":   "Дерево навыков",
+	"tui.home.menu_effects":  "Характеристики",
+	"tui.home.menu_dungeon":  "Отправиться в данж",
+	"tui.home.menu_search":   "Поиск",
+	"tui.home.menu_journal":  "Журнал",
+	"tui.home.menu_exit":     "Выход",
+	"tui.effects.title":      "📊 Характеристики",
+	"tui.effects.base":       "базовое значение",
+	"tui.effects.help":       "q/esc — назад",
+
+	// TUI: создание игрока и выбор класса
+	"tui.create.placeholder": "Имя твоего героя",
+	"tui.create.welcome":     "Добро пожаловать в Magus!\n\nДавай создадим твоего персонажа.\n\n%s\n\nНажми Enter, чтобы начать.",
+	"tui.class.title":        "⚔️ Пришло время выбрать свой путь!",
+	"tui.class.prompt":       "Выберите класс:",
+	"tui.class.help":         "Нажмите 'enter' для выбора. Этот выбор нельзя будет изменить.",
+	"tui.class.err_load":     "Бонусы классов не загрузились: %v",
+	// TUI: список квестов
+	"tui.quests.title":             "Активные квесты",
+	"tui.quests.key_add":           "добавить",
+	"tui.quests.key_delete":        "удалить",
+	"tui.quests.key_expand":        "развернуть",
+	"tui.quests.key_block":         "ждать квест / снять",
+	"tui.quests.key_priority":      "приоритет",
+	"tui.quests.key_sort":          "по срочности",
+	"tui.quests.key_snooze":        "отложить",
+	"tui.quests.key_upcoming":      "предстоящие",
+	"tui.quests.key_completed":     "выполненные",
+	"tui.quests.key_checklist":     "чек-лист",
+	"tui.quests.key_details":       "подробности",
+	"tui.quests.key_tags":          "теги",
+	"tui.quests.key_mark":          "отметить",
+	"tui.quests.key_bulk_tag":      "тег отмеченным",
+	"tui.quests.key_bulk_deadline": "срок отмеченным",
+	"tui.quests.key_bulk_move":     "перенести",
+	"tui.quests.key_bulk_dungeon":  "в данж",
+	"tui.quests.key_undo":          "отменить",
+	"tui.quests.key_reorder":       "выше/ниже",
+	"tui.quests.key_indent":        "вложить/вынести",
+	"tui.quests.order_urgency":     "Порядок меняется только без сортировки по срочности (o)",
+	"tui.quests.indent_none":       "Над квестом нет соседа, под который его можно вложить",
+	"tui.quests.indented":          "📂 «%s» теперь подзадача «%s»",
+	"tui.quests.outdented":         "📂 «%s» вынесен на уровень выше",
+	"tui.quests.outdent_top":       "Квест уже на верхнем уровне",
+
+	// Массовые операции в списке квестов
+	"tui.bulk.title_marked":           "Активные квесты · отмечено: %d",
+	"tui.bulk.title_range":            "Активные квесты · диапазон: %d (V или пробел — закрепить)",
+	"tui.bulk.prompt_add_tag":         "Тег для квестов: %d (enter — далее, esc — отмена)",
+	"tui.bulk.prompt_remove_tag":      "Убрать тег у квестов: %d (enter — далее, esc — отмена)",
+	"tui.bulk.prompt_deadline":        "Срок для квестов: %d — ГГГГ-ММ-ДД [ЧЧ:ММ], пусто — убрать срок",
+	"tui.bulk.pick_parent":            "Куда перенести квесты (%d): enter — под выбранный, 0 — на верхний уровень, esc — отмена",
+	"tui.bulk.confirm_complete":       "Завершить квесты: %d? y — да, n — нет",
+	"tui.bulk.confirm_delete":         "Удалить квесты (%d) вместе с подзадачами? y — да, n — нет",
+	"tui.bulk.confirm_add_tag":        "Добавить #%s квестам: %d? y — да, n — нет",
+	"tui.bulk.confirm_remove_tag":     "Убрать #%s у квестов: %d? y — да, n — нет",
+	"tui.bulk.confirm_deadline":       "Поставить срок %s квестам: %d? y — да, n — нет",
+	"tui.bulk.confirm_clear_deadline": "Убрать срок у квестов: %d? y — да, n — нет",
+	"tui.bulk.confirm_move":           "Перенести квесты (%d) под «%s»? y — да, n — нет",
+	"tui.bulk.top_level":              "верхний уровень",
+	"tui.bulk.cancelled":              "Операция отменена",
+	"tui.bulk.err_cycle":              "❗ Нельзя перенести квест под «%s»: это он сам или его подзадача",
+	"tui.bulk.err_parent_done":        "❗ «%s» уже выполнена: под нее можно перенести только выполненные квесты",
+	"tui.bulk.completed":              "✅ Завершено квестов: %d, +%d XP.",
+	"tui.bulk.skipped":                "Пропущено: %d.",
+	"tui.bulk.deleted":                "🗑️ Удалено квестов вместе с подзадачами: %d.",
+	"tui.bulk.tag_added":              "🏷️ #%s добавлен квестам: %d.",
+	"tui.bulk.tag_removed":            "🏷️ #%s убран у квестов: %d.",
+	"tui.bulk.deadline_set":           "⏳ Срок %s поставлен квестам: %d.",
+	"tui.bulk.deadline_cleared":       "⏳ Срок убран у квестов: %d.",
+	"tui.bulk.moved":                  "📂 Перенесено квестов: %d.",
+	"tui.bulk.no_focus":               "Среди отмеченных нет невыполненных фокус-квестов",
+	"tui.bulk.nothing_to_undo":        "Нечего отменять",
+	"tui.bulk.undo_stale":             "Квесты изменились после операции — отменить ее уже нельзя",
+	"tui.bulk.undone":                 "↩️ Последняя массовая операция отменена",
+	"tui.quests.deleted":              "🗑️ Квест '%s' и все подзадачи удалены.",
+	"tui.quests.already_done":         "✅ Квест уже выполнен",
+	"tui.quests.focus_in_dungeon":     "❗ Этот квест выполняется в фокус-сессии (подземелье)",
+	"tui.quests.blocked_by":           "⛔ ждет: %s",
+	"tui.quests.is_blocked":           "⛔ Сначала нужно выполнить: %s",
+	"tui.quests.link_pick":            "Что нужно сделать раньше «%s»? b/enter — выбрать, esc — отмена",
+	"tui.quests.link_cancelled":       "Связь не добавлена",
+	"tui.quests.link_self":            "⚠️ Квест не может ждать сам себя",
+	"tui.quests.link_cycle":           "⚠️ Такая связь замкнет цикл",
+	"tui.quests.link_error":           "❌ Не удалось добавить связь: %v",
+	"tui.quests.linked":               "🔗 Теперь ждет «%s»",
+	"tui.quests.unlinked":             "🔓 С «%s» сняты все блокировки",
+	"tui.quests.priority":             "⚑ %s",
+	"tui.quests.priority_set":         "⚑ Приоритет «%s»: %s",
+	"tui.quests.priority_cleared":     "Приоритет «%s» снят",
+	"tui.quests.sort_urgency":         "⚡ Сначала самые срочные",
+	"tui.quests.sort_file":            "Ручной порядок",
+	"tui.quests.completed_shown":      "Выполненные квесты показаны",
+	"tui.quests.completed_hidden":     "Выполненные квесты скрыты",
+	"tui.quests.goal_has_children":    "❗ Сначала завершите все подзадачи для цели '%s'",
+	"tui.quests.parent_done":          "🎉 Цель '%s' завершена! +%d XP",
+	"tui.quests.early_bonus":          "⏰ До срока: +%d XP!",
+	"tui.quests.ritual_mana":          "💧 +%d маны за ритуал '%s'",
+	"tui.quests.recurring_done":       "🔁 '%s' выполнен, следующий раз: %s",
+	"tui.quests.ritual_cooldown":      "⏳ '%s' на перезарядке до %s",
+	"tui.ritual.streak":               "🔥 Серия: %d.",
+	"tui.ritual.new_best":             "🏆 Новый рекорд!",
+	"tui.quests.rituals_done":         "🛡️ Все ритуалы дня: +%d XP!",
+	"tui.ritual.streak_card":          "🔥 %d (рекорд %d)",
+	"tui.ritual.cooldown":             "✓ снова в %s",
+	"tui.quests.xp_gained":            "✨ +%d XP за квест '%s'!",
+	"tui.quests.level_no_skills":      "🔮 Новый уровень! Доступных для изучения навыков пока нет.",
+
+	// TUI: добавление и редактирование квеста
+	"tui.add.title":                   "📝 Новый квест",
+	"tui.add.placeholder_title":       "Название квеста",
+	"tui.add.placeholder_tags":        "работа,дом",
+	"tui.add.placeholder_deadline":    "ГГГГ-ММ-ДД ЧЧ:ММ",
+	"tui.add.placeholder_recurrence":  "FREQ=WEEKLY;BYDAY=MO",
+	"tui.add.placeholder_description": "Подробности, шаги, ссылки — в Markdown",
+	"tui.add.placeholder_links":       "https://…, ~/docs/plan.md",
+	"tui.add.err_recurrence":          "Ошибка в расписании повтора: %v",
+	"tui.add.err_template":            "Ошибка в шаблоне: %v",
+	"tui.add.err_deadline":            "Непонятный дедлайн %q: нужен формат ГГГГ-ММ-ДД или ГГГГ-ММ-ДД ЧЧ:ММ",
+	"tui.add.no_template":             "— без шаблона —",
+	"tui.add.template_name":           "Название ({{name}} в шаблоне)",
+	"tui.add.template_due":            "через %d дн.",
+	"tui.add.save_button":             "[ Сохранить ]",
+	"tui.add.help":                    "ctrl+s - сохранить, tab - следующее поле, esc - отмена",
+	"tui.edit.title":                  "Редактирование квеста",
+	"tui.edit.help":                   "Enter - сохранить (в описании и заметке - Ctrl+S), Tab - следующее поле, Esc - отмена",
+	"tui.edit.placeholder_note":       "Что нового?",
+
+	// TUI: теги
+	"tui.tags.title":           "Управление тегами",
+	"tui.tags.placeholder":     "Новое имя тега",
+	"tui.tags.empty":           "У вас пока нет тегов.",
+	"tui.tags.rename_to":       "Переименовать в: ",
+	"tui.tags.color_to":        "Цвет (#RRGGBB или номер ANSI, пусто — без цвета): ",
+	"tui.tags.multiplier_to":   "Множитель XP (пусто — без множителя): ",
+	"tui.tags.usage":           "%d открыто · %d выполнено · %d XP",
+	"tui.tags.trains":          "💪 %s",
+	"tui.tags.merging":         "← сливается",
+	"tui.tags.renamed":         "🏷️ #%s → #%s (квестов: %d)",
+	"tui.tags.err_multiplier":  "⚠️ Множитель должен быть положительным числом: %q.",
+	"tui.tags.err_into_itself": "⚠️ Нельзя перенести #%s внутрь самого себя (#%s).",
+	"tui.tags.merge_help":      "Выберите тег, с которым слить #%s, и нажмите 'm' или enter; esc — отмена.",
+	"tui.tags.help":            "Навигация: ↑/↓, 'r' - переименовать, 'm' - слить, 'd' - удалить (с вложенными), 'c' - цвет, 'x' - множитель XP, 's' - характеристика, 'q' - назад.",
+
+	// TUI: поиск и журнал
+	"tui.search.title":       "🔍 Поиск",
+	"tui.search.placeholder": "Название, тег или текст заметки",
+	"tui.search.empty":       "Введите запрос, чтобы искать по квестам и журналу.",
+	"tui.search.no_results":  "Ничего не найдено.",
+	"tui.search.reflection":  "Журнал: %s",
+	"tui.search.archived":    "Архив: %s",
+	"tui.search.restored":    "♻️ Квест '%s' восстановлен из архива",
+	"tui.search.err_restore": "Не удалось восстановить квест: %v",
+
+	// Чек-лист квеста
+	"tui.checklist.title":       "☑ %s",
+	"tui.checklist.placeholder": "Новый пункт",
+	"tui.checklist.empty":       "Пунктов пока нет. Нажмите a, чтобы добавить.",
+	"tui.checklist.err_empty":   "Текст пункта не может быть пустым",
+	"tui.checklist.help":        "↑/↓: выбрать | space: отметить | a: добавить | d: удалить | esc: назад",
+	"tui.checklist.help_adding": "enter: добавить | esc: отмена",
+
+	// Отложенные квесты
+	"tui.snooze.title":        "💤 Отложить «%s»",
+	"tui.snooze.err_duration": "Непонятный срок %q",
+	"tui.snooze.help":         "3d, 2w, 4h или ГГГГ-ММ-ДД | enter: отложить | esc: отмена",
+	"tui.upcoming.title":      "💤 Предстоящие квесты",
+	"tui.upcoming.empty":      "Отложенных квестов нет. Нажмите s в списке квестов, чтобы отложить квест.",
+	"tui.upcoming.help":       "↑/↓: выбрать | w: вернуть сейчас | s: отложить еще | esc: назад",
+
+	// Подробности квеста
+	"tui.detail.help":           "↑/↓: прокрутка | e: редактировать | x: чек-лист | esc: назад",
+	"tui.detail.no_description": "Описания нет. Нажмите e, чтобы добавить.",
+	"tui.detail.notes":          "📝 Заметки",
+	"tui.detail.links":          "🔗 Ссылки",
+	"tui.detail.deferred":       "💤 с %s",
+	"tui.search.help":           "↑/↓: выбрать | enter: перейти или вернуть из архива | esc: назад",
+	"tui.journal.title":         "📓 Журнал рефлексии",
+	"tui.journal.empty":         "Записей пока нет. Они появляются после фокус-сессий.",
+	"tui.journal.meta":          "Длительность: %s · +%d XP · -%d HP",
+	"tui.journal.help":          "↑/↓: выбрать запись | q/esc: назад",
+	"tui.agenda.title":          "📅 Сегодня, %s",
+	"tui.agenda.help":           "↑/↓: выбрать | enter: к квесту | p: в данж по плану | q/esc: назад",
+
+	// TUI: подземелье
+	"tui.prep.minutes":                   "%d минут",
+	"tui.prep.duration_desc":             "Длительность фокус-сессии · %d маны",
+	"tui.prep.duration":                  "Длительность",
+	"tui.prep.quests":                    "Квесты для сессии",
+	"tui.prep.no_mana":                   "Недостаточно маны! Нужно %d, у вас %d.",
+	"tui.prep.start":                     "[ Начать ]",
+	"tui.prep.start_focused":             "> Начать <",
+	"tui.prep.help":                      "tab: сменить фокус | space: выбрать квест | s: по срочности | enter: начать | q/esc: назад",
+	"tui.dungeon.time_left":              "Осталось времени: ",
+	"tui.dungeon.focus":                  "Вы в подземелье. Сконцентрируйтесь на задаче.",
+	"tui.dungeon.attacks":                "Атаки на концентрацию: %d",
+	"tui.dungeon.help":                   "Нажмите 'q' или 'esc' для досрочного завершения.",
+	"tui.dungeon.confirm_exit":           "Вы уверены, что хотите прервать фокус-сессию?",
+	"tui.dungeon.confirm_exit_note":      "Прогресс не будет засчитан.",
+	"tui.summary.title":                  "Отчет о сессии",
+	"tui.summary.stats":                  "Длительность: %s\nАтаки на концентрацию: %d",
+	"tui.summary.distraction_prompt":     "Сколько раз вы отвлеклись на самом деле?",
+	"tui.summary.reflection_placeholder": "Что было сделано? Какие возникли трудности?",
+	"tui.summary.notes":                  "Заметки о сессии:",
+	"tui.summary.finish":                 "[ Завершить ]",
+	"tui.summary.finish_focused":         "> Завершить <",
+	"tui.summary.split":                  "Распределите %d мин между квестами:",
+	"tui.summary.quest_time":             "⏱ %d мин → %s",
+	"tui.summary.split_invalid":          "Укажите минуты числом для «%s»",
+	"tui.summary.split_mismatch":         "Распределено %d мин, а сессия длилась %d мин",
+	"tui.summary.damage":                 "⚔ Урон по квестам:",
+	"tui.summary.damage_hp":              "HP %d/%d (−%d)",
+	"tui.summary.defeated":               "⚔ Квест «%s» побежден! +%d XP",
+
+	// TUI: навыки и повышение уровня
+	"tui.skills.err_load":   "Ошибка загрузки дерева навыков.",
+	"tui.skills.help":       "Навигация: ↑↓ | Tab: сменить вид | Enter: изучить | q: назад",
+	"tui.skills.common":     "Общие навыки",
+	"tui.skills.class":      "Навыки класса: %s",
+	"tui.skills.title":      "🧠 Дерево навыков (%s) | Очки: %d",
+	"tui.skills.empty":      "Нет доступных навыков в этой категории.",
+	"tui.skills.choose":     "Выберите навык...",
+	"tui.levelup.err_tree":  "🔮 Новый уровень! Дерево навыков не загрузилось: %v",
+	"tui.levelup.no_skills": "На этом уровне изучить нечего — очки навыков сохранятся.",
+	"tui.levelup.title":     "🔥 Поздравляем! Уровень %d!",
+	"tui.levelup.more":      "⏳ Опыта хватает еще на уровней: %d — навык выбирается для каждого.",
+	"tui.levelup.prompt":    "Выберите навык для изучения:",
+	"tui.levelup.help":      "Нажмите 'enter' для выбора.",
+	"tui.levelup.help_next": "Нажмите 'enter', чтобы продолжить.",
+}
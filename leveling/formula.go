@@ -0,0 +1,185 @@
+package leveling
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrBadFormula — выражение кривой не удалось разобрать.
+var ErrBadFormula = errors.New("invalid leveling formula")
+
+// Formula — кривая, заданная выражением от переменной level. Поддерживаются
+// числа, + - * /, ^ (степень) и скобки.
+type Formula struct {
+	source string
+	eval   func(level float64) float64
+}
+
+// Next вычисляет выражение для уровня level.
+func (f Formula) Next(level int) int {
+	return clamp(f.eval(float64(level)))
+}
+
+// String возвращает исходное выражение.
+func (f Formula) String() string {
+	return f.source
+}
+
+// ParseFormula разбирает выражение кривой, например "100 * level^1.5 + 50".
+func ParseFormula(source string) (Formula, error) {
+	p := &parser{src: source}
+	eval, err := p.expr()
+	if err == nil && p.skip() < len(p.src) {
+		err = p.fail("unexpected %q", p.src[p.pos:])
+	}
+	if err != nil {
+		return Formula{}, err
+	}
+	return Formula{source: source, eval: eval}, nil
+}
+
+type evaluator = func(level float64) float64
+
+// parser — разбор методом рекурсивного спуска:
+//
+//	expr  = term { ("+" | "-") term }
+//	term  = unary { ("*" | "/") unary }
+//	unary = "-" unary | power
+//	power = atom [ "^" unary ]
+//	atom  = number | "level" | "(" expr ")"
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) expr() (evaluator, error) {
+	left, err := p.term()
+	for err == nil && p.peek("+", "-") {
+		op := p.next()
+		var right evaluator
+		if right, err = p.term(); err == nil {
+			left = binary(op, left, right)
+		}
+	}
+	return left, err
+}
+
+func (p *parser) term() (evaluator, error) {
+	left, err := p.unary()
+	for err == nil && p.peek("*", "/") {
+		op := p.next()
+		var right evaluator
+		if right, err = p.unary(); err == nil {
+			left = binary(op, left, right)
+		}
+	}
+	return left, err
+}
+
+func (p *parser) unary() (evaluator, error) {
+	if p.peek("-") {
+		p.next()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(l float64) float64 { return -operand(l) }, nil
+	}
+	return p.power()
+}
+
+func (p *parser) power() (evaluator, error) {
+	base, err := p.atom()
+	if err != nil || !p.peek("^") {
+		return base, err
+	}
+	p.next()
+	exp, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return binary("^", base, exp), nil
+}
+
+func (p *parser) atom() (evaluator, error) {
+	if p.skip() >= len(p.src) {
+		return nil, p.fail("unexpected end")
+	}
+	switch c := rune(p.src[p.pos]); {
+	case c == '(':
+		p.pos++
+		inner, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peek(")") {
+			return nil, p.fail("missing )")
+		}
+		p.next()
+		return inner, nil
+	case unicode.IsDigit(c) || c == '.':
+		start := p.pos
+		for p.pos < len(p.src) && (unicode.IsDigit(rune(p.src[p.pos])) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		v, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return nil, p.fail("bad number %q", p.src[start:p.pos])
+		}
+		return func(float64) float64 { return v }, nil
+	case strings.HasPrefix(p.src[p.pos:], "level"):
+		p.pos += len("level")
+		return func(l float64) float64 { return l }, nil
+	}
+	return nil, p.fail("unexpected %q", p.src[p.pos:])
+}
+
+// peek сообщает, что следующий символ — один из ops.
+func (p *parser) peek(ops ...string) bool {
+	if p.skip() >= len(p.src) {
+		return false
+	}
+	for _, op := range ops {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			return true
+		}
+	}
+	return false
+}
+
+// next возвращает следующий символ-оператор.
+func (p *parser) next() string {
+	op := p.src[p.pos : p.pos+1]
+	p.pos++
+	return op
+}
+
+// skip пропускает пробелы и возвращает новую позицию.
+func (p *parser) skip() int {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+	return p.pos
+}
+
+func (p *parser) fail(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at %d", ErrBadFormula, fmt.Sprintf(format, args...), p.pos)
+}
+
+func binary(op string, a, b evaluator) evaluator {
+	switch op {
+	case "+":
+		return func(l float64) float64 { return a(l) + b(l) }
+	case "-":
+		return func(l float64) float64 { return a(l) - b(l) }
+	case "*":
+		return func(l float64) float64 { return a(l) * b(l) }
+	case "/":
+		return func(l float64) float64 { return a(l) / b(l) }
+	}
+	return func(l float64) float64 { return math.Pow(a(l), b(l)) }
+}
@@ -0,0 +1,115 @@
+// Package leveling — кривая опыта: сколько XP нужно, чтобы перейти с уровня
+// на следующий. Кривая задается в data/config.json: многочленом от уровня,
+// таблицей или собственной формулой.
+package leveling
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Curve возвращает XP, нужный для перехода с уровня level на level+1.
+type Curve interface {
+	Next(level int) int
+}
+
+// Типы кривых в настройках.
+const (
+	TypePolynomial = "polynomial"
+	TypeTable      = "table"
+	TypeCustom     = "custom"
+)
+
+// Default — кривая по умолчанию: 100·level².
+var Default Curve = Polynomial{0, 0, 100}
+
+var (
+	ErrUnknownType = errors.New("unknown leveling curve type")
+	ErrEmptyCurve  = errors.New("leveling curve has no values")
+)
+
+// Spec — описание кривой в настройках. Пустое описание — Default.
+type Spec struct {
+	Type         string    `json:"type,omitempty"`         // polynomial, table или custom
+	Coefficients []float64 `json:"coefficients,omitempty"` // Для polynomial: c0 + c1·level + c2·level² + ...
+	Table        []int     `json:"table,omitempty"`        // Для table: XP для уровней 1, 2, 3, ...
+	Formula      string    `json:"formula,omitempty"`      // Для custom: например "100 * level^1.5"
+}
+
+// New строит кривую по описанию.
+func New(s Spec) (Curve, error) {
+	switch s.Type {
+	case "":
+		return Default, nil
+	case TypePolynomial:
+		if len(s.Coefficients) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrEmptyCurve, s.Type)
+		}
+		return Polynomial(s.Coefficients), nil
+	case TypeTable:
+		if len(s.Table) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrEmptyCurve, s.Type)
+		}
+		return Table(s.Table), nil
+	case TypeCustom:
+		return ParseFormula(s.Formula)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownType, s.Type)
+}
+
+// Polynomial — многочлен от уровня: коэффициенты от свободного члена.
+type Polynomial []float64
+
+// Next вычисляет многочлен для уровня level.
+func (c Polynomial) Next(level int) int {
+	xp, pow := 0.0, 1.0
+	for _, k := range c {
+		xp += k * pow
+		pow *= float64(level)
+	}
+	return clamp(xp)
+}
+
+// Table — XP для уровней по порядку. После конца таблицы порог растет
+// на последнюю разницу, а из одного значения — не растет.
+type Table []int
+
+// Next берет порог уровня level из таблицы.
+func (t Table) Next(level int) int {
+	if level < 1 {
+		level = 1
+	}
+	if level <= len(t) {
+		return max(t[level-1], 1)
+	}
+	last := t[len(t)-1]
+	step := 0
+	if len(t) > 1 {
+		step = last - t[len(t)-2]
+	}
+	return max(last+step*(level-len(t)), 1)
+}
+
+// Pending возвращает, на сколько уровней хватает опыта xp игроку уровня level.
+func Pending(c Curve, level, xp int) int {
+	n := 0
+	for next := c.Next(level); xp >= next; next = c.Next(level) {
+		xp -= next
+		level++
+		n++
+	}
+	return n
+}
+
+// clamp округляет порог и не дает ему опуститься ниже 1 XP, иначе
+// повышение уровня никогда бы не закончилось.
+func clamp(xp float64) int {
+	if math.IsNaN(xp) || xp < 1 {
+		return 1
+	}
+	if xp > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(math.Round(xp))
+}
@@ -0,0 +1,60 @@
+package leveling
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCurves(t *testing.T) {
+	tests := []struct {
+		name string
+		spec Spec
+		want []int // Пороги для уровней 1, 2, 3, 4
+	}{
+		{"default", Spec{}, []int{100, 400, 900, 1600}},
+		{"polynomial", Spec{Type: TypePolynomial, Coefficients: []float64{50, 50}}, []int{100, 150, 200, 250}},
+		{"table", Spec{Type: TypeTable, Table: []int{100, 250, 500}}, []int{100, 250, 500, 750}},
+		{"flat table", Spec{Type: TypeTable, Table: []int{300}}, []int{300, 300, 300, 300}},
+		{"formula", Spec{Type: TypeCustom, Formula: "100 * level^2"}, []int{100, 400, 900, 1600}},
+		{"formula precedence", Spec{Type: TypeCustom, Formula: "50 + 2*(level - 1) * 25"}, []int{50, 100, 150, 200}},
+		{"never below one", Spec{Type: TypeCustom, Formula: "-level"}, []int{1, 1, 1, 1}},
+	}
+	for _, tt := range tests {
+		curve, err := New(tt.spec)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		for i, want := range tt.want {
+			if got := curve.Next(i + 1); got != want {
+				t.Errorf("%s: Next(%d) = %d, want %d", tt.name, i+1, got, want)
+			}
+		}
+	}
+}
+
+func TestBadSpecs(t *testing.T) {
+	for _, spec := range []Spec{
+		{Type: "cubic"},
+		{Type: TypeTable},
+		{Type: TypeCustom, Formula: "100 * lvl"},
+		{Type: TypeCustom, Formula: "(level + 1"},
+		{Type: TypeCustom, Formula: "level level"},
+	} {
+		if _, err := New(spec); err == nil {
+			t.Errorf("New(%+v) succeeded", spec)
+		}
+	}
+	if _, err := New(Spec{Type: TypeCustom, Formula: "2 *"}); !errors.Is(err, ErrBadFormula) {
+		t.Errorf("error = %v, want ErrBadFormula", err)
+	}
+}
+
+func TestPending(t *testing.T) {
+	// 100 + 400 + 900 XP — ровно три уровня с первого
+	if got := Pending(Default, 1, 1400); got != 3 {
+		t.Errorf("Pending = %d, want 3", got)
+	}
+	if got := Pending(Default, 1, 99); got != 0 {
+		t.Errorf("Pending = %d, want 0", got)
+	}
+}
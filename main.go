@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"magus/cmd"
+	"magus/config"
+	"magus/i18n"
 	"magus/tui"
 	"math/rand"
 	"os"
@@ -14,12 +16,24 @@ import (
 
 func main() {
 	rand.Seed(time.Now().UnixNano())
+
+	// MAGUS_HOME позволяет запускать magus из любой директории (например, из PS1)
+	if home := os.Getenv("MAGUS_HOME"); home != "" {
+		if err := os.Chdir(home); err != nil {
+			log.Fatalf("MAGUS_HOME: %v", err)
+		}
+	}
+
+	// Язык интерфейса: ключ language из data/config.json, иначе LANG
+	cfg, _ := config.Load()
+	i18n.SetLang(i18n.Detect(cfg.Language))
+
 	if len(os.Args) < 2 {
 		// Запускаем TUI, если нет команд
 		m := tui.InitialModel()
 		prog := tea.NewProgram(m, tea.WithAltScreen())
 		if _, err := prog.Run(); err != nil {
-			log.Fatalf("Ошибка при запуске TUI: %v", err)
+			log.Fatalf("%s: %v", i18n.T("main.err_tui"), err)
 		}
 		os.Exit(0)
 	}
@@ -31,15 +45,35 @@ func main() {
 		cmd.List()
 	case "show":
 		cmd.Show()
+	case "skills":
+		cmd.Skills()
 	case "why":
 		cmd.Why()
 	case "complete":
 		cmd.Complete()
+	case "check":
+		cmd.Check()
+	case "block":
+		cmd.Block()
+	case "unblock":
+		cmd.Unblock()
+	case "snooze":
+		cmd.Snooze()
+	case "tags":
+		cmd.Tags()
 	case "roadmap":
 		cmd.Roadmap()
+	case "agenda":
+		cmd.Agenda()
+	case "archive":
+		cmd.Archive()
+	case "search":
+		cmd.Search()
+	case "prompt":
+		cmd.Prompt()
 	case "version":
 		cmd.Version()
 	default:
-		fmt.Println("Неизвестная команда:", os.Args[1])
+		fmt.Println(i18n.T("main.unknown_command"), os.Args[1])
 	}
 }
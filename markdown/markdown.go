@@ -0,0 +1,129 @@
+// Package markdown отображает описания квестов в разметке Markdown в
+// терминале. Поддерживается небольшое подмножество: заголовки, списки,
+// цитаты, блоки кода, **жирный**, *курсив*, `код` и [ссылки](url).
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	headingStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	boldStyle    = lipgloss.NewStyle().Bold(true)
+	italicStyle  = lipgloss.NewStyle().Italic(true)
+	codeStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#DAA520"))
+	quoteStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	linkStyle    = lipgloss.NewStyle().Underline(true).Foreground(lipgloss.Color("#36A2EB"))
+)
+
+var (
+	reCode    = regexp.MustCompile("`([^`]+)`")
+	reBold    = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	reItalic  = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+	reLink    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	reBullet  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	reOrdered = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	reHeading = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+)
+
+// Render превращает Markdown в текст для терминала. Строки длиннее width
+// переносятся по словам; width <= 0 отключает перенос.
+func Render(src string, width int) string {
+	var out []string
+	inCode := false
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out = append(out, codeStyle.Render("  "+line))
+			continue
+		}
+
+		switch {
+		case reHeading.MatchString(trimmed):
+			text := reHeading.FindStringSubmatch(trimmed)[2]
+			out = append(out, wrap(headingStyle.Render(stripInline(text)), width, ""))
+		case reBullet.MatchString(line):
+			m := reBullet.FindStringSubmatch(line)
+			indent := m[1] + "  "
+			out = append(out, wrap(m[1]+"• "+inline(m[2]), width, indent))
+		case reOrdered.MatchString(line):
+			m := reOrdered.FindStringSubmatch(line)
+			indent := m[1] + strings.Repeat(" ", len(m[2])+1)
+			out = append(out, wrap(m[1]+m[2]+" "+inline(m[3]), width, indent))
+		case strings.HasPrefix(trimmed, ">"):
+			text := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			out = append(out, quoteStyle.Render(wrap("│ "+stripInline(text), width, "│ ")))
+		default:
+			out = append(out, wrap(inline(line), width, ""))
+		}
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+// inline оформляет выделения внутри строки.
+func inline(s string) string {
+	// Код обрабатывается первым: внутри него остальная разметка не действует
+	var codes []string
+	s = reCode.ReplaceAllStringFunc(s, func(m string) string {
+		codes = append(codes, codeStyle.Render(m[1:len(m)-1]))
+		return fmt.Sprintf("\x00%d\x00", len(codes)-1)
+	})
+	s = reLink.ReplaceAllStringFunc(s, func(m string) string {
+		parts := reLink.FindStringSubmatch(m)
+		return parts[1] + " (" + linkStyle.Render(parts[2]) + ")"
+	})
+	s = reBold.ReplaceAllStringFunc(s, func(m string) string {
+		return boldStyle.Render(m[2 : len(m)-2])
+	})
+	s = reItalic.ReplaceAllStringFunc(s, func(m string) string {
+		return italicStyle.Render(m[1 : len(m)-1])
+	})
+	for i, code := range codes {
+		s = strings.Replace(s, fmt.Sprintf("\x00%d\x00", i), code, 1)
+	}
+	return s
+}
+
+// stripInline убирает маркеры выделения там, где стиль задан всей строке.
+func stripInline(s string) string {
+	s = reLink.ReplaceAllString(s, "$1")
+	s = reBold.ReplaceAllString(s, "$1$2")
+	s = reItalic.ReplaceAllString(s, "$1$2")
+	return reCode.ReplaceAllString(s, "$1")
+}
+
+// wrap переносит строку по словам, продолжая перенесенные строки отступом indent.
+func wrap(s string, width int, indent string) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+	var lines []string
+	var cur string
+	for _, word := range strings.Fields(s) {
+		switch {
+		case cur == "":
+			cur = word
+		case lipgloss.Width(cur)+1+lipgloss.Width(word) > width:
+			lines = append(lines, cur)
+			cur = indent + word
+		default:
+			cur += " " + word
+		}
+	}
+	if cur != "" {
+		lines = append(lines, cur)
+	}
+	// Отступ исходной строки (например, у вложенного пункта списка) сохраняем
+	if lead := len(s) - len(strings.TrimLeft(s, " ")); lead > 0 && len(lines) > 0 {
+		lines[0] = s[:lead] + lines[0]
+	}
+	return strings.Join(lines, "\n")
+}
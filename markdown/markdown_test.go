@@ -0,0 +1,35 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderStripsMarkup(t *testing.T) {
+	src := "# План\n\n- купить **краску**\n- см. [гайд](https://example.com)\n> цитата\n```\nmake build\n```\nВызвать `magus show`"
+	got := Render(src, 0)
+
+	for _, want := range []string{"План", "• купить краску", "гайд (https://example.com)", "│ цитата", "  make build", "Вызвать magus show"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rendered text misses %q:\n%s", want, got)
+		}
+	}
+	for _, marker := range []string{"#", "**", "```", "`"} {
+		if strings.Contains(got, marker) {
+			t.Errorf("rendered text still contains %q:\n%s", marker, got)
+		}
+	}
+}
+
+func TestRenderWraps(t *testing.T) {
+	got := Render("- один два три четыре пять", 12)
+	lines := strings.Split(got, "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected wrapped lines, got %q", got)
+	}
+	for _, line := range lines[1:] {
+		if !strings.HasPrefix(line, "  ") {
+			t.Errorf("continuation line %q is not indented", line)
+		}
+	}
+}
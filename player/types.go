@@ -11,15 +11,66 @@ type Position struct {
 	Y int `json:"y"`
 }
 
+// PlayerClass — стабильный, не зависящий от языка идентификатор класса.
+// Отображаемое имя берется из каталога сообщений (см. rpg.ClassName).
 type PlayerClass string
 
 const (
 	ClassNone    PlayerClass = ""
-	ClassMage    PlayerClass = "Маг"
-	ClassWarrior PlayerClass = "Воин"
-	ClassRogue   PlayerClass = "Разбойник"
+	ClassMage    PlayerClass = "mage"
+	ClassWarrior PlayerClass = "warrior"
+	ClassRogue   PlayerClass = "rogue"
 )
 
+// legacyClasses сопоставляет старые русские значения класса с новыми ID.
+var legacyClasses = map[PlayerClass]PlayerClass{
+	"Маг":       ClassMage,
+	"Воин":      ClassWarrior,
+	"Разбойник": ClassRogue,
+}
+
+// NormalizeClass приводит старое русское имя класса к стабильному ID.
+func NormalizeClass(c PlayerClass) PlayerClass {
+	if id, ok := legacyClasses[c]; ok {
+		return id
+	}
+	return c
+}
+
+// Стабильные ID базовых навыков игрока (ключи Player.Skills).
+const (
+	SkillDiscipline   = "discipline"
+	SkillVitality     = "vitality"
+	SkillIntelligence = "intelligence"
+	SkillFocus        = "focus"
+	SkillLearning     = "learning"
+	SkillRegeneration = "regeneration"
+	SkillStrength     = "strength"
+	SkillFortitude    = "fortitude"
+	SkillEfficiency   = "efficiency"
+)
+
+// legacySkills сопоставляет старые русские ключи Player.Skills с новыми ID.
+var legacySkills = map[string]string{
+	"Дисциплина":    SkillDiscipline,
+	"Живучесть":     SkillVitality,
+	"Интеллект":     SkillIntelligence,
+	"Концентрация":  SkillFocus,
+	"Обучаемость":   SkillLearning,
+	"Регенерация":   SkillRegeneration,
+	"Сила":          SkillStrength,
+	"Стойкость":     SkillFortitude,
+	"Эффективность": SkillEfficiency,
+}
+
+// PerkPlanning — перк, дающий бонус XP родительскому квесту при добавлении подзадачи.
+const PerkPlanning = "planning"
+
+// legacyPerks сопоставляет старые русские имена перков с ID навыков.
+var legacyPerks = map[string]string{
+	"Планирование": PerkPlanning,
+}
+
 type Player struct {
 	Name            string         `json:"name"`
 	Class           PlayerClass    `json:"class,omitempty"`
@@ -39,6 +90,7 @@ type Player struct {
 	History         struct {
 		QuestsCompleted int `json:"quests_completed"`
 		XPGained        int `json:"xp_gained"`
+		FocusMinutes    int `json:"focus_minutes,omitempty"` // Всего минут в фокус-сессиях
 	} `json:"history"`
 	LastSeen time.Time `json:"last_seen"`
 }
@@ -61,6 +113,15 @@ type SkillNode struct {
 	Requirements     []string           `json:"requirements"`
 	Position         Position           `json:"position"`
 	Effects          map[string]float64 `json:"effects,omitempty"`
+	// Translations содержит переводы имени и описания по коду языка.
+	// Базовые Name и Description написаны на русском.
+	Translations map[string]SkillText `json:"translations,omitempty"`
+}
+
+// SkillText — переведенные имя и описание навыка.
+type SkillText struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 type Stats struct {
@@ -73,6 +134,31 @@ type Stats struct {
 	Discipline   int `json:"discipline"`
 }
 
+// StatNames — имена характеристик, как в JSON.
+var StatNames = []string{"strength", "endurance", "intelligence", "focus", "charisma", "willpower", "discipline"}
+
+// Stat возвращает указатель на характеристику по имени из StatNames
+// или nil для неизвестного имени.
+func (s *Stats) Stat(name string) *int {
+	switch name {
+	case "strength":
+		return &s.Strength
+	case "endurance":
+		return &s.Endurance
+	case "intelligence":
+		return &s.Intelligence
+	case "focus":
+		return &s.Focus
+	case "charisma":
+		return &s.Charisma
+	case "willpower":
+		return &s.Willpower
+	case "discipline":
+		return &s.Discipline
+	}
+	return nil
+}
+
 type QuestType string
 
 const (
@@ -93,10 +179,51 @@ const (
 	RitualMaintenance RitualType = "maintenance" // Поддержание (уборка)
 )
 
+// Priority — приоритет квеста. Пустое значение означает «без приоритета».
+type Priority string
+
+const (
+	PriorityNone   Priority = ""
+	PriorityLow    Priority = "low"
+	PriorityMedium Priority = "medium"
+	PriorityHigh   Priority = "high"
+)
+
+// Priorities перечисляет приоритеты по возрастанию.
+var Priorities = []Priority{PriorityNone, PriorityLow, PriorityMedium, PriorityHigh}
+
+// ParsePriority принимает полное имя или первую букву (h, m, l); "none" снимает приоритет.
+func ParsePriority(s string) (Priority, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "none", "n":
+		return PriorityNone, true
+	case "low", "l":
+		return PriorityLow, true
+	case "medium", "m":
+		return PriorityMedium, true
+	case "high", "h":
+		return PriorityHigh, true
+	}
+	return PriorityNone, false
+}
+
+// Next возвращает следующий приоритет по кругу: none → low → medium → high → none.
+func (p Priority) Next() Priority {
+	for i, pr := range Priorities {
+		if pr == p {
+			return Priorities[(i+1)%len(Priorities)]
+		}
+	}
+	return PriorityNone
+}
+
 type Quest struct {
 	ID            string     `json:"id"`
-	ParentID      string     `json:"parent_id,omitempty"` // ID родительского квеста
+	ParentID      string     `json:"parent_id,omitempty"`  // ID родительского квеста
+	Order         int        `json:"order,omitempty"`      // Ручной порядок среди соседей по родителю (см. пакет tree)
+	BlockedBy     []string   `json:"blocked_by,omitempty"` // ID квестов, которые нужно выполнить раньше (см. пакет deps)
 	Title         string     `json:"title"`
+	Priority      Priority   `json:"priority,omitempty"`
 	Type          QuestType  `json:"type"`
 	RitualSubtype RitualType `json:"ritual_subtype,omitempty"` // Только для RitualQuest
 
@@ -105,12 +232,46 @@ type Quest struct {
 	Progress int `json:"progress,omitempty"` // Текущий прогресс по HP
 
 	// Общие поля
-	XP          int        `json:"xp"`
-	Tags        []string   `json:"tags,omitempty"`
-	Deadline    *time.Time `json:"deadline,omitempty"`
-	Completed   bool       `json:"completed"`
-	CompletedAt time.Time  `json:"completed_at"`
-	CreatedAt   time.Time  `json:"created_at"`
+	XP           int        `json:"xp"`
+	Tags         []string   `json:"tags,omitempty"`
+	Deadline     *time.Time `json:"deadline,omitempty"`
+	DeadlineTime bool       `json:"deadline_time,omitempty"` // В сроке явно указано время (см. пакет deadline)
+	StartAt      *time.Time `json:"start_at,omitempty"`      // Квест скрыт до этого момента (см. пакет snooze)
+	PenaltyAt    *time.Time `json:"penalty_at,omitempty"`    // Штраф за просрочку начислен по этот момент; nil — квест из старых данных
+	Completed    bool       `json:"completed"`
+	Rewarded     bool       `json:"rewarded,omitempty"` // Награда уже выдана: квест восстановлен из архива
+	CompletedAt  time.Time  `json:"completed_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+
+	FocusMinutes int `json:"focus_minutes,omitempty"` // Время, проведенное над квестом в фокус-сессиях
+
+	Checklist []ChecklistItem `json:"checklist,omitempty"` // Мелкие шаги без отдельных подзадач (см. пакет checklist)
+
+	// Описание и заметки
+	Description string      `json:"description,omitempty"` // Описание в Markdown (см. пакет markdown)
+	Notes       []QuestNote `json:"notes,omitempty"`       // Заметки с датой в порядке добавления
+	Links       []string    `json:"links,omitempty"`       // Ссылки и пути к файлам
+
+	// Повторяющиеся квесты и ритуалы
+	Recurrence  string      `json:"recurrence,omitempty"`  // Правило повтора (подмножество RRULE, см. пакет recur)
+	Completions []time.Time `json:"completions,omitempty"` // Журнал выполнений всех экземпляров
+
+	// Поля для RitualQuest (см. пакет ritual)
+	CooldownHours int `json:"cooldown_hours,omitempty"` // Перезарядка в часах; 0 — раз в игровой день
+	Streak        int `json:"streak,omitempty"`         // Текущая серия выполнений без пропусков
+	BestStreak    int `json:"best_streak,omitempty"`    // Лучшая серия
+}
+
+// ChecklistItem — пункт чек-листа квеста.
+type ChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done,omitempty"`
+}
+
+// QuestNote — заметка к квесту.
+type QuestNote struct {
+	Date time.Time `json:"date"`
+	Text string    `json:"text"`
 }
 
 // FilterValue implements list.Item.
@@ -121,4 +282,5 @@ func (q Quest) FilterValue() string {
 type History struct {
 	QuestsCompleted int `json:"quests_completed"`
 	XPGained        int `json:"xp_gained"`
+	FocusMinutes    int `json:"focus_minutes,omitempty"` // Всего минут в фокус-сессиях
 }
@@ -3,6 +3,7 @@ package player
 import (
 	"encoding/json"
 	"errors"
+	"magus/leveling"
 	"os" // lol
 	"time"
 )
@@ -11,6 +12,15 @@ var PlayerFile = "data/player.json"
 
 var ErrPlayerNotFound = errors.New("player file not found")
 
+// Базовые максимумы HP и маны без навыков и класса (см. пакет effects).
+const (
+	BaseHP   = 100
+	BaseMana = 100
+)
+
+// SkillPointsPerLevel — очки навыков за новый уровень без бонусов класса.
+const SkillPointsPerLevel = 10
+
 // AddXP добавляет опыт игроку и возвращает true, если можно повысить уровень.
 func AddXP(xp int) (bool, error) {
 	p, err := LoadPlayer()
@@ -18,9 +28,8 @@ func AddXP(xp int) (bool, error) {
 		return false, err
 	}
 
-	p.XP += xp
+	p.GainXP(xp)
 	p.History.QuestsCompleted++
-	p.History.XPGained += xp
 
 	err = SavePlayer(p)
 	if err != nil {
@@ -31,24 +40,26 @@ func AddXP(xp int) (bool, error) {
 	return p.XP >= p.NextLevelXP, nil
 }
 
-// LevelUpPlayer повышает уровень игрока, добавляет выбранный перк и начисляет очки навыков.
-func LevelUpPlayer(chosenPerkName string) error {
-	p, err := LoadPlayer()
-	if err != nil {
-		return err
-	}
+// GainXP начисляет опыт без сохранения и возвращает true, если можно повысить уровень.
+func (p *Player) GainXP(xp int) bool {
+	p.XP += xp
+	p.History.XPGained += xp
+	return p.XP >= p.NextLevelXP
+}
 
+// LevelUp повышает уровень на один, если опыта хватает, и начисляет
+// skillPoints очков навыков. next — порог нового уровня по кривой опыта
+// (см. game.LevelUp). Возвращает false, если опыта не хватает.
+func (p *Player) LevelUp(next, skillPoints int) bool {
 	if p.XP < p.NextLevelXP {
-		// На всякий случай, если функция будет вызвана по ошибке
-		return nil
+		return false
 	}
 
 	p.Level++
 	p.XP -= p.NextLevelXP
-	p.NextLevelXP = calculateNextLevelXP(p.Level)
-	p.SkillPoints += 10 // Начисляем 10 очков навыков за уровень
-
-	return SavePlayer(p)
+	p.NextLevelXP = next
+	p.SkillPoints += skillPoints
+	return true
 }
 
 // CreatePlayer создает нового игрока с заданным именем.
@@ -59,10 +70,12 @@ func CreatePlayer(name string) (*Player, error) {
 	p := &Player{
 		Name:        name,
 		Level:       1,
-		HP:          100,
-		MaxHP:       100,
+		HP:          BaseHP,
+		MaxHP:       BaseHP,
+		Mana:        BaseMana,
+		MaxMana:     BaseMana,
 		XP:          0,
-		NextLevelXP: 100,
+		NextLevelXP: leveling.Default.Next(1),
 		Skills:      make(map[string]int),
 		History: History{
 			QuestsCompleted: 0,
@@ -93,12 +106,14 @@ func LoadPlayer() (*Player, error) {
 		p.Skills = make(map[string]int)
 	}
 
-	// ��ля обратной совместимости: если у старого игрока нет HP, устанавливаем его
+	// Для обратной совместимости: если у старого игрока нет HP, устанавливаем его
 	if p.MaxHP == 0 {
-		p.MaxHP = 100
-		p.HP = 100
+		p.MaxHP = BaseHP
+		p.HP = BaseHP
 	}
 
+	migrateLegacyIDs(&p)
+
 	return &p, nil
 }
 
@@ -116,8 +131,21 @@ func SavePlayer(p *Player) error {
 	return os.WriteFile(PlayerFile, data, 0644)
 }
 
-// calculateNextLevelXP определяет, сколько опыта нужно для следующего уровня.
-func calculateNextLevelXP(level int) int {
-	return 100 * level * level
-}
+// migrateLegacyIDs заменяет русские имена класса, навыков и перков из старых
+// сохранений на стабильные ID, чтобы сохранения не зависели от языка.
+func migrateLegacyIDs(p *Player) {
+	p.Class = NormalizeClass(p.Class)
 
+	for oldKey, id := range legacySkills {
+		if v, ok := p.Skills[oldKey]; ok {
+			delete(p.Skills, oldKey)
+			p.Skills[id] += v
+		}
+	}
+
+	for i, skill := range p.UnlockedSkills {
+		if id, ok := legacyPerks[skill]; ok {
+			p.UnlockedSkills[i] = id
+		}
+	}
+}
@@ -59,3 +59,38 @@ func TestAddXP(t *testing.T) {
 		t.Errorf("Expected XP to be 110, but got %d", loadedPlayer.XP)
 	}
 }
+
+func TestLevelUp(t *testing.T) {
+	p := &Player{Level: 1, XP: 130, NextLevelXP: 100}
+	if !p.LevelUp(400, 10) {
+		t.Fatal("LevelUp failed with enough XP")
+	}
+	if p.Level != 2 || p.XP != 30 || p.NextLevelXP != 400 || p.SkillPoints != 10 {
+		t.Errorf("after LevelUp: %+v", p)
+	}
+	if p.LevelUp(900, 10) {
+		t.Error("LevelUp succeeded without enough XP")
+	}
+}
+
+func TestLoadPlayerMigratesLegacyIDs(t *testing.T) {
+	legacy := `{"name":"Old","class":"Маг","level":1,"max_hp":100,"next_level_xp":100,
+		"skills":{"Концентрация":8},"unlocked_skills":["Планирование","hp_1"]}`
+	if err := os.WriteFile(PlayerFile, []byte(legacy), 0644); err != nil {
+		t.Fatalf("failed to write legacy player: %v", err)
+	}
+
+	p, err := LoadPlayer()
+	if err != nil {
+		t.Fatalf("LoadPlayer failed: %v", err)
+	}
+	if p.Class != ClassMage {
+		t.Errorf("expected class %q, got %q", ClassMage, p.Class)
+	}
+	if p.Skills[SkillFocus] != 8 {
+		t.Errorf("expected focus skill 8, got %v", p.Skills)
+	}
+	if p.UnlockedSkills[0] != PerkPlanning {
+		t.Errorf("expected legacy perk to be migrated, got %v", p.UnlockedSkills)
+	}
+}
@@ -0,0 +1,222 @@
+// Package prompt строит однострочный статус игрока для PS1, tmux и статус-баров.
+//
+// Пакет намеренно легкий: он читает только player.json, quests.json и
+// config.json и не трогает дерево навыков и TUI, чтобы команда
+// `magus prompt` отвечала за несколько миллисекунд.
+package prompt
+
+import (
+	"encoding/json"
+	"magus/agenda"
+	"magus/config"
+	"magus/deadline"
+	"magus/i18n"
+	"magus/player"
+	"magus/recur"
+	"magus/snooze"
+	"magus/storage"
+	"magus/utils"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var CacheFile = "data/.prompt_cache"
+
+// Status — данные, которые можно вывести в строке статуса.
+type Status struct {
+	Name    string
+	Level   int
+	HP      int
+	MaxHP   int
+	Mana    int
+	MaxMana int
+	XP      int
+	NextXP  int
+	Due     int // Незавершенные квесты со сроком до конца сегодняшнего дня
+	Overdue int // Из них уже просроченные
+}
+
+// Options управляют видом строки.
+type Options struct {
+	// Format — шаблон с плейсхолдерами вида {level}. Пустой означает формат по умолчанию.
+	Format string
+	// NoEmoji убирает эмодзи для терминалов и шрифтов, которые их не умеют.
+	NoEmoji bool
+}
+
+// Placeholders — все поддерживаемые плейсхолдеры шаблона.
+var Placeholders = []string{"{name}", "{level}", "{hp}", "{max_hp}", "{mana}", "{max_mana}", "{xp}", "{next_xp}", "{due}", "{overdue}"}
+
+// Build собирает статус из игрока и квестов на момент now. Отложенные
+// квесты, как и в плане дня, не учитываются.
+func Build(p *player.Player, quests []player.Quest, now time.Time, dayStartHour int) Status {
+	s := Status{
+		Name:    p.Name,
+		Level:   p.Level,
+		HP:      p.HP,
+		MaxHP:   p.MaxHP,
+		Mana:    p.Mana,
+		MaxMana: p.MaxMana,
+		XP:      p.XP,
+		NextXP:  p.NextLevelXP,
+	}
+	dayEnd := utils.DayEnd(now, dayStartHour)
+	for _, q := range snooze.Active(quests, now) {
+		if q.Completed || !agenda.IsDue(q, dayEnd) {
+			continue
+		}
+		s.Due++
+		if agenda.IsOverdue(q, now) {
+			s.Overdue++
+		}
+	}
+	return s
+}
+
+// Render подставляет значения статуса в шаблон.
+func Render(s Status, opts Options) string {
+	format := opts.Format
+	if format == "" {
+		format = i18n.T("prompt.format")
+		if opts.NoEmoji {
+			format = i18n.T("prompt.format_plain")
+		}
+	}
+	r := strings.NewReplacer(
+		"{name}", s.Name,
+		"{level}", strconv.Itoa(s.Level),
+		"{hp}", strconv.Itoa(s.HP),
+		"{max_hp}", strconv.Itoa(s.MaxHP),
+		"{mana}", strconv.Itoa(s.Mana),
+		"{max_mana}", strconv.Itoa(s.MaxMana),
+		"{xp}", strconv.Itoa(s.XP),
+		"{next_xp}", strconv.Itoa(s.NextXP),
+		"{due}", strconv.Itoa(s.Due),
+		"{overdue}", strconv.Itoa(s.Overdue),
+	)
+	out := r.Replace(format)
+	if opts.NoEmoji {
+		out = StripEmoji(out)
+	}
+	return out
+}
+
+// StripEmoji удаляет из строки эмодзи и служебные символы вокруг них
+// и схлопывает образовавшиеся двойные пробелы.
+func StripEmoji(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\u200d', r >= '\ufe00' && r <= '\ufe0f':
+			// Соединитель и селекторы вариантов — части составных эмодзи
+			continue
+		case unicode.Is(unicode.So, r), unicode.Is(unicode.Sk, r) && r > unicode.MaxASCII:
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// cacheEntry — сохраненная строка и отпечаток данных, из которых она построена.
+type cacheEntry struct {
+	Key       string `json:"key"`
+	PlayerMod int64  `json:"player_mod"`
+	QuestsMod int64  `json:"quests_mod"`
+	ConfigMod int64  `json:"config_mod"`
+	DayStart  int64  `json:"day_start"`
+	Expires   int64  `json:"expires"` // Конец дня или ближайший срок квеста, если он раньше
+	Output    string `json:"output"`
+}
+
+// Line возвращает строку статуса, по возможности из кэша.
+// Кэш действителен, пока не изменились файлы игрока, квестов и настроек,
+// не наступил новый день и не прошел ближайший срок квеста: от них
+// зависит число квестов к сроку и просроченных.
+func Line(opts Options, now time.Time) (string, error) {
+	fingerprint := cacheEntry{
+		Key:       string(i18n.Current()) + "\x00" + strconv.FormatBool(opts.NoEmoji) + "\x00" + opts.Format,
+		PlayerMod: modTime(player.PlayerFile),
+		QuestsMod: modTime(storage.QuestFile),
+		ConfigMod: modTime(config.ConfigFile),
+	}
+
+	if cached, ok := readCache(); ok && cached.Key == fingerprint.Key &&
+		cached.PlayerMod == fingerprint.PlayerMod &&
+		cached.QuestsMod == fingerprint.QuestsMod &&
+		cached.ConfigMod == fingerprint.ConfigMod &&
+		now.Unix() >= cached.DayStart && now.Unix() < cached.Expires {
+		return cached.Output, nil
+	}
+
+	p, err := player.LoadPlayer()
+	if err != nil {
+		return "", err
+	}
+	quests, err := storage.LoadAllQuests()
+	if err != nil {
+		return "", err
+	}
+	cfg, _ := config.Load()
+	// Расписания повторяющихся квестов учитываем без сохранения: prompt только читает данные
+	recur.Roll(quests, now)
+
+	fingerprint.DayStart = utils.DayStart(now, cfg.DayStartHour).Unix()
+	fingerprint.Expires = expiry(quests, now, utils.DayEnd(now, cfg.DayStartHour)).Unix()
+	fingerprint.Output = Render(Build(p, quests, now, cfg.DayStartHour), opts)
+	// Кэш — только ускорение: если его не удалось записать, строка все равно верна
+	writeCache(fingerprint)
+	return fingerprint.Output, nil
+}
+
+// expiry возвращает момент, когда строка устареет без записи файлов:
+// ближайший срок или дата начала отложенного квеста после now, но не
+// позже конца дня dayEnd.
+func expiry(quests []player.Quest, now, dayEnd time.Time) time.Time {
+	next := dayEnd
+	for _, q := range quests {
+		if q.Completed {
+			continue
+		}
+		if q.Deadline != nil {
+			if due := deadline.Due(*q.Deadline, q.DeadlineTime); due.After(now) && due.Before(next) {
+				next = due
+			}
+		}
+		if q.StartAt != nil && q.StartAt.After(now) && q.StartAt.Before(next) {
+			next = *q.StartAt
+		}
+	}
+	return next
+}
+
+func modTime(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.ModTime().UnixNano()
+}
+
+func readCache() (cacheEntry, bool) {
+	var c cacheEntry
+	data, err := os.ReadFile(CacheFile)
+	if err != nil {
+		return c, false
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, false
+	}
+	return c, true
+}
+
+func writeCache(c cacheEntry) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+	os.WriteFile(CacheFile, data, 0644)
+}
@@ -0,0 +1,131 @@
+package prompt
+
+import (
+	"magus/config"
+	"magus/i18n"
+	"magus/player"
+	"magus/storage"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	i18n.SetLang(i18n.EN)
+	defer i18n.SetLang(i18n.DefaultLang)
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.Local)
+	yesterday := now.AddDate(0, 0, -1)
+	later := now.Add(3 * time.Hour)
+	nextWeek := now.AddDate(0, 0, 7)
+	tomorrow := now.AddDate(0, 0, 1)
+	quests := []player.Quest{
+		{ID: "a", Deadline: &yesterday},
+		{ID: "b", Deadline: &later},
+		{ID: "c", Deadline: &nextWeek},
+		{ID: "d", Deadline: &yesterday, Completed: true},
+		{ID: "e"},
+		// Отложенный квест и его подзадача не считаются, хотя срок прошел
+		{ID: "f", Deadline: &yesterday, StartAt: &tomorrow},
+		{ID: "g", ParentID: "f", Deadline: &yesterday},
+	}
+	p := &player.Player{Level: 7, HP: 82, MaxHP: 100, Mana: 14, MaxMana: 30}
+
+	s := Build(p, quests, now, 0)
+	if s.Due != 2 || s.Overdue != 1 {
+		t.Fatalf("Due/Overdue = %d/%d, want 2/1", s.Due, s.Overdue)
+	}
+
+	if got, want := Render(s, Options{}), "Lv7 ❤82/100 💧14/30 ⏳2 due"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+	if got, want := Render(s, Options{NoEmoji: true}), "Lv7 HP 82/100 MP 14/30 due: 2"; got != want {
+		t.Errorf("Render(NoEmoji) = %q, want %q", got, want)
+	}
+	if got, want := Render(s, Options{Format: "❤️ {hp} ⏳ {overdue}!", NoEmoji: true}), "82 1!"; got != want {
+		t.Errorf("Render(custom, NoEmoji) = %q, want %q", got, want)
+	}
+}
+
+func TestLineUsesCache(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []*string{&player.PlayerFile, &storage.QuestFile, &config.ConfigFile, &CacheFile} {
+		orig := *f
+		*f = filepath.Join(dir, filepath.Base(orig))
+		defer func(f *string) { *f = orig }(f)
+	}
+	if err := os.WriteFile(player.PlayerFile, []byte(`{"level": 3, "hp": 50, "max_hp": 100}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	opts := Options{Format: "{level}"}
+	if got, _ := Line(opts, now); got != "3" {
+		t.Fatalf("Line() = %q, want 3", got)
+	}
+
+	// Подменяем ответ в кэше: пока файлы не менялись, должен вернуться он
+	c, ok := readCache()
+	if !ok {
+		t.Fatal("cache was not written")
+	}
+	c.Output = "cached"
+	writeCache(c)
+	if got, _ := Line(opts, now); got != "cached" {
+		t.Errorf("Line() = %q, want cached value", got)
+	}
+
+	// Новый день сбрасывает кэш
+	if got, _ := Line(opts, now.AddDate(0, 0, 1)); got != "3" {
+		t.Errorf("Line() next day = %q, want 3", got)
+	}
+}
+
+func TestLineExpiresAtDeadline(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []*string{&player.PlayerFile, &storage.QuestFile, &config.ConfigFile, &CacheFile} {
+		orig := *f
+		*f = filepath.Join(dir, filepath.Base(orig))
+		defer func(f *string) { *f = orig }(f)
+	}
+	if err := os.WriteFile(player.PlayerFile, []byte(`{"level": 3}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.Local)
+	due := now.Add(3 * time.Hour)
+	if err := storage.SaveAllQuests([]player.Quest{{ID: "a", Deadline: &due, DeadlineTime: true}}); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := Options{Format: "{due}/{overdue}"}
+	if got, _ := Line(opts, now); got != "1/0" {
+		t.Fatalf("Line() = %q, want 1/0", got)
+	}
+	if got, _ := Line(opts, due.Add(-time.Minute)); got != "1/0" {
+		t.Errorf("Line() before deadline = %q, want 1/0", got)
+	}
+	// Срок прошел без записи файлов — кэш устарел
+	if got, _ := Line(opts, due.Add(time.Minute)); got != "1/1" {
+		t.Errorf("Line() after deadline = %q, want 1/1", got)
+	}
+}
+
+// Команда вызывается на каждую отрисовку приглашения,
+// поэтому пакет не должен тянуть TUI и дерево навыков.
+func TestNoHeavyDependencies(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go tool not found")
+	}
+	out, err := exec.Command("go", "list", "-deps", ".").Output()
+	if err != nil {
+		t.Fatalf("go list: %v", err)
+	}
+	for _, dep := range strings.Fields(string(out)) {
+		if dep == "magus/tui" || dep == "magus/rpg" || strings.HasPrefix(dep, "github.com/charmbracelet/") {
+			t.Errorf("prompt depends on %s", dep)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package recur
+
+import (
+	"fmt"
+	"magus/checklist"
+	"magus/i18n"
+	"magus/player"
+	"time"
+)
+
+// RuleOf разбирает правило повтора квеста.
+// Для квестов без повтора или с испорченным правилом ok == false.
+func RuleOf(q player.Quest) (Rule, bool) {
+	if q.Recurrence == "" {
+		return Rule{}, false
+	}
+	r, err := Parse(q.Recurrence)
+	if err != nil {
+		return Rule{}, false
+	}
+	r.Start = q.CreatedAt
+	return r, true
+}
+
+// Complete отмечает выполнение текущего экземпляра повторяющегося квеста:
+// записывает его в журнал, сбрасывает прогресс и переносит срок на
+// следующее повторение. Квест при этом не становится Completed.
+// Возвращает false, если квест не повторяющийся.
+func Complete(q *player.Quest, now time.Time) bool {
+	r, ok := RuleOf(*q)
+	if !ok {
+		return false
+	}
+	q.Completions = append(q.Completions, now)
+	q.CompletedAt = now
+	q.Completed = false
+	q.Progress = 0
+	checklist.Reset(q) // Новый экземпляр начинается с чистым чек-листом
+
+	if q.Deadline == nil {
+		due := r.First(now)
+		q.Deadline, q.DeadlineTime = &due, false
+	}
+	if r.Times > 0 {
+		// Квота периода: срок сдвигается, только когда она выполнена
+		if done, _ := PeriodProgress(*q); done >= r.Times {
+			next := r.Next(*q.Deadline)
+			q.Deadline, q.DeadlineTime = &next, false
+		}
+		return true
+	}
+
+	// Выполнили раньше срока — засчитываем текущий экземпляр, позже — следующий ждет после now
+	base := *q.Deadline
+	if now.After(base) {
+		base = now
+	}
+	next := r.Next(base)
+	q.Deadline, q.DeadlineTime = &next, false // Сроки повторений — конец дня
+	return true
+}
+
+// PeriodProgress возвращает число выполнений в текущем периоде и квоту.
+// Для правил без TIMES квота равна 1.
+func PeriodProgress(q player.Quest) (done, times int) {
+	r, ok := RuleOf(q)
+	if !ok || q.Deadline == nil {
+		return 0, 0
+	}
+	times = 1
+	if r.Times > 0 {
+		times = r.Times
+	}
+	start := r.PeriodStart(*q.Deadline)
+	for _, c := range q.Completions {
+		if !c.Before(start) && !c.After(*q.Deadline) {
+			done++
+		}
+	}
+	return done, times
+}
+
+// Roll приводит повторяющиеся квесты к текущему моменту: выставляет срок
+// квестам без него и заменяет пропущенные экземпляры актуальными, когда
+// наступает следующее повторение. Пропуски не копятся: просроченный
+// экземпляр висит, пока не начнется следующий. Возвращает true, если
+// что-то изменилось и квесты стоит сохранить.
+func Roll(quests []player.Quest, now time.Time) bool {
+	changed := false
+	for i := range quests {
+		q := &quests[i]
+		if q.Completed {
+			continue
+		}
+		r, ok := RuleOf(*q)
+		if !ok {
+			continue
+		}
+		if q.Deadline == nil {
+			due := r.First(now)
+			q.Deadline, q.DeadlineTime = &due, false
+			changed = true
+			continue
+		}
+		for {
+			next := r.Next(*q.Deadline)
+			if next.IsZero() || r.PeriodStart(next).After(now) {
+				break
+			}
+			q.Deadline, q.DeadlineTime = &next, false
+			q.Progress = 0
+			checklist.Reset(q) // Как и в Complete: новый экземпляр — чистый чек-лист
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Summary описывает расписание квеста, выполнение квоты и срок
+// следующего экземпляра, например «еженедельно: пн, далее: 2024-05-13».
+func Summary(q player.Quest) string {
+	r, ok := RuleOf(q)
+	if !ok {
+		return ""
+	}
+	summary := r.Describe()
+	if r.Times > 0 {
+		done, times := PeriodProgress(q)
+		summary += fmt.Sprintf(" %d/%d", done, times)
+	}
+	if q.Deadline != nil {
+		summary += ", " + i18n.T("recur.next", q.Deadline.Format("2006-01-02"))
+	}
+	return summary
+}
@@ -0,0 +1,325 @@
+// Package recur описывает расписания повторяющихся квестов.
+//
+// Правило записывается подмножеством RRULE (RFC 5545):
+//
+//	FREQ=DAILY                     — каждый день
+//	FREQ=WEEKLY;BYDAY=MO           — каждый понедельник
+//	FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH
+//	FREQ=MONTHLY;BYMONTHDAY=1      — 1-го числа (-1 — последний день месяца)
+//
+// Сверх RFC поддерживается ключ TIMES: FREQ=WEEKLY;TIMES=3 означает
+// «3 раза в неделю в любые дни», срок такого квеста — конец периода.
+package recur
+
+import (
+	"errors"
+	"fmt"
+	"magus/i18n"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Freq string
+
+const (
+	Daily   Freq = "DAILY"
+	Weekly  Freq = "WEEKLY"
+	Monthly Freq = "MONTHLY"
+)
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// maxScanDays ограничивает поиск следующего повторения (10 лет).
+const maxScanDays = 3660
+
+// defaultStart — опорная дата для правил без Start (понедельник).
+var defaultStart = time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC)
+
+// Rule — разобранное правило повтора.
+type Rule struct {
+	Freq       Freq
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	Times      int // Сколько раз за период; дни выполнения не важны
+	// Start — опорная дата для INTERVAL и дней по умолчанию.
+	// В строку правила не входит: для квестов это дата создания.
+	Start time.Time
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+var weekdayOrder = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Parse разбирает правило. Префикс "RRULE:" необязателен.
+func Parse(s string) (Rule, error) {
+	r := Rule{Interval: 1}
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if s == "" {
+		return r, fmt.Errorf("%w: empty", ErrInvalidRule)
+	}
+
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return r, fmt.Errorf("%w: %q", ErrInvalidRule, part)
+		}
+		switch key {
+		case "FREQ":
+			switch Freq(value) {
+			case Daily, Weekly, Monthly:
+				r.Freq = Freq(value)
+			default:
+				return r, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRule, value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return r, fmt.Errorf("%w: INTERVAL %q", ErrInvalidRule, value)
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				wd, ok := weekdayCodes[code]
+				if !ok {
+					return r, fmt.Errorf("%w: BYDAY %q", ErrInvalidRule, code)
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(v)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return r, fmt.Errorf("%w: BYMONTHDAY %q", ErrInvalidRule, v)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, n)
+			}
+		case "TIMES":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return r, fmt.Errorf("%w: TIMES %q", ErrInvalidRule, value)
+			}
+			r.Times = n
+		default:
+			return r, fmt.Errorf("%w: unsupported key %q", ErrInvalidRule, key)
+		}
+	}
+
+	switch {
+	case r.Freq == "":
+		return r, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	case len(r.ByDay) > 0 && r.Freq != Weekly:
+		return r, fmt.Errorf("%w: BYDAY needs FREQ=WEEKLY", ErrInvalidRule)
+	case len(r.ByMonthDay) > 0 && r.Freq != Monthly:
+		return r, fmt.Errorf("%w: BYMONTHDAY needs FREQ=MONTHLY", ErrInvalidRule)
+	case r.Times > 0 && r.Freq == Daily:
+		return r, fmt.Errorf("%w: TIMES needs FREQ=WEEKLY or MONTHLY", ErrInvalidRule)
+	case r.Times > 0 && (len(r.ByDay) > 0 || len(r.ByMonthDay) > 0):
+		return r, fmt.Errorf("%w: TIMES can't be combined with BYDAY/BYMONTHDAY", ErrInvalidRule)
+	}
+	return r, nil
+}
+
+// String возвращает каноническую запись правила.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, wd := range r.ByDay {
+			codes[i] = weekdayOrder[wd]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Times > 0 {
+		parts = append(parts, "TIMES="+strconv.Itoa(r.Times))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next возвращает срок первого повторения строго после дня after
+// (конец дня повторения). Для правил с TIMES это конец следующего периода.
+// Если повторений не нашлось, возвращается нулевое время.
+func (r Rule) Next(after time.Time) time.Time {
+	day := dateOf(after).AddDate(0, 0, 1)
+	for i := 0; i < maxScanDays; i++ {
+		if r.occursOn(day) {
+			return endOfDay(day)
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}
+}
+
+// First возвращает срок ближайшего повторения начиная с дня now включительно.
+func (r Rule) First(now time.Time) time.Time {
+	return r.Next(dateOf(now).AddDate(0, 0, -1))
+}
+
+// PeriodStart возвращает момент, с которого повторение со сроком due
+// становится актуальным: начало дня, а для TIMES — начало периода.
+func (r Rule) PeriodStart(due time.Time) time.Time {
+	day := dateOf(due)
+	if r.Times == 0 {
+		return day
+	}
+	if r.Freq == Weekly {
+		return day.AddDate(0, 0, -daysSinceMonday(day))
+	}
+	return day.AddDate(0, 0, 1-day.Day())
+}
+
+// occursOn сообщает, приходится ли на день day срок повторения.
+func (r Rule) occursOn(day time.Time) bool {
+	start := r.Start
+	if start.IsZero() {
+		start = defaultStart
+	}
+	start = dateOf(start.In(day.Location()))
+
+	switch r.Freq {
+	case Daily:
+		return mod(dayNumber(day)-dayNumber(start), r.interval()) == 0
+	case Weekly:
+		weeks := (dayNumber(weekStart(day)) - dayNumber(weekStart(start))) / 7
+		if mod(weeks, r.interval()) != 0 {
+			return false
+		}
+		if r.Times > 0 {
+			return day.Weekday() == time.Sunday
+		}
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		for _, wd := range days {
+			if day.Weekday() == wd {
+				return true
+			}
+		}
+		return false
+	case Monthly:
+		months := (day.Year()-start.Year())*12 + int(day.Month()) - int(start.Month())
+		if mod(months, r.interval()) != 0 {
+			return false
+		}
+		last := daysInMonth(day)
+		if r.Times > 0 {
+			return day.Day() == last
+		}
+		monthDays := r.ByMonthDay
+		if len(monthDays) == 0 {
+			monthDays = []int{start.Day()}
+		}
+		for _, md := range monthDays {
+			if md < 0 {
+				md = last + md + 1
+			}
+			if day.Day() == md {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// Describe возвращает человекочитаемое описание правила на текущем языке.
+func (r Rule) Describe() string {
+	switch r.Freq {
+	case Daily:
+		if r.Interval > 1 {
+			return i18n.T("recur.every_n_days", r.Interval)
+		}
+		return i18n.T("recur.daily")
+	case Weekly:
+		if r.Times > 0 {
+			return i18n.T("recur.times_week", r.Times)
+		}
+		var days []string
+		for _, wd := range r.ByDay {
+			days = append(days, i18n.T("recur.weekday."+strings.ToLower(weekdayOrder[wd])))
+		}
+		desc := i18n.T("recur.weekly")
+		if r.Interval > 1 {
+			desc = i18n.T("recur.every_n_weeks", r.Interval)
+		}
+		if len(days) > 0 {
+			desc += ": " + strings.Join(days, ", ")
+		}
+		return desc
+	case Monthly:
+		if r.Times > 0 {
+			return i18n.T("recur.times_month", r.Times)
+		}
+		var days []string
+		for _, d := range r.ByMonthDay {
+			if d == -1 {
+				days = append(days, i18n.T("recur.last_day"))
+			} else {
+				days = append(days, strconv.Itoa(d))
+			}
+		}
+		desc := i18n.T("recur.monthly")
+		if r.Interval > 1 {
+			desc = i18n.T("recur.every_n_months", r.Interval)
+		}
+		if len(days) > 0 {
+			desc += ": " + strings.Join(days, ", ")
+		}
+		return desc
+	}
+	return r.String()
+}
+
+func (r Rule) interval() int {
+	if r.Interval < 1 {
+		return 1
+	}
+	return r.Interval
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func endOfDay(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 59, 0, day.Location())
+}
+
+// dayNumber — номер календарного дня, не зависящий от переходов на летнее время.
+func dayNumber(day time.Time) int {
+	return int(time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+func daysSinceMonday(day time.Time) int {
+	return (int(day.Weekday()) + 6) % 7
+}
+
+func weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -daysSinceMonday(day))
+}
+
+func daysInMonth(day time.Time) int {
+	return time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+}
+
+func mod(a, n int) int {
+	return (a%n + n) % n
+}
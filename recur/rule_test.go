@@ -0,0 +1,142 @@
+package recur
+
+import (
+	"errors"
+	"magus/player"
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 12, 0, 0, 0, time.Local)
+}
+
+func TestParse(t *testing.T) {
+	r, err := Parse("rrule:freq=weekly;byday=mo,th;interval=2")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got, want := r.String(), "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	for _, bad := range []string{"", "FREQ=HOURLY", "BYDAY=MO", "FREQ=DAILY;BYDAY=MO", "FREQ=WEEKLY;TIMES=3;BYDAY=MO", "FREQ=MONTHLY;BYMONTHDAY=32", "FREQ=DAILY;COUNT=3"} {
+		if _, err := Parse(bad); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalidRule", bad, err)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		rule  string
+		after time.Time
+		want  time.Time
+	}{
+		// 2024-05-10 — пятница
+		{"FREQ=DAILY", date(2024, 5, 10), date(2024, 5, 11)},
+		{"FREQ=WEEKLY;BYDAY=MO", date(2024, 5, 10), date(2024, 5, 13)},
+		{"FREQ=WEEKLY;BYDAY=MO", date(2024, 5, 13), date(2024, 5, 20)},
+		{"FREQ=WEEKLY;TIMES=3", date(2024, 5, 10), date(2024, 5, 12)},
+		{"FREQ=MONTHLY;BYMONTHDAY=1", date(2024, 5, 10), date(2024, 6, 1)},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", date(2024, 2, 10), date(2024, 2, 29)},
+		{"FREQ=MONTHLY;BYMONTHDAY=31", date(2024, 4, 10), date(2024, 5, 31)},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.rule)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.rule, err)
+		}
+		got := r.Next(tt.after)
+		if y, m, d := got.Date(); y != tt.want.Year() || m != tt.want.Month() || d != tt.want.Day() {
+			t.Errorf("%s: Next(%s) = %s, want %s", tt.rule, tt.after.Format("2006-01-02"), got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+
+	// INTERVAL отсчитывается от даты создания
+	r, _ := Parse("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO")
+	r.Start = date(2024, 5, 6)
+	if got := r.Next(date(2024, 5, 6)); got.Day() != 20 {
+		t.Errorf("biweekly Next() = %s, want 2024-05-20", got.Format("2006-01-02"))
+	}
+}
+
+func TestCompleteAndRoll(t *testing.T) {
+	created := date(2024, 5, 1)
+	q := player.Quest{ID: "q", Type: player.TypeRitual, Recurrence: "FREQ=WEEKLY;BYDAY=MO", CreatedAt: created}
+
+	// Срок выставляется при первом Roll
+	quests := []player.Quest{q}
+	if !Roll(quests, date(2024, 5, 10)) {
+		t.Fatal("Roll() should set the first deadline")
+	}
+	q = quests[0]
+	if q.Deadline.Day() != 13 {
+		t.Fatalf("first deadline = %s, want 2024-05-13", q.Deadline.Format("2006-01-02"))
+	}
+
+	// Выполнение раньше срока закрывает экземпляр 13-го
+	Complete(&q, date(2024, 5, 11))
+	if q.Completed || q.Deadline.Day() != 20 || len(q.Completions) != 1 {
+		t.Fatalf("after Complete: completed=%v deadline=%s completions=%d", q.Completed, q.Deadline.Format("2006-01-02"), len(q.Completions))
+	}
+
+	// Пропущенный экземпляр заменяется, когда наступает следующий
+	quests = []player.Quest{q}
+	if Roll(quests, date(2024, 5, 22)) {
+		t.Error("Roll() on overdue instance should wait for the next occurrence")
+	}
+	if !Roll(quests, date(2024, 5, 27)) || quests[0].Deadline.Day() != 27 {
+		t.Errorf("Roll() deadline = %s, want 2024-05-27", quests[0].Deadline.Format("2006-01-02"))
+	}
+}
+
+func TestRollResetsMissedInstance(t *testing.T) {
+	due := endOfDay(date(2024, 5, 13))
+	q := player.Quest{
+		ID:         "q",
+		Type:       player.TypeRitual,
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO",
+		Deadline:   &due,
+		Progress:   1,
+		Checklist:  []player.ChecklistItem{{Text: "a", Done: true}, {Text: "b"}},
+	}
+
+	// Пропущенный экземпляр 13-го заменяется экземпляром 20-го с нуля
+	quests := []player.Quest{q}
+	if !Roll(quests, date(2024, 5, 20)) {
+		t.Fatal("Roll() should replace the missed instance")
+	}
+	q = quests[0]
+	if q.Deadline.Day() != 20 || q.Progress != 0 {
+		t.Errorf("after Roll: deadline %s, progress %d", q.Deadline.Format("2006-01-02"), q.Progress)
+	}
+	for _, item := range q.Checklist {
+		if item.Done {
+			t.Errorf("checklist item %q still done after Roll", item.Text)
+		}
+	}
+}
+
+func TestCompleteQuota(t *testing.T) {
+	q := player.Quest{ID: "q", Type: player.TypeRitual, Recurrence: "FREQ=WEEKLY;TIMES=2", CreatedAt: date(2024, 5, 1)}
+	quests := []player.Quest{q}
+	Roll(quests, date(2024, 5, 7))
+	q = quests[0]
+
+	Complete(&q, date(2024, 5, 7))
+	if done, times := PeriodProgress(q); done != 1 || times != 2 || q.Deadline.Day() != 12 {
+		t.Fatalf("after first completion: %d/%d, deadline %s", done, times, q.Deadline.Format("2006-01-02"))
+	}
+	Complete(&q, date(2024, 5, 8))
+	if done, _ := PeriodProgress(q); done != 0 || q.Deadline.Day() != 19 {
+		t.Errorf("after quota: done=%d, deadline %s, want 0 and 2024-05-19", done, q.Deadline.Format("2006-01-02"))
+	}
+}
+
+func TestCompleteNonRecurring(t *testing.T) {
+	q := player.Quest{ID: "q", Type: player.TypeFocus}
+	if Complete(&q, time.Now()) {
+		t.Error("Complete() should ignore quests without recurrence")
+	}
+}
@@ -0,0 +1,121 @@
+// Package ritual содержит правила ритуалов: перезарядку, журнал выполнений
+// и серии (streaks), которые растут, пока ритуал выполняется без пропусков.
+package ritual
+
+import (
+	"errors"
+	"magus/player"
+	"magus/recur"
+	"magus/utils"
+	"time"
+)
+
+const (
+	// BaseMana — мана за выполнение ритуала без серии.
+	BaseMana = 5
+	// StreakStep — каждые StreakStep выполнений подряд добавляют +1 маны.
+	StreakStep = 3
+	// MaxStreakBonus ограничивает надбавку за серию.
+	MaxStreakBonus = 5
+)
+
+var ErrCooldown = errors.New("ritual is on cooldown")
+
+// Reward — результат выполнения ритуала.
+type Reward struct {
+	Mana    int
+	Streak  int
+	NewBest bool // Серия побила прежний рекорд
+}
+
+// AvailableAt возвращает момент, когда ритуал снова можно выполнить.
+// По умолчанию ритуал выполняется раз в игровой день; CooldownHours
+// задает перезарядку в часах. Нулевое время означает «доступен сразу».
+func AvailableAt(q player.Quest, dayStartHour int) time.Time {
+	if q.CompletedAt.IsZero() {
+		return time.Time{}
+	}
+	if q.CooldownHours > 0 {
+		return q.CompletedAt.Add(time.Duration(q.CooldownHours) * time.Hour)
+	}
+	return utils.DayEnd(q.CompletedAt, dayStartHour)
+}
+
+// Ready сообщает, что перезарядка ритуала закончилась.
+func Ready(q player.Quest, now time.Time, dayStartHour int) bool {
+	return !now.Before(AvailableAt(q, dayStartHour))
+}
+
+// StreakBonus возвращает надбавку маны за серию длины streak.
+func StreakBonus(streak int) int {
+	bonus := streak / StreakStep
+	if bonus > MaxStreakBonus {
+		bonus = MaxStreakBonus
+	}
+	return bonus
+}
+
+// Complete выполняет ритуал: проверяет перезарядку, пишет выполнение
+// в журнал и продлевает серию. Серия обычного ритуала считается по дням,
+// ритуала с расписанием — по экземплярам (см. пакет recur).
+func Complete(q *player.Quest, now time.Time, dayStartHour int) (Reward, error) {
+	if !Ready(*q, now, dayStartHour) {
+		return Reward{}, ErrCooldown
+	}
+
+	if q.Recurrence != "" {
+		var due time.Time
+		if q.Deadline != nil {
+			due = *q.Deadline
+		}
+		recur.Complete(q, now)
+		// Серия растет, когда закрыт экземпляр (для TIMES — вся квота периода)
+		if q.Deadline != nil && !q.Deadline.Equal(due) {
+			q.Streak++
+		}
+	} else {
+		last := q.CompletedAt
+		today := utils.DayStart(now, dayStartHour)
+		switch {
+		case !last.IsZero() && !last.Before(today):
+			// Уже выполнялся сегодня (короткая перезарядка) — серия не меняется
+		case !last.IsZero() && !last.Before(today.AddDate(0, 0, -1)):
+			q.Streak++
+		default:
+			q.Streak = 1
+		}
+		q.Completions = append(q.Completions, now)
+		q.CompletedAt = now
+	}
+
+	reward := Reward{Mana: BaseMana + StreakBonus(q.Streak), Streak: q.Streak}
+	if q.Streak > q.BestStreak {
+		q.BestStreak = q.Streak
+		reward.NewBest = q.Streak > 1
+	}
+	return reward, nil
+}
+
+// CheckStreaks обнуляет серии ритуалов, которые прервались: обычный
+// ритуал пропустил целый игровой день, у ритуала с расписанием истек
+// срок невыполненного экземпляра. Вызывается при запуске до recur.Roll.
+// Возвращает ритуалы с прерванными сериями (с длиной серии до обнуления).
+func CheckStreaks(quests []player.Quest, now time.Time, dayStartHour int) []player.Quest {
+	var broken []player.Quest
+	yesterday := utils.DayStart(now, dayStartHour).AddDate(0, 0, -1)
+	for i := range quests {
+		q := &quests[i]
+		if q.Type != player.TypeRitual || q.Streak == 0 {
+			continue
+		}
+		missed := q.CompletedAt.Before(yesterday)
+		if q.Recurrence != "" {
+			missed = q.Deadline != nil && q.Deadline.Before(now)
+		}
+		if missed {
+			broken = append(broken, *q)
+			q.Streak = 0
+		}
+	}
+	return broken
+}
@@ -0,0 +1,93 @@
+package ritual
+
+import (
+	"errors"
+	"magus/player"
+	"testing"
+	"time"
+)
+
+func at(day, hour int) time.Time {
+	return time.Date(2024, 5, day, hour, 0, 0, 0, time.Local)
+}
+
+func TestCompleteCooldown(t *testing.T) {
+	q := player.Quest{ID: "r", Type: player.TypeRitual}
+
+	if _, err := Complete(&q, at(10, 9), 0); err != nil {
+		t.Fatalf("first Complete() error = %v", err)
+	}
+	if _, err := Complete(&q, at(10, 20), 0); !errors.Is(err, ErrCooldown) {
+		t.Errorf("second Complete() on the same day error = %v, want ErrCooldown", err)
+	}
+	// С границей дня в 4 утра 02:00 еще относится к 10-му числу
+	if _, err := Complete(&q, at(11, 2), 4); !errors.Is(err, ErrCooldown) {
+		t.Errorf("Complete() before day start error = %v, want ErrCooldown", err)
+	}
+	if _, err := Complete(&q, at(11, 9), 0); err != nil {
+		t.Errorf("Complete() next day error = %v", err)
+	}
+	if len(q.Completions) != 2 {
+		t.Errorf("len(Completions) = %d, want 2", len(q.Completions))
+	}
+
+	// Перезарядка в часах позволяет выполнять ритуал чаще
+	water := player.Quest{ID: "w", Type: player.TypeRitual, CooldownHours: 2}
+	Complete(&water, at(10, 9), 0)
+	if _, err := Complete(&water, at(10, 10), 0); !errors.Is(err, ErrCooldown) {
+		t.Errorf("Complete() within cooldown error = %v, want ErrCooldown", err)
+	}
+	if _, err := Complete(&water, at(10, 11), 0); err != nil {
+		t.Errorf("Complete() after cooldown error = %v", err)
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	q := player.Quest{ID: "r", Type: player.TypeRitual}
+
+	var reward Reward
+	for day := 1; day <= 6; day++ {
+		reward, _ = Complete(&q, at(day, 9), 0)
+	}
+	if reward.Streak != 6 || reward.Mana != BaseMana+2 {
+		t.Errorf("after 6 days: streak %d, mana %d; want 6 and %d", reward.Streak, reward.Mana, BaseMana+2)
+	}
+
+	// 7-е пропущено: при запуске 8-го серия прерывается
+	quests := []player.Quest{q}
+	broken := CheckStreaks(quests, at(8, 9), 0)
+	if len(broken) != 1 || broken[0].Streak != 6 || quests[0].Streak != 0 {
+		t.Fatalf("CheckStreaks() = %v, streak after = %d", broken, quests[0].Streak)
+	}
+	if quests[0].BestStreak != 6 {
+		t.Errorf("BestStreak = %d, want 6", quests[0].BestStreak)
+	}
+
+	reward, _ = Complete(&quests[0], at(8, 9), 0)
+	if reward.Streak != 1 || reward.NewBest {
+		t.Errorf("after break: streak %d, new best %v", reward.Streak, reward.NewBest)
+	}
+}
+
+func TestStreakBonusCapped(t *testing.T) {
+	if got := StreakBonus(1000); got != MaxStreakBonus {
+		t.Errorf("StreakBonus(1000) = %d, want %d", got, MaxStreakBonus)
+	}
+}
+
+func TestRecurringStreak(t *testing.T) {
+	q := player.Quest{ID: "r", Type: player.TypeRitual, Recurrence: "FREQ=WEEKLY;BYDAY=MO", CreatedAt: at(1, 9)}
+
+	// 2024-05-06 и 2024-05-13 — понедельники
+	Complete(&q, at(6, 9), 0)
+	reward, _ := Complete(&q, at(13, 9), 0)
+	if reward.Streak != 2 {
+		t.Fatalf("streak = %d, want 2", reward.Streak)
+	}
+
+	// Экземпляр 20-го не выполнен — серия прерывается
+	quests := []player.Quest{q}
+	if broken := CheckStreaks(quests, at(21, 9), 0); len(broken) != 1 {
+		t.Errorf("CheckStreaks() broken = %d, want 1", len(broken))
+	}
+}
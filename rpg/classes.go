@@ -1,25 +1,104 @@
 package rpg
 
-import "magus/player"
+import (
+	"encoding/json"
+	"fmt"
+	"magus/i18n"
+	"magus/player"
+	"os"
+)
+
+// ClassesFile — пассивные бонусы классов.
+var ClassesFile = "data/classes.json"
+
+// Passive — постоянный бонус класса. Effects использует те же ключи,
+// что и навыки дерева (см. пакет effects).
+type Passive struct {
+	ID           string                      `json:"id"`
+	Name         string                      `json:"name"`
+	Description  string                      `json:"description"`
+	Effects      map[string]float64          `json:"effects"`
+	Translations map[string]player.SkillText `json:"translations,omitempty"`
+}
 
 type Class struct {
-	Name        player.PlayerClass
-	Description string
+	ID          player.PlayerClass `json:"id"`
+	Name        string             `json:"-"`
+	Description string             `json:"-"`
+	Passives    []Passive          `json:"passives"`
+}
+
+// GetAvailableClasses возвращает классы с пассивными бонусами из
+// data/classes.json на текущем языке. Без файла у классов нет бонусов.
+func GetAvailableClasses() ([]Class, error) {
+	ids := []player.PlayerClass{player.ClassMage, player.ClassWarrior, player.ClassRogue}
+	passives, err := loadPassives()
+
+	classes := make([]Class, 0, len(ids))
+	for _, id := range ids {
+		classes = append(classes, Class{
+			ID:          id,
+			Name:        ClassName(id),
+			Description: i18n.T("class." + string(id) + ".desc"),
+			Passives:    passives[id],
+		})
+	}
+	return classes, err
+}
+
+// ClassPassives возвращает пассивные бонусы класса на текущем языке.
+func ClassPassives(c player.PlayerClass) ([]Passive, error) {
+	passives, err := loadPassives()
+	return passives[c], err
+}
+
+// loadPassives читает бонусы классов из ClassesFile.
+func loadPassives() (map[player.PlayerClass][]Passive, error) {
+	result := make(map[player.PlayerClass][]Passive)
+	file, err := os.ReadFile(ClassesFile)
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", i18n.T("rpg.err_read_classes"), err)
+	}
+
+	var classes []Class
+	if err := json.Unmarshal(file, &classes); err != nil {
+		return result, fmt.Errorf("%s: %w", i18n.T("rpg.err_parse_classes"), err)
+	}
+	for _, c := range classes {
+		id := player.NormalizeClass(c.ID)
+		for _, passive := range c.Passives {
+			result[id] = append(result[id], localizePassive(passive))
+		}
+	}
+	return result, nil
 }
 
-func GetAvailableClasses() []Class {
-	return []Class{
-		{
-			Name:        player.ClassMage,
-			Description: "+15% XP за квесты типа 'Arc' и 'Epic'.",
-		},
-		{
-			Name:        player.ClassWarrior,
-			Description: "+10% XP за выполнение всех 'Daily' квестов в течение дня.",
-		},
-		{
-			Name:        player.ClassRogue,
-			Description: "Получает на 1 очко навыков больше за каждый второй уровень.",
-		},
+// localizePassive подставляет перевод имени и описания бонуса для текущего языка.
+func localizePassive(p Passive) Passive {
+	text, ok := p.Translations[string(i18n.Current())]
+	if !ok {
+		return p
+	}
+	if text.Name != "" {
+		p.Name = text.Name
+	}
+	if text.Description != "" {
+		p.Description = text.Description
+	}
+	return p
+}
+
+// ClassName возвращает отображаемое имя класса на текущем языке.
+func ClassName(c player.PlayerClass) string {
+	if c == player.ClassNone {
+		return ""
+	}
+	key := "class." + string(c)
+	if !i18n.Has(key) {
+		return string(c)
 	}
+	return i18n.T(key)
 }
@@ -0,0 +1,185 @@
+package rpg
+
+import (
+	"errors"
+	"magus/player"
+	"sort"
+	"strings"
+
+	"github.com/dominikbraun/graph"
+)
+
+// SkillStatus — состояние навыка для конкретного игрока.
+type SkillStatus int
+
+const (
+	SkillLocked    SkillStatus = iota // Требования не выполнены
+	SkillNoPoints                     // Требования выполнены, но нет очков навыков
+	SkillAvailable                    // Можно изучить прямо сейчас
+	SkillUnlocked                     // Уже изучен
+)
+
+var (
+	ErrSkillNotFound      = errors.New("skill not found")
+	ErrSkillUnlocked      = errors.New("skill already unlocked")
+	ErrNoSkillPoints      = errors.New("not enough skill points")
+	ErrRequirementsNotMet = errors.New("skill requirements not met")
+)
+
+// Хеш-функция для player.SkillNode, необходимая для библиотеки graph.
+// Она позволяет графу уникально идентифицировать каждую вершину по ее ID.
+func skillNodeHash(s player.SkillNode) string {
+	return s.ID
+}
+
+// BuildSkillGraph строит направленный граф навыков: ребро ведет от требования
+// к зависящему от него навыку. Возвращает граф и ID навыков в порядке обхода
+// (топологическая сортировка, затем по позиции в дереве).
+func BuildSkillGraph(skillMap map[string]player.SkillNode) (graph.Graph[string, player.SkillNode], []string) {
+	g := graph.New(skillNodeHash, graph.Directed(), graph.PreventCycles())
+
+	for _, skill := range skillMap {
+		_ = g.AddVertex(skill)
+	}
+
+	for _, skill := range skillMap {
+		for _, reqID := range skill.Requirements {
+			if strings.HasPrefix(reqID, "level_") {
+				continue
+			}
+			if _, err := g.Vertex(reqID); err == nil {
+				_ = g.AddEdge(reqID, skill.ID)
+			}
+		}
+	}
+
+	sortedIDs, _ := graph.TopologicalSort(g)
+	sortByPosition(g, sortedIDs)
+	return g, sortedIDs
+}
+
+// SkillRoots возвращает навыки без требований-навыков, отсортированные по позиции.
+func SkillRoots(g graph.Graph[string, player.SkillNode]) []string {
+	var roots []string
+	adjMap, _ := g.AdjacencyMap()
+	for id := range adjMap {
+		// Узел является корневым, если на него никто не ссылается.
+		// Проверяем, есть ли он в качестве цели в каком-либо ребре.
+		isRoot := true
+		for _, edges := range adjMap {
+			if _, ok := edges[id]; ok {
+				isRoot = false
+				break
+			}
+		}
+		if isRoot {
+			roots = append(roots, id)
+		}
+	}
+	sortByPosition(g, roots)
+	return roots
+}
+
+// SkillChildren возвращает навыки, открывающиеся после skillID, отсортированные по позиции.
+func SkillChildren(g graph.Graph[string, player.SkillNode], skillID string) []string {
+	adjMap, _ := g.AdjacencyMap()
+	var children []string
+	for childID := range adjMap[skillID] {
+		children = append(children, childID)
+	}
+	sortByPosition(g, children)
+	return children
+}
+
+// SkillMap возвращает все вершины графа в виде карты.
+func SkillMap(g graph.Graph[string, player.SkillNode]) map[string]player.SkillNode {
+	skills := make(map[string]player.SkillNode)
+	adjMap, _ := g.AdjacencyMap()
+	for id := range adjMap {
+		v, _ := g.Vertex(id)
+		skills[id] = v
+	}
+	return skills
+}
+
+// RenderSkillTree рисует дерево навыков псевдографикой. label отвечает
+// за подпись каждого узла, чтобы CLI и TUI могли оформлять их по-своему.
+func RenderSkillTree(g graph.Graph[string, player.SkillNode], label func(node player.SkillNode) string) string {
+	var b strings.Builder
+	var draw func(skillID, prefix string, isLast bool)
+	draw = func(skillID, prefix string, isLast bool) {
+		node, _ := g.Vertex(skillID)
+		b.WriteString(prefix)
+		if isLast {
+			b.WriteString("└─ ")
+			prefix += "   "
+		} else {
+			b.WriteString("├─ ")
+			prefix += "│  "
+		}
+		b.WriteString(label(node))
+		b.WriteString("\n")
+
+		children := SkillChildren(g, skillID)
+		for i, childID := range children {
+			draw(childID, prefix, i == len(children)-1)
+		}
+	}
+
+	for _, rootID := range SkillRoots(g) {
+		draw(rootID, "", true)
+	}
+	return b.String()
+}
+
+// GetSkillStatus определяет состояние навыка для игрока.
+func GetSkillStatus(p *player.Player, node player.SkillNode, skillTree map[string]player.SkillNode) SkillStatus {
+	switch {
+	case IsSkillUnlocked(p, node.ID):
+		return SkillUnlocked
+	case !IsSkillAvailable(p, node, skillTree):
+		return SkillLocked
+	case p.SkillPoints <= 0:
+		return SkillNoPoints
+	default:
+		return SkillAvailable
+	}
+}
+
+// UnlockSkill изучает навык, тратя одно очко навыков. Игрок не сохраняется.
+func UnlockSkill(p *player.Player, node player.SkillNode, skillTree map[string]player.SkillNode) error {
+	switch GetSkillStatus(p, node, skillTree) {
+	case SkillUnlocked:
+		return ErrSkillUnlocked
+	case SkillLocked:
+		return ErrRequirementsNotMet
+	case SkillNoPoints:
+		return ErrNoSkillPoints
+	}
+	p.UnlockedSkills = append(p.UnlockedSkills, node.ID)
+	p.SkillPoints--
+	return nil
+}
+
+// AllSkills объединяет общие и классовые навыки в одну карту.
+func (t SkillTrees) AllSkills() map[string]player.SkillNode {
+	all := make(map[string]player.SkillNode, len(t.Common)+len(t.Class))
+	for id, node := range t.Common {
+		all[id] = node
+	}
+	for id, node := range t.Class {
+		all[id] = node
+	}
+	return all
+}
+
+func sortByPosition(g graph.Graph[string, player.SkillNode], ids []string) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		sA, _ := g.Vertex(ids[i])
+		sB, _ := g.Vertex(ids[j])
+		if sA.Position.Y != sB.Position.Y {
+			return sA.Position.Y < sB.Position.Y
+		}
+		return sA.Position.X < sB.Position.X
+	})
+}
@@ -0,0 +1,51 @@
+package rpg
+
+import (
+	"testing"
+
+	"magus/player"
+)
+
+func testTree() map[string]player.SkillNode {
+	return map[string]player.SkillNode{
+		"root":  {ID: "root", Position: player.Position{X: 0, Y: 0}},
+		"left":  {ID: "left", Requirements: []string{"root"}, Position: player.Position{X: 0, Y: 1}},
+		"right": {ID: "right", Requirements: []string{"root", "level_3"}, Position: player.Position{X: 1, Y: 1}},
+	}
+}
+
+func TestUnlockSkill(t *testing.T) {
+	tree := testTree()
+	p := &player.Player{Level: 1, SkillPoints: 1}
+
+	if err := UnlockSkill(p, tree["left"], tree); err != ErrRequirementsNotMet {
+		t.Fatalf("expected ErrRequirementsNotMet, got %v", err)
+	}
+	if err := UnlockSkill(p, tree["root"], tree); err != nil {
+		t.Fatalf("unlock root: %v", err)
+	}
+	if p.SkillPoints != 0 || !IsSkillUnlocked(p, "root") {
+		t.Fatalf("root should be unlocked and the point spent, got %+v", p)
+	}
+	if err := UnlockSkill(p, tree["root"], tree); err != ErrSkillUnlocked {
+		t.Errorf("expected ErrSkillUnlocked, got %v", err)
+	}
+	if err := UnlockSkill(p, tree["left"], tree); err != ErrNoSkillPoints {
+		t.Errorf("expected ErrNoSkillPoints, got %v", err)
+	}
+	if status := GetSkillStatus(p, tree["right"], tree); status != SkillLocked {
+		t.Errorf("right needs level 3, expected locked, got %v", status)
+	}
+}
+
+func TestRenderSkillTree(t *testing.T) {
+	g, ids := BuildSkillGraph(testTree())
+	if len(ids) != 3 || ids[0] != "root" {
+		t.Fatalf("unexpected order: %v", ids)
+	}
+	got := RenderSkillTree(g, func(node player.SkillNode) string { return node.ID })
+	want := "└─ root\n   ├─ left\n   └─ right\n"
+	if got != want {
+		t.Errorf("unexpected tree:\n%s\nwant:\n%s", got, want)
+	}
+}
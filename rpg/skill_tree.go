@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"magus/i18n"
 	"magus/player"
 	"strconv"
 	"strings"
@@ -24,15 +25,17 @@ func LoadSkillTrees(p *player.Player) (SkillTrees, error) {
 
 	file, err := ioutil.ReadFile("data/skill_tree.json")
 	if err != nil {
-		return trees, fmt.Errorf("не удалось прочитать файл data/skill_tree.json: %w", err)
+		return trees, fmt.Errorf("%s: %w", i18n.T("rpg.err_read_tree"), err)
 	}
 
 	var allNodes []player.SkillNode
 	if err := json.Unmarshal(file, &allNodes); err != nil {
-		return trees, fmt.Errorf("ошибка парсинга data/skill_tree.json: %w", err)
+		return trees, fmt.Errorf("%s: %w", i18n.T("rpg.err_parse_tree"), err)
 	}
 
 	for _, node := range allNodes {
+		node = localizeSkill(node)
+		node.ClassRequirement = string(player.NormalizeClass(player.PlayerClass(node.ClassRequirement)))
 		// Убираем поле ClassRequirement из JSON, если оно пустое, для обратной совместимости
 		if node.ClassRequirement == "" {
 			trees.Common[node.ID] = node
@@ -44,6 +47,21 @@ func LoadSkillTrees(p *player.Player) (SkillTrees, error) {
 	return trees, nil
 }
 
+// localizeSkill подставляет перевод имени и описания навыка для текущего языка.
+func localizeSkill(node player.SkillNode) player.SkillNode {
+	text, ok := node.Translations[string(i18n.Current())]
+	if !ok {
+		return node
+	}
+	if text.Name != "" {
+		node.Name = text.Name
+	}
+	if text.Description != "" {
+		node.Description = text.Description
+	}
+	return node
+}
+
 // IsSkillUnlocked проверяет, разблокирован ли у игрока данный навык.
 func IsSkillUnlocked(p *player.Player, skillID string) bool {
 	for _, unlockedSkill := range p.UnlockedSkills {
@@ -72,7 +90,7 @@ func IsSkillAvailable(p *player.Player, node player.SkillNode, skillTree map[str
 			levelStr := strings.TrimPrefix(reqID, "level_")
 			requiredLevel, err := strconv.Atoi(levelStr)
 			if err != nil {
-				fmt.Printf("Ошибка парсинга требования к уровню: %s\n", reqID)
+				fmt.Println(i18n.T("rpg.err_level_req", reqID))
 				return false
 			}
 			if p.Level < requiredLevel {
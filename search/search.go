@@ -0,0 +1,274 @@
+// Package search реализует полнотекстовый поиск по квестам и заметкам рефлексии.
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"magus/player"
+	"magus/storage"
+)
+
+// Kind определяет, на что указывает результат поиска.
+type Kind string
+
+const (
+	KindQuest      Kind = "quest"
+	KindReflection Kind = "reflection"
+)
+
+// Веса полей при ранжировании: совпадение в названии важнее, чем в тексте.
+const (
+	weightTitle   = 5.0
+	weightTag     = 3.0
+	weightText    = 1.0
+	phraseBonus   = 4.0
+	wordBonus     = 0.5 // Совпадение с началом слова ценнее, чем с серединой
+	snippetRadius = 30  // Символов контекста вокруг совпадения
+)
+
+// Span — диапазон подсветки в сниппете (в байтах, [Start, End)).
+type Span struct {
+	Start, End int
+}
+
+// Result — один найденный документ.
+type Result struct {
+	Kind            Kind
+	QuestID         string // Для KindQuest
+	Archived        bool   // Для KindQuest: квест лежит в архиве
+	ReflectionIndex int    // Для KindReflection: индекс записи в журнале
+	Title           string
+	Field           string // Поле, из которого взят сниппет: "title", "tags", "text"
+	Snippet         string
+	Highlights      []Span
+	Score           float64
+}
+
+type field struct {
+	name   string
+	text   string
+	weight float64
+}
+
+type document struct {
+	result Result
+	fields []field
+}
+
+// Search ищет все слова запроса в квестах, архиве и заметках и возвращает
+// результаты, отсортированные по убыванию релевантности. Документ попадает
+// в выдачу, только если в нем встречаются все слова запроса.
+func Search(query string, quests, archived []player.Quest, notes []storage.ReflectionNote) []Result {
+	terms := Tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+	phrase := strings.ToLower(strings.TrimSpace(query))
+
+	var results []Result
+	for _, doc := range documents(quests, archived, notes) {
+		if r, ok := score(doc, terms, phrase); ok {
+			results = append(results, r)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// Tokenize разбивает запрос на слова в нижнем регистре.
+func Tokenize(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func documents(quests, archived []player.Quest, notes []storage.ReflectionNote) []document {
+	docs := make([]document, 0, len(quests)+len(archived)+len(notes))
+	for _, q := range quests {
+		docs = append(docs, document{
+			result: Result{Kind: KindQuest, QuestID: q.ID, Title: q.Title},
+			fields: questFields(q),
+		})
+	}
+	for _, q := range archived {
+		docs = append(docs, document{
+			result: Result{Kind: KindQuest, QuestID: q.ID, Title: q.Title, Archived: true},
+			fields: questFields(q),
+		})
+	}
+	for i, n := range notes {
+		docs = append(docs, document{
+			result: Result{Kind: KindReflection, ReflectionIndex: i, Title: n.Date.Format("2006-01-02 15:04")},
+			fields: []field{{name: "text", text: n.Content, weight: weightText}},
+		})
+	}
+	return docs
+}
+
+// questFields перечисляет индексируемые поля квеста.
+func questFields(q player.Quest) []field {
+	fields := []field{
+		{name: "title", text: q.Title, weight: weightTitle},
+		{name: "tags", text: strings.Join(q.Tags, " "), weight: weightTag},
+		{name: "text", text: q.Description, weight: weightText},
+	}
+	for _, n := range q.Notes {
+		fields = append(fields, field{name: "text", text: n.Text, weight: weightText})
+	}
+	return fields
+}
+
+func score(doc document, terms []string, phrase string) (Result, bool) {
+	r := doc.result
+	best := -1.0
+	for _, term := range terms {
+		found := false
+		for _, f := range doc.fields {
+			lower := strings.ToLower(f.text)
+			for _, idx := range indexAll(lower, term) {
+				found = true
+				r.Score += f.weight
+				if isWordStart(lower, idx) {
+					r.Score += f.weight * wordBonus
+				}
+			}
+		}
+		if !found {
+			return r, false
+		}
+	}
+
+	// Сниппет берем из поля с наибольшим весом совпадений
+	for _, f := range doc.fields {
+		lower := strings.ToLower(f.text)
+		fieldScore := 0.0
+		for _, term := range terms {
+			fieldScore += float64(len(indexAll(lower, term))) * f.weight
+		}
+		if len(terms) > 1 && strings.Contains(lower, phrase) {
+			fieldScore += phraseBonus * f.weight
+			r.Score += phraseBonus * f.weight
+		}
+		if fieldScore > best {
+			best = fieldScore
+			r.Field = f.name
+			r.Snippet, r.Highlights = Snippet(f.text, terms)
+		}
+	}
+	return r, true
+}
+
+// Snippet вырезает фрагмент текста вокруг первого совпадения и возвращает
+// диапазоны всех совпадений внутри фрагмента.
+func Snippet(text string, terms []string) (string, []Span) {
+	text = strings.Join(strings.Fields(text), " ")
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// Редкие символы меняют длину в нижнем регистре; чтобы позиции
+		// совпадений не разъехались, показываем текст в нижнем регистре.
+		text = lower
+	}
+
+	first := -1
+	for _, term := range terms {
+		if idx := strings.Index(lower, term); idx >= 0 && (first < 0 || idx < first) {
+			first = idx
+		}
+	}
+	if first < 0 {
+		first = 0
+	}
+
+	start := first
+	for i := 0; i < snippetRadius && start > 0; i++ {
+		_, size := utf8.DecodeLastRuneInString(text[:start])
+		start -= size
+	}
+	end := first
+	for i := 0; i < snippetRadius*2 && end < len(text); i++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+
+	prefix, suffix := "", ""
+	if start > 0 {
+		prefix = "…"
+	}
+	if end < len(text) {
+		suffix = "…"
+	}
+	snippet := prefix + text[start:end] + suffix
+
+	var spans []Span
+	lowerSnippet := strings.ToLower(snippet)
+	for _, term := range terms {
+		for _, idx := range indexAll(lowerSnippet, term) {
+			spans = append(spans, Span{Start: idx, End: idx + len(term)})
+		}
+	}
+	return snippet, mergeSpans(spans)
+}
+
+// Highlight оборачивает подсвеченные диапазоны функцией mark.
+func Highlight(snippet string, spans []Span, mark func(string) string) string {
+	var b strings.Builder
+	pos := 0
+	for _, s := range spans {
+		if s.Start < pos || s.End > len(snippet) {
+			continue
+		}
+		b.WriteString(snippet[pos:s.Start])
+		b.WriteString(mark(snippet[s.Start:s.End]))
+		pos = s.End
+	}
+	b.WriteString(snippet[pos:])
+	return b.String()
+}
+
+// indexAll возвращает позиции всех непересекающихся вхождений sub в s.
+// strings.ToLower может менять длину отдельных символов, поэтому позиции
+// считаются по уже приведенной к нижнему регистру строке.
+func indexAll(s, sub string) []int {
+	var out []int
+	for offset := 0; ; {
+		idx := strings.Index(s[offset:], sub)
+		if idx < 0 {
+			return out
+		}
+		out = append(out, offset+idx)
+		offset += idx + len(sub)
+	}
+}
+
+func isWordStart(s string, idx int) bool {
+	if idx == 0 {
+		return true
+	}
+	r, _ := utf8.DecodeLastRuneInString(s[:idx])
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func mergeSpans(spans []Span) []Span {
+	if len(spans) == 0 {
+		return nil
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Start < spans[j].Start })
+	merged := []Span{spans[0]}
+	for _, s := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if s.Start <= last.End {
+			if s.End > last.End {
+				last.End = s.End
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"magus/player"
+	"magus/storage"
+)
+
+func TestSearchRanksTitleAboveText(t *testing.T) {
+	quests := []player.Quest{
+		{ID: "q1", Title: "Починить кран на кухне", Tags: []string{"дом"}},
+		{ID: "q2", Title: "Написать отчет", Tags: []string{"работа"}},
+	}
+	notes := []storage.ReflectionNote{
+		{Date: time.Now(), Content: "Весь вечер возился с краном, но так и не починил."},
+	}
+
+	results := Search("кран", quests, nil, notes)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d: %+v", len(results), results)
+	}
+	if results[0].Kind != KindQuest || results[0].QuestID != "q1" {
+		t.Errorf("expected quest q1 first, got %+v", results[0])
+	}
+	if results[1].Kind != KindReflection || results[1].ReflectionIndex != 0 {
+		t.Errorf("expected reflection second, got %+v", results[1])
+	}
+}
+
+func TestSearchRequiresAllTerms(t *testing.T) {
+	quests := []player.Quest{
+		{ID: "q1", Title: "Release backend", Tags: []string{"work"}},
+		{ID: "q2", Title: "Release notes"},
+	}
+	results := Search("release work", quests, nil, nil)
+	if len(results) != 1 || results[0].QuestID != "q1" {
+		t.Fatalf("expected only q1 to match, got %+v", results)
+	}
+}
+
+func TestSearchFindsArchivedQuests(t *testing.T) {
+	quests := []player.Quest{{ID: "q1", Title: "Отчет за май"}}
+	archived := []player.Quest{{ID: "q2", Title: "Отчет за апрель", Completed: true}}
+
+	results := Search("отчет", quests, archived, nil)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %+v", results)
+	}
+	for _, r := range results {
+		if r.Archived != (r.QuestID == "q2") {
+			t.Errorf("wrong archived flag for %s: %v", r.QuestID, r.Archived)
+		}
+	}
+}
+
+func TestSearchDescriptionAndNotes(t *testing.T) {
+	quests := []player.Quest{
+		{ID: "q1", Title: "Ремонт", Description: "Купить **шпатлевку** и валик"},
+		{ID: "q2", Title: "Переезд", Notes: []player.QuestNote{{Date: time.Now(), Text: "Заказать грузчиков"}}},
+	}
+	if r := Search("шпатлевку", quests, nil, nil); len(r) != 1 || r[0].QuestID != "q1" || r[0].Field != "text" {
+		t.Errorf("description search = %+v", r)
+	}
+	if r := Search("грузчиков", quests, nil, nil); len(r) != 1 || r[0].QuestID != "q2" {
+		t.Errorf("notes search = %+v", r)
+	}
+}
+
+func TestSnippetHighlights(t *testing.T) {
+	text := "Сегодня долго не мог сосредоточиться, потом помог таймер и тишина."
+	snippet, spans := Snippet(text, []string{"таймер"})
+	if len(spans) != 1 {
+		t.Fatalf("expected one highlight, got %v", spans)
+	}
+	if got := snippet[spans[0].Start:spans[0].End]; got != "таймер" {
+		t.Errorf("highlight points to %q", got)
+	}
+	marked := Highlight(snippet, spans, func(s string) string { return "[" + s + "]" })
+	if want := "[таймер]"; !strings.Contains(marked, want) {
+		t.Errorf("expected %q in %q", want, marked)
+	}
+}
@@ -0,0 +1,115 @@
+// Package snooze откладывает квесты. У отложенного квеста есть дата
+// начала: до нее он вместе с подзадачами не виден в списке квестов, при
+// подготовке к подземелью и в плане дня, а показывается только среди
+// предстоящих.
+package snooze
+
+import (
+	"errors"
+	"magus/player"
+	"magus/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrBadDuration = errors.New("bad snooze duration")
+
+// Deferred сообщает, что квест отложен: его дата начала еще не наступила.
+func Deferred(q player.Quest, now time.Time) bool {
+	return q.StartAt != nil && now.Before(*q.StartAt)
+}
+
+// Hidden возвращает ID квестов, скрытых на момент now: отложенных и
+// всех их подзадач.
+func Hidden(quests []player.Quest, now time.Time) map[string]bool {
+	index := make(map[string]player.Quest, len(quests))
+	for _, q := range quests {
+		index[q.ID] = q
+	}
+
+	hidden := make(map[string]bool)
+	for _, q := range quests {
+		for cur, ok := q, true; ok; cur, ok = index[cur.ParentID] {
+			if Deferred(cur, now) {
+				hidden[q.ID] = true
+				break
+			}
+		}
+	}
+	return hidden
+}
+
+// Active возвращает квесты, доступные на момент now, в исходном порядке.
+func Active(quests []player.Quest, now time.Time) []player.Quest {
+	hidden := Hidden(quests, now)
+	if len(hidden) == 0 {
+		return quests
+	}
+	var active []player.Quest
+	for _, q := range quests {
+		if !hidden[q.ID] {
+			active = append(active, q)
+		}
+	}
+	return active
+}
+
+// Upcoming возвращает невыполненные отложенные квесты, упорядоченные по
+// дате начала. Подзадачи отложенного квеста отдельно не перечисляются.
+func Upcoming(quests []player.Quest, now time.Time) []player.Quest {
+	var upcoming []player.Quest
+	for _, q := range quests {
+		if !q.Completed && Deferred(q, now) {
+			upcoming = append(upcoming, q)
+		}
+	}
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].StartAt.Before(*upcoming[j].StartAt)
+	})
+	return upcoming
+}
+
+// Parse разбирает срок откладывания относительно now: "4h" — через
+// 4 часа, "3d" и "2w" — с начала игрового дня через 3 дня или 2 недели,
+// "ГГГГ-ММ-ДД" — с начала указанного дня.
+func Parse(s string, now time.Time, dayStartHour int) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if date, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return date.Add(time.Duration(dayStartHour) * time.Hour), nil
+	}
+	if len(s) < 2 {
+		return time.Time{}, ErrBadDuration
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return time.Time{}, ErrBadDuration
+	}
+
+	today := utils.DayStart(now, dayStartHour)
+	switch s[len(s)-1] {
+	case 'h':
+		return now.Add(time.Duration(n) * time.Hour), nil
+	case 'd':
+		return today.AddDate(0, 0, n), nil
+	case 'w':
+		return today.AddDate(0, 0, 7*n), nil
+	}
+	return time.Time{}, ErrBadDuration
+}
+
+// Until откладывает квест до момента t. Момент в прошлом снимает
+// откладывание.
+func Until(q *player.Quest, t time.Time, now time.Time) {
+	if !t.After(now) {
+		Wake(q)
+		return
+	}
+	q.StartAt = &t
+}
+
+// Wake делает отложенный квест доступным сразу.
+func Wake(q *player.Quest) {
+	q.StartAt = nil
+}
@@ -0,0 +1,82 @@
+package snooze
+
+import (
+	"magus/player"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2024, 3, 10, 2, 30, 0, 0, time.UTC) // До начала игрового дня в 4:00
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"4h", now.Add(4 * time.Hour)},
+		{"1d", time.Date(2024, 3, 10, 4, 0, 0, 0, time.UTC)},
+		{"3D", time.Date(2024, 3, 12, 4, 0, 0, 0, time.UTC)},
+		{"2w", time.Date(2024, 3, 23, 4, 0, 0, 0, time.UTC)},
+		{"2024-04-01", time.Date(2024, 4, 1, 4, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in, now, 4)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "d", "0d", "-1d", "3x", "завтра"} {
+		if _, err := Parse(bad, now, 4); err != ErrBadDuration {
+			t.Errorf("Parse(%q) err = %v, want ErrBadDuration", bad, err)
+		}
+	}
+}
+
+func TestHiddenAndUpcoming(t *testing.T) {
+	now := time.Now()
+	later := now.Add(48 * time.Hour)
+	soon := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+	quests := []player.Quest{
+		{ID: "goal", StartAt: &later},
+		{ID: "child", ParentID: "goal"},
+		{ID: "soon", StartAt: &soon},
+		{ID: "woken", StartAt: &past},
+		{ID: "open"},
+	}
+
+	active := Active(quests, now)
+	if len(active) != 2 || active[0].ID != "woken" || active[1].ID != "open" {
+		t.Errorf("Active() = %+v, want woken and open", active)
+	}
+	if hidden := Hidden(quests, now); !hidden["child"] {
+		t.Error("subquest of a deferred goal is visible")
+	}
+
+	upcoming := Upcoming(quests, now)
+	if len(upcoming) != 2 || upcoming[0].ID != "soon" || upcoming[1].ID != "goal" {
+		t.Errorf("Upcoming() = %+v, want soon then goal", upcoming)
+	}
+}
+
+func TestUntilAndWake(t *testing.T) {
+	now := time.Now()
+	var q player.Quest
+	Until(&q, now.Add(time.Hour), now)
+	if !Deferred(q, now) {
+		t.Fatal("quest not deferred")
+	}
+	Until(&q, now.Add(-time.Hour), now)
+	if q.StartAt != nil {
+		t.Error("past start date kept")
+	}
+	Until(&q, now.Add(time.Hour), now)
+	Wake(&q)
+	if Deferred(q, now) {
+		t.Error("woken quest still deferred")
+	}
+}
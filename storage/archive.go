@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"magus/player"
+	"os"
+	"time"
+)
+
+var ArchiveFile = "data/archive.json"
+
+// ErrNotArchived возвращается, если квеста нет в архиве.
+var ErrNotArchived = errors.New("quest is not in the archive")
+
+// LoadArchive загружает архив выполненных квестов.
+func LoadArchive() ([]player.Quest, error) {
+	data, err := os.ReadFile(ArchiveFile)
+	if os.IsNotExist(err) {
+		return []player.Quest{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var quests []player.Quest
+	err = json.Unmarshal(data, &quests)
+	return quests, err
+}
+
+// SaveArchive сохраняет архив целиком.
+func SaveArchive(quests []player.Quest) error {
+	data, err := json.MarshalIndent(quests, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat("data"); os.IsNotExist(err) {
+		os.Mkdir("data", 0755)
+	}
+	return os.WriteFile(ArchiveFile, data, 0644)
+}
+
+// SplitArchivable отделяет квесты, которые пора убрать в архив: выполненные
+// до cutoff верхнеуровневые квесты вместе со всеми подзадачами, если
+// подзадачи тоже выполнены. Подзадачи невыполненной цели остаются на месте.
+func SplitArchivable(quests []player.Quest, cutoff time.Time) (active, archived []player.Quest) {
+	children := make(map[string][]string)
+	for _, q := range quests {
+		if q.ParentID != "" {
+			children[q.ParentID] = append(children[q.ParentID], q.ID)
+		}
+	}
+	index := make(map[string]player.Quest, len(quests))
+	for _, q := range quests {
+		index[q.ID] = q
+	}
+
+	// done сообщает, что квест и все его подзадачи выполнены
+	var done func(id string) bool
+	done = func(id string) bool {
+		if !index[id].Completed {
+			return false
+		}
+		for _, child := range children[id] {
+			if !done(child) {
+				return false
+			}
+		}
+		return true
+	}
+
+	move := make(map[string]bool)
+	var mark func(id string)
+	mark = func(id string) {
+		move[id] = true
+		for _, child := range children[id] {
+			mark(child)
+		}
+	}
+	for _, q := range quests {
+		if _, hasParent := index[q.ParentID]; hasParent {
+			continue
+		}
+		if q.Completed && q.CompletedAt.Before(cutoff) && done(q.ID) {
+			mark(q.ID)
+		}
+	}
+
+	for _, q := range quests {
+		if move[q.ID] {
+			archived = append(archived, q)
+		} else {
+			active = append(active, q)
+		}
+	}
+	return active, archived
+}
+
+// ArchiveQuests дописывает квесты в архив. Квест с тем же ID заменяется.
+func ArchiveQuests(quests []player.Quest) error {
+	if len(quests) == 0 {
+		return nil
+	}
+	archive, err := LoadArchive()
+	if err != nil {
+		return err
+	}
+
+	replaced := make(map[string]bool)
+	for _, q := range quests {
+		replaced[q.ID] = true
+	}
+	kept := archive[:0]
+	for _, q := range archive {
+		if !replaced[q.ID] {
+			kept = append(kept, q)
+		}
+	}
+	return SaveArchive(append(kept, quests...))
+}
+
+// AutoArchive убирает в архив квесты, выполненные раньше cutoff, и
+// возвращает оставшиеся квесты и число перенесенных. Список квестов
+// вызывающий сохраняет сам.
+func AutoArchive(quests []player.Quest, cutoff time.Time) ([]player.Quest, int, error) {
+	active, archived := SplitArchivable(quests, cutoff)
+	if len(archived) == 0 {
+		return quests, 0, nil
+	}
+	if err := ArchiveQuests(archived); err != nil {
+		return quests, 0, err
+	}
+	return active, len(archived), nil
+}
+
+// Unarchive достает квест из архива вместе с его подзадачами и целями
+// над ним и возвращает восстановленные квесты и остаток архива. Сам квест
+// и его предки снова открываются, иначе они вернулись бы в архив при
+// следующем запуске, и помечаются как уже награжденные (Rewarded), чтобы
+// повторное выполнение не принесло опыт еще раз. Квесты стоит сохранить
+// раньше архива, чтобы при ошибке ничего не потерять.
+func Unarchive(archive []player.Quest, questID string) (restored, rest []player.Quest, err error) {
+	index := make(map[string]player.Quest, len(archive))
+	children := make(map[string][]string)
+	for _, q := range archive {
+		index[q.ID] = q
+		if q.ParentID != "" {
+			children[q.ParentID] = append(children[q.ParentID], q.ID)
+		}
+	}
+	if _, ok := index[questID]; !ok {
+		return nil, archive, ErrNotArchived
+	}
+
+	restore := make(map[string]bool)
+	reopen := make(map[string]bool)
+	for id := questID; id != ""; id = index[id].ParentID {
+		if _, ok := index[id]; !ok {
+			break
+		}
+		restore[id] = true
+		reopen[id] = true
+	}
+	var mark func(id string)
+	mark = func(id string) {
+		restore[id] = true
+		for _, child := range children[id] {
+			mark(child)
+		}
+	}
+	mark(questID)
+
+	rest = []player.Quest{}
+	for _, q := range archive {
+		if !restore[q.ID] {
+			rest = append(rest, q)
+			continue
+		}
+		if reopen[q.ID] {
+			q.Completed = false
+			q.CompletedAt = time.Time{}
+			q.Progress = 0
+			q.Rewarded = true
+		}
+		restored = append(restored, q)
+	}
+	return restored, rest, nil
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"magus/player"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSplitArchivable(t *testing.T) {
+	now := time.Now()
+	old := now.AddDate(0, 0, -30)
+	quests := []player.Quest{
+		{ID: "goal", Completed: true, CompletedAt: old},
+		{ID: "child", ParentID: "goal", Completed: true, CompletedAt: old},
+		{ID: "recent", Completed: true, CompletedAt: now},
+		{ID: "open"},
+		{ID: "parent"},
+		{ID: "done_child", ParentID: "parent", Completed: true, CompletedAt: old},
+	}
+
+	active, archived := SplitArchivable(quests, now.AddDate(0, 0, -7))
+	if len(archived) != 2 || archived[0].ID != "goal" || archived[1].ID != "child" {
+		t.Fatalf("archived = %+v, want goal and child", archived)
+	}
+	if len(active) != 4 {
+		t.Errorf("active = %d quests, want 4", len(active))
+	}
+}
+
+func TestArchiveAndRestore(t *testing.T) {
+	originalPath := ArchiveFile
+	ArchiveFile = filepath.Join(t.TempDir(), "archive.json")
+	defer func() { ArchiveFile = originalPath }()
+
+	old := time.Now().AddDate(0, 0, -30)
+	quests := []player.Quest{
+		{ID: "goal", Type: player.TypeGoal, Completed: true, CompletedAt: old},
+		{ID: "child", ParentID: "goal", Completed: true, CompletedAt: old},
+		{ID: "open"},
+	}
+	active, moved, err := AutoArchive(quests, time.Now())
+	if err != nil || moved != 2 || len(active) != 1 {
+		t.Fatalf("AutoArchive = %d active, %d moved, %v", len(active), moved, err)
+	}
+
+	archive, err := LoadArchive()
+	if err != nil || len(archive) != 2 {
+		t.Fatalf("LoadArchive = %d quests, %v", len(archive), err)
+	}
+
+	// Подзадача возвращается вместе с целью, обе снова открыты и уже награждены
+	restored, rest, err := Unarchive(archive, "child")
+	if err != nil {
+		t.Fatalf("Unarchive: %v", err)
+	}
+	if len(restored) != 2 || len(rest) != 0 {
+		t.Fatalf("restored %d, left %d; want 2 and 0", len(restored), len(rest))
+	}
+	for _, q := range restored {
+		if q.Completed || !q.Rewarded {
+			t.Errorf("quest %s after restore: completed %v, rewarded %v", q.ID, q.Completed, q.Rewarded)
+		}
+	}
+
+	if _, _, err := Unarchive(rest, "goal"); err != ErrNotArchived {
+		t.Errorf("Unarchive of missing quest: %v, want ErrNotArchived", err)
+	}
+}
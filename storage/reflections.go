@@ -15,10 +15,10 @@ type ReflectionNote struct {
 	HPLoss   int           `json:"hp_loss"`
 }
 
-const reflectionsFile = "data/reflections.json"
+var ReflectionsFile = "data/reflections.json"
 
 func SaveReflection(note ReflectionNote) error {
-	notes, err := loadReflections()
+	notes, err := LoadReflections()
 	if err != nil {
 		notes = []ReflectionNote{} // Если файл не существует или пуст, создаем новый срез
 	}
@@ -30,15 +30,16 @@ func SaveReflection(note ReflectionNote) error {
 		return err
 	}
 
-	return ioutil.WriteFile(reflectionsFile, data, 0644)
+	return ioutil.WriteFile(ReflectionsFile, data, 0644)
 }
 
-func loadReflections() ([]ReflectionNote, error) {
-	if _, err := os.Stat(reflectionsFile); os.IsNotExist(err) {
+// LoadReflections загружает все заметки рефлексии в порядке их добавления.
+func LoadReflections() ([]ReflectionNote, error) {
+	if _, err := os.Stat(ReflectionsFile); os.IsNotExist(err) {
 		return []ReflectionNote{}, nil
 	}
 
-	data, err := ioutil.ReadFile(reflectionsFile)
+	data, err := ioutil.ReadFile(ReflectionsFile)
 	if err != nil {
 		return nil, err
 	}
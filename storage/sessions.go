@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// QuestTime — минуты фокус-сессии, отданные одному квесту.
+type QuestTime struct {
+	QuestID string `json:"quest_id"`
+	Minutes int    `json:"minutes"`
+}
+
+// Session — запись о завершенной фокус-сессии.
+type Session struct {
+	Date     time.Time     `json:"date"`
+	Duration time.Duration `json:"duration"`
+	Success  bool          `json:"success"`
+	Quests   []QuestTime   `json:"quests,omitempty"`
+}
+
+var SessionsFile = "data/sessions.json"
+
+// SaveSession добавляет сессию в журнал сессий.
+func SaveSession(session Session) error {
+	sessions, err := LoadSessions()
+	if err != nil {
+		sessions = []Session{}
+	}
+	sessions = append(sessions, session)
+
+	data, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat("data"); os.IsNotExist(err) {
+		os.Mkdir("data", 0755)
+	}
+	return os.WriteFile(SessionsFile, data, 0644)
+}
+
+// LoadSessions загружает журнал сессий в порядке добавления.
+func LoadSessions() ([]Session, error) {
+	data, err := os.ReadFile(SessionsFile)
+	if os.IsNotExist(err) {
+		return []Session{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []Session
+	err = json.Unmarshal(data, &sessions)
+	return sessions, err
+}
+
+// QuestSessions возвращает сессии, в которых было время для квеста questID,
+// и сколько минут квест получил в каждой из них.
+func QuestSessions(sessions []Session, questID string) ([]Session, []int) {
+	var found []Session
+	var minutes []int
+	for _, s := range sessions {
+		for _, qt := range s.Quests {
+			if qt.QuestID == questID {
+				found = append(found, s)
+				minutes = append(minutes, qt.Minutes)
+			}
+		}
+	}
+	return found, minutes
+}
@@ -0,0 +1,140 @@
+package tags
+
+import (
+	"errors"
+	"magus/player"
+	"sort"
+	"strings"
+)
+
+var ErrIntoItself = errors.New("tag cannot be moved into itself")
+
+// Rename переименовывает тег old вместе с вложенными тегами во всех
+// квестах: "work" → "job" превращает "work/backend" в "job/backend".
+// Если новое имя уже занято, теги сливаются и повторы убираются.
+// Возвращает число измененных квестов.
+func Rename(quests []player.Quest, old, new string) (int, error) {
+	new = Normalize(new)
+	if new == "" || new == old {
+		return 0, nil
+	}
+	if Within(new, old) {
+		return 0, ErrIntoItself
+	}
+
+	changed := 0
+	for i := range quests {
+		q := &quests[i]
+		touched := false
+		var renamed []string
+		for _, tag := range q.Tags {
+			if Within(tag, old) {
+				tag = new + tag[len(old):]
+				touched = true
+			}
+			renamed = append(renamed, tag)
+		}
+		if touched {
+			q.Tags = dedupe(renamed)
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// Delete убирает тег и вложенные в него теги из всех квестов.
+// Возвращает число измененных квестов.
+func Delete(quests []player.Quest, tag string) int {
+	changed := 0
+	for i := range quests {
+		q := &quests[i]
+		var kept []string
+		for _, t := range q.Tags {
+			if !Within(t, tag) {
+				kept = append(kept, t)
+			}
+		}
+		if len(kept) != len(q.Tags) {
+			q.Tags = kept
+			changed++
+		}
+	}
+	return changed
+}
+
+// dedupe убирает повторы, сохраняя порядок.
+func dedupe(list []string) []string {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, s := range list {
+		if !seen[s] {
+			seen[s] = true
+			unique = append(unique, s)
+		}
+	}
+	return unique
+}
+
+// Usage — статистика использования тега.
+type Usage struct {
+	Tag       string
+	Open      int // Невыполненные квесты с тегом или вложенными тегами
+	Completed int // Выполненные квесты
+	XP        int // XP за выполненные квесты
+}
+
+// Total возвращает число квестов с тегом.
+func (u Usage) Total() int {
+	return u.Open + u.Completed
+}
+
+// Collect считает статистику по всем тегам квестов. Родительские теги
+// попадают в список, даже если ими не отмечен ни один квест, и учитывают
+// квесты вложенных тегов, каждый по одному разу. Вложенные теги идут
+// сразу за родителем.
+func Collect(quests []player.Quest) []Usage {
+	byTag := make(map[string]*Usage)
+	for _, q := range quests {
+		counted := make(map[string]bool)
+		for _, tag := range q.Tags {
+			for _, t := range Lineage(tag) {
+				if counted[t] {
+					continue
+				}
+				counted[t] = true
+				u := byTag[t]
+				if u == nil {
+					u = &Usage{Tag: t}
+					byTag[t] = u
+				}
+				if q.Completed {
+					u.Completed++
+					u.XP += q.XP
+				} else {
+					u.Open++
+				}
+			}
+		}
+	}
+
+	usage := make([]Usage, 0, len(byTag))
+	for _, u := range byTag {
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		return treeLess(usage[i].Tag, usage[j].Tag)
+	})
+	return usage
+}
+
+// treeLess сравнивает теги по уровням, чтобы вложенные теги не
+// отрывались от родителя ("a/b" раньше "a-b").
+func treeLess(a, b string) bool {
+	pa, pb := strings.Split(a, Sep), strings.Split(b, Sep)
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		if pa[i] != pb[i] {
+			return pa[i] < pb[i]
+		}
+	}
+	return len(pa) < len(pb)
+}
@@ -0,0 +1,199 @@
+// Package tags — иерархические теги квестов и их настройки.
+//
+// Тег может быть вложенным: "work/backend" входит в "work". Настройки
+// тегов — цвет, множитель XP и тренируемая характеристика — хранятся в
+// data/tags.json и наследуются: у "work/backend" без своего цвета будет
+// цвет "work".
+package tags
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var File = "data/tags.json"
+
+// Sep разделяет уровни вложенного тега.
+const Sep = "/"
+
+// Info — настройки тега.
+type Info struct {
+	Color        string  `json:"color,omitempty"`         // Цвет lipgloss: "#E67E22" или номер ANSI
+	XPMultiplier float64 `json:"xp_multiplier,omitempty"` // Множитель XP за квесты с тегом
+	Stat         string  `json:"stat,omitempty"`          // Характеристика игрока, которую тренируют квесты с тегом
+}
+
+// Registry — настройки тегов по имени тега.
+type Registry map[string]Info
+
+// Load загружает настройки тегов. Если файла нет, настроек просто нет.
+func Load() (Registry, error) {
+	r := Registry{}
+	data, err := os.ReadFile(File)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return r, err
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Registry{}, err
+	}
+	return r, nil
+}
+
+// Save сохраняет настройки тегов в файл.
+func Save(r Registry) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(File), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(File, data, 0644)
+}
+
+// Normalize приводит тег к каноническому виду: без "#", пробелов по краям
+// и пустых уровней (" #work//backend/ " → "work/backend").
+func Normalize(tag string) string {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+	var parts []string
+	for _, part := range strings.Split(tag, Sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, Sep)
+}
+
+// Parse разбирает теги, перечисленные через запятую, без пустых и повторов.
+func Parse(s string) []string {
+	var list []string
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(s, ",") {
+		if tag = Normalize(tag); tag != "" && !seen[tag] {
+			seen[tag] = true
+			list = append(list, tag)
+		}
+	}
+	return list
+}
+
+// Parent возвращает родительский тег или "" для тега верхнего уровня.
+func Parent(tag string) string {
+	if i := strings.LastIndex(tag, Sep); i >= 0 {
+		return tag[:i]
+	}
+	return ""
+}
+
+// Depth возвращает уровень вложенности: 0 для тега верхнего уровня.
+func Depth(tag string) int {
+	return strings.Count(tag, Sep)
+}
+
+// Lineage возвращает тег вместе с предками, начиная с верхнего уровня:
+// "a/b/c" → "a", "a/b", "a/b/c".
+func Lineage(tag string) []string {
+	var lineage []string
+	for i, r := range tag {
+		if string(r) == Sep {
+			lineage = append(lineage, tag[:i])
+		}
+	}
+	return append(lineage, tag)
+}
+
+// Within сообщает, что тег tag совпадает с root или вложен в него.
+func Within(tag, root string) bool {
+	return tag == root || strings.HasPrefix(tag, root+Sep)
+}
+
+// Lookup возвращает настройки тега с наследованием: незаданные поля
+// берутся у ближайшего предка.
+func (r Registry) Lookup(tag string) Info {
+	var info Info
+	lineage := Lineage(tag)
+	for i := len(lineage) - 1; i >= 0; i-- {
+		own := r[lineage[i]]
+		if info.Color == "" {
+			info.Color = own.Color
+		}
+		if info.XPMultiplier == 0 {
+			info.XPMultiplier = own.XPMultiplier
+		}
+		if info.Stat == "" {
+			info.Stat = own.Stat
+		}
+	}
+	return info
+}
+
+// Multiplier возвращает множитель XP для квеста с тегами questTags.
+// Множители не складываются: действует наибольший из заданных, а без
+// них — 1.
+func (r Registry) Multiplier(questTags []string) float64 {
+	best := 0.0
+	for _, tag := range questTags {
+		best = max(best, r.Lookup(tag).XPMultiplier)
+	}
+	if best <= 0 {
+		return 1
+	}
+	return best
+}
+
+// XP возвращает награду xp с учетом множителей тегов.
+func (r Registry) XP(questTags []string, xp int) int {
+	return int(math.Round(float64(xp) * r.Multiplier(questTags)))
+}
+
+// Affinities возвращает характеристики, которые тренируют теги квеста,
+// без повторов и в порядке тегов.
+func (r Registry) Affinities(questTags []string) []string {
+	var stats []string
+	seen := make(map[string]bool)
+	for _, tag := range questTags {
+		if stat := r.Lookup(tag).Stat; stat != "" && !seen[stat] {
+			seen[stat] = true
+			stats = append(stats, stat)
+		}
+	}
+	return stats
+}
+
+// Rename переносит настройки тега old и вложенных в него тегов под имя
+// new. Если у нового тега уже есть настройки, они остаются. Возвращает
+// true, если настройки изменились.
+func (r Registry) Rename(old, new string) bool {
+	moved := make(Registry)
+	for tag, info := range r {
+		if Within(tag, old) {
+			moved[new+tag[len(old):]] = info
+			delete(r, tag)
+		}
+	}
+	for tag, info := range moved {
+		if _, ok := r[tag]; !ok {
+			r[tag] = info
+		}
+	}
+	return len(moved) > 0
+}
+
+// Delete удаляет настройки тега и вложенных в него тегов. Возвращает
+// true, если настройки изменились.
+func (r Registry) Delete(tag string) bool {
+	changed := false
+	for t := range r {
+		if Within(t, tag) {
+			delete(r, t)
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,124 @@
+package tags
+
+import (
+	"magus/player"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseAndLineage(t *testing.T) {
+	got := Parse(" #work//backend/ , sport,, work/backend")
+	if want := []string{"work/backend", "sport"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %v, want %v", got, want)
+	}
+	if got, want := Lineage("a/b/c"), []string{"a", "a/b", "a/b/c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Lineage() = %v, want %v", got, want)
+	}
+	if Parent("a/b") != "a" || Parent("a") != "" || Depth("a/b/c") != 2 {
+		t.Error("Parent/Depth broken")
+	}
+	if !Within("work/backend", "work") || Within("workshop", "work") {
+		t.Error("Within() matches by string prefix instead of tag levels")
+	}
+}
+
+func TestLookupInheritsFromParent(t *testing.T) {
+	r := Registry{
+		"sport":         {Color: "#2ECC71", XPMultiplier: 1.5, Stat: "strength"},
+		"sport/running": {Stat: "endurance"},
+		"chores":        {XPMultiplier: 0.5},
+	}
+
+	info := r.Lookup("sport/running")
+	if info.Color != "#2ECC71" || info.XPMultiplier != 1.5 || info.Stat != "endurance" {
+		t.Errorf("Lookup() = %+v", info)
+	}
+
+	// Множители не складываются: действует наибольший
+	if got := r.XP([]string{"sport/running", "chores"}, 10); got != 15 {
+		t.Errorf("XP() = %d, want 15", got)
+	}
+	if got := r.XP([]string{"chores"}, 15); got != 8 {
+		t.Errorf("XP() for chores = %d, want 8", got)
+	}
+	if got := r.XP([]string{"reading"}, 10); got != 10 {
+		t.Errorf("XP() without settings = %d, want 10", got)
+	}
+
+	got := r.Affinities([]string{"sport", "sport/running", "sport/gym"})
+	if want := []string{"strength", "endurance"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Affinities() = %v, want %v", got, want)
+	}
+}
+
+func TestRenameMergesSubtree(t *testing.T) {
+	quests := []player.Quest{
+		{ID: "1", Tags: []string{"work/backend", "job"}},
+		{ID: "2", Tags: []string{"workshop"}},
+		{ID: "3", Tags: []string{"work"}},
+	}
+	n, err := Rename(quests, "work", "job")
+	if err != nil || n != 2 {
+		t.Fatalf("Rename() = %d, %v", n, err)
+	}
+	if want := []string{"job/backend", "job"}; !reflect.DeepEqual(quests[0].Tags, want) {
+		t.Errorf("quest 1 tags = %v, want %v", quests[0].Tags, want)
+	}
+	if quests[1].Tags[0] != "workshop" || quests[2].Tags[0] != "job" {
+		t.Errorf("tags after rename: %v, %v", quests[1].Tags, quests[2].Tags)
+	}
+
+	if _, err := Rename(quests, "job", "job/old"); err != ErrIntoItself {
+		t.Errorf("err = %v, want ErrIntoItself", err)
+	}
+
+	if n := Delete(quests, "job"); n != 2 || len(quests[0].Tags) != 0 {
+		t.Errorf("Delete() = %d, tags %v", n, quests[0].Tags)
+	}
+}
+
+func TestRegistryRename(t *testing.T) {
+	r := Registry{"work": {Color: "1"}, "work/backend": {Color: "2"}, "job": {Color: "3"}}
+	r.Rename("work", "job")
+	if len(r) != 2 || r["job"].Color != "3" || r["job/backend"].Color != "2" {
+		t.Errorf("registry after rename = %v", r)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	quests := []player.Quest{
+		{Tags: []string{"work/backend", "work"}, Completed: true, XP: 30},
+		{Tags: []string{"work/frontend"}},
+		{Tags: []string{"work-log"}},
+	}
+	usage := Collect(quests)
+
+	var order []string
+	for _, u := range usage {
+		order = append(order, u.Tag)
+	}
+	if want := []string{"work", "work/backend", "work/frontend", "work-log"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	if work := usage[0]; work.Completed != 1 || work.Open != 1 || work.XP != 30 || work.Total() != 2 {
+		t.Errorf("work usage = %+v", work)
+	}
+}
+
+func TestLoadAndSave(t *testing.T) {
+	File = filepath.Join(t.TempDir(), "tags.json")
+
+	r, err := Load()
+	if err != nil || len(r) != 0 {
+		t.Fatalf("Load() without file = %v, %v", r, err)
+	}
+	r["sport"] = Info{Stat: "strength"}
+	if err := Save(r); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load()
+	if err != nil || loaded["sport"].Stat != "strength" {
+		t.Errorf("Load() = %v, %v", loaded, err)
+	}
+}
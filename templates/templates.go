@@ -0,0 +1,232 @@
+// Package templates создает целые деревья квестов по шаблонам.
+//
+// Шаблон — JSON-файл в каталоге data/templates (имя файла без .json —
+// имя шаблона). Каждый узел описывает квест и его подзадачи; в текстах
+// можно использовать плейсхолдеры {{name}}, {{date}}, {{week}} и {{month}},
+// а сроки задаются относительно дня создания. Переводы текстов задаются
+// полем translations, как у навыков: шаблон загружается на текущем языке.
+package templates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"magus/deadline"
+	"magus/i18n"
+	"magus/player"
+	"magus/recur"
+	"magus/utils"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+var Dir = "data/templates"
+
+var ErrNotFound = errors.New("template not found")
+
+// Node — квест в шаблоне вместе с подзадачами.
+type Node struct {
+	Title         string            `json:"title"`
+	Type          player.QuestType  `json:"type"`
+	RitualSubtype player.RitualType `json:"ritual_subtype,omitempty"`
+	Priority      player.Priority   `json:"priority,omitempty"`
+	HP            int               `json:"hp,omitempty"`
+	XP            int               `json:"xp,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
+	DueInDays     int               `json:"due_in_days,omitempty"` // Срок: через сколько дней после создания
+	Recurrence    string            `json:"recurrence,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	Checklist     []string          `json:"checklist,omitempty"`
+	Children      []Node            `json:"children,omitempty"`
+	Translations  map[string]Text   `json:"translations,omitempty"`
+}
+
+// Text — переведенные тексты узла или описание шаблона. Пустые поля
+// остаются на языке оригинала.
+type Text struct {
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Checklist   []string `json:"checklist,omitempty"`
+}
+
+// Template — именованный шаблон.
+type Template struct {
+	Name         string          `json:"-"`
+	Description  string          `json:"description,omitempty"`
+	Quest        Node            `json:"quest"`
+	Translations map[string]Text `json:"translations,omitempty"`
+}
+
+// Load загружает шаблон по имени.
+func Load(name string) (Template, error) {
+	data, err := os.ReadFile(filepath.Join(Dir, name+".json"))
+	if os.IsNotExist(err) {
+		return Template{}, ErrNotFound
+	}
+	if err != nil {
+		return Template{}, err
+	}
+
+	var t Template
+	if err := json.Unmarshal(data, &t); err != nil {
+		return Template{}, fmt.Errorf("%s: %w", name, err)
+	}
+	t.Name = name
+	return t.Localize(string(i18n.Current())), nil
+}
+
+// Localize возвращает шаблон с текстами на языке lang.
+func (t Template) Localize(lang string) Template {
+	if text, ok := t.Translations[lang]; ok && text.Description != "" {
+		t.Description = text.Description
+	}
+	t.Quest = t.Quest.localize(lang)
+	return t
+}
+
+// localize подставляет перевод текстов узла и его подзадач.
+func (n Node) localize(lang string) Node {
+	if text, ok := n.Translations[lang]; ok {
+		if text.Title != "" {
+			n.Title = text.Title
+		}
+		if text.Description != "" {
+			n.Description = text.Description
+		}
+		if len(text.Tags) > 0 {
+			n.Tags = text.Tags
+		}
+		if len(text.Checklist) > 0 {
+			n.Checklist = text.Checklist
+		}
+	}
+	if len(n.Children) > 0 {
+		children := make([]Node, len(n.Children))
+		for i, child := range n.Children {
+			children[i] = child.localize(lang)
+		}
+		n.Children = children
+	}
+	return n
+}
+
+// List загружает все шаблоны, отсортированные по имени. Если каталога
+// нет, шаблонов просто нет.
+func List() ([]Template, error) {
+	files, err := filepath.Glob(filepath.Join(Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var list []Template
+	for _, f := range files {
+		t, err := Load(strings.TrimSuffix(filepath.Base(f), ".json"))
+		if err != nil {
+			return list, err
+		}
+		list = append(list, t)
+	}
+	return list, nil
+}
+
+// Vars возвращает значения плейсхолдеров для момента now.
+func Vars(name string, now time.Time) map[string]string {
+	year, week := now.ISOWeek()
+	return map[string]string{
+		"name":  name,
+		"date":  now.Format("2006-01-02"),
+		"week":  fmt.Sprintf("%d-W%02d", year, week),
+		"month": now.Format("2006-01"),
+	}
+}
+
+// Expand подставляет значения плейсхолдеров вида {{key}}.
+// Неизвестные плейсхолдеры остаются как есть.
+func Expand(s string, vars map[string]string) string {
+	for k, v := range vars {
+		s = strings.ReplaceAll(s, "{{"+k+"}}", v)
+	}
+	return s
+}
+
+// Instantiate создает квесты по шаблону: корень (подзадача parentID, если
+// он задан) и все его подзадачи с новыми ID. Корень идет первым.
+func Instantiate(t Template, parentID string, vars map[string]string, now time.Time) ([]player.Quest, error) {
+	var quests []player.Quest
+	var add func(n Node, parentID string) error
+	add = func(n Node, parentID string) error {
+		created := now // Просрочка учитывается с момента создания
+		q := player.Quest{
+			ID:            utils.GenerateID(),
+			ParentID:      parentID,
+			Title:         Expand(n.Title, vars),
+			Priority:      n.Priority,
+			Type:          n.Type,
+			RitualSubtype: n.RitualSubtype,
+			HP:            n.HP,
+			XP:            n.XP,
+			Description:   Expand(n.Description, vars),
+			CreatedAt:     created,
+			PenaltyAt:     &created,
+		}
+		if q.Type == "" {
+			q.Type = player.TypeFocus
+		}
+		if strings.TrimSpace(q.Title) == "" {
+			return fmt.Errorf("%s: quest without title", t.Name)
+		}
+		for _, tag := range n.Tags {
+			q.Tags = append(q.Tags, Expand(tag, vars))
+		}
+		for _, item := range n.Checklist {
+			q.Checklist = append(q.Checklist, player.ChecklistItem{Text: Expand(item, vars)})
+		}
+		if n.DueInDays > 0 {
+			// Срок без времени, как при вводе ГГГГ-ММ-ДД
+			due := deadline.EndOfDay(now.AddDate(0, 0, n.DueInDays))
+			q.Deadline = &due
+		}
+		if n.Recurrence != "" {
+			rule, err := recur.Parse(n.Recurrence)
+			if err != nil {
+				return fmt.Errorf("%s: %q: %w", t.Name, n.Title, err)
+			}
+			q.Recurrence = rule.String()
+			if q.Deadline == nil {
+				due := rule.First(now)
+				q.Deadline = &due
+			}
+		}
+
+		quests = append(quests, q)
+		for _, child := range n.Children {
+			if err := add(child, q.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := add(t.Quest, parentID); err != nil {
+		return nil, err
+	}
+	return quests, nil
+}
+
+// Count возвращает число квестов, которые создаст шаблон.
+func (t Template) Count() int {
+	var count func(n Node) int
+	count = func(n Node) int {
+		total := 1
+		for _, child := range n.Children {
+			total += count(child)
+		}
+		return total
+	}
+	return count(t.Quest)
+}
@@ -0,0 +1,139 @@
+package templates
+
+import (
+	"errors"
+	"magus/i18n"
+	"magus/player"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+)
+
+func TestInstantiate(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+	tmpl := Template{Name: "release", Quest: Node{
+		Title: "Релиз {{name}}",
+		Type:  "goal",
+		Children: []Node{
+			{Title: "Проверить {{name}}", HP: 30, DueInDays: 3, Checklist: []string{"Тесты {{date}}"}},
+			{Title: "Опубликовать", Children: []Node{{Title: "Анонс"}}},
+		},
+	}}
+
+	quests, err := Instantiate(tmpl, "parent", Vars("v1.2", now), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quests) != tmpl.Count() || len(quests) != 4 {
+		t.Fatalf("got %d quests, want 4", len(quests))
+	}
+
+	root := quests[0]
+	if root.Title != "Релиз v1.2" || root.ParentID != "parent" {
+		t.Errorf("root = %q (parent %q)", root.Title, root.ParentID)
+	}
+	check := quests[1]
+	if check.ParentID != root.ID || check.Title != "Проверить v1.2" {
+		t.Errorf("child = %q (parent %q), want child of root", check.Title, check.ParentID)
+	}
+	if check.Type != "focus" {
+		t.Errorf("default type = %q, want focus", check.Type)
+	}
+	if want := time.Date(2024, 3, 13, 23, 59, 59, 0, time.UTC); check.Deadline == nil || !check.Deadline.Equal(want) {
+		t.Errorf("deadline = %v, want %v", check.Deadline, want)
+	}
+	if len(check.Checklist) != 1 || check.Checklist[0].Text != "Тесты 2024-03-10" {
+		t.Errorf("checklist = %+v", check.Checklist)
+	}
+	if quests[3].ParentID != quests[2].ID {
+		t.Errorf("grandchild parent = %q, want %q", quests[3].ParentID, quests[2].ID)
+	}
+
+	// Каждое применение шаблона дает новые ID
+	again, _ := Instantiate(tmpl, "", Vars("v1.3", now), now)
+	if again[0].ID == root.ID {
+		t.Error("template reused quest IDs")
+	}
+}
+
+func TestInstantiateInvalid(t *testing.T) {
+	now := time.Now()
+	if _, err := Instantiate(Template{Quest: Node{Title: "{{name}}"}}, "", Vars("", now), now); err == nil {
+		t.Error("quest with empty title accepted")
+	}
+	bad := Template{Quest: Node{Title: "x", Recurrence: "FREQ=NEVER"}}
+	if _, err := Instantiate(bad, "", Vars("", now), now); err == nil {
+		t.Error("invalid recurrence accepted")
+	}
+}
+
+func TestLoadAndList(t *testing.T) {
+	originalDir := Dir
+	Dir = t.TempDir()
+	defer func() { Dir = originalDir }()
+
+	if list, err := List(); err != nil || len(list) != 0 {
+		t.Fatalf("List() on empty dir = %v, %v", list, err)
+	}
+	data := `{"description": "Обзор", "quest": {"title": "Обзор {{week}}", "children": [{"title": "Входящие"}]}}`
+	if err := os.WriteFile(filepath.Join(Dir, "weekly.json"), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := Load("weekly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Name != "weekly" || tmpl.Description != "Обзор" || len(tmpl.Quest.Children) != 1 {
+		t.Errorf("Load() = %+v", tmpl)
+	}
+	if _, err := Load("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Load(missing) err = %v, want ErrNotFound", err)
+	}
+	if list, _ := List(); len(list) != 1 || list[0].Name != "weekly" {
+		t.Errorf("List() = %+v", list)
+	}
+}
+
+// TestShippedTemplates проверяет шаблоны из data/templates: у каждого
+// есть английский перевод, а подзадачи целей можно завершить (ритуалы
+// никогда не завершаются и не дали бы закрыть цель).
+func TestShippedTemplates(t *testing.T) {
+	originalDir := Dir
+	Dir = filepath.Join("..", "data", "templates")
+	defer func() { Dir = originalDir }()
+	defer i18n.SetLang(i18n.Current())
+
+	var walk func(n Node, parent player.QuestType, visit func(n Node, parent player.QuestType))
+	walk = func(n Node, parent player.QuestType, visit func(n Node, parent player.QuestType)) {
+		visit(n, parent)
+		for _, child := range n.Children {
+			walk(child, n.Type, visit)
+		}
+	}
+
+	i18n.SetLang(i18n.EN)
+	list, err := List()
+	if err != nil || len(list) == 0 {
+		t.Fatalf("List() = %v, %v", list, err)
+	}
+	for _, tmpl := range list {
+		texts := []string{tmpl.Description}
+		walk(tmpl.Quest, "", func(n Node, parent player.QuestType) {
+			if parent == player.TypeGoal && n.Type == player.TypeRitual {
+				t.Errorf("%s: ritual %q under a goal", tmpl.Name, n.Title)
+			}
+			texts = append(texts, n.Title, n.Description)
+			texts = append(texts, n.Tags...)
+			texts = append(texts, n.Checklist...)
+		})
+		for _, text := range texts {
+			if strings.ContainsFunc(text, func(r rune) bool { return unicode.Is(unicode.Cyrillic, r) }) {
+				t.Errorf("%s: untranslated text %q", tmpl.Name, text)
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Package tree — иерархия квестов по ParentID: поддеревья, перенос
+// квеста под другого родителя без циклов и ручной порядок соседей.
+package tree
+
+import (
+	"errors"
+	"magus/player"
+	"math"
+	"slices"
+)
+
+var (
+	ErrQuestNotFound = errors.New("quest not found")
+	ErrCycle         = errors.New("quest can't be moved under itself or its subquest")
+	ErrNotSiblings   = errors.New("quests have different parents")
+	ErrTopLevel      = errors.New("quest is already at the top level")
+	ErrParentDone    = errors.New("open quest can't be moved under a completed quest")
+)
+
+// Subtree возвращает ID квестов ids вместе со всеми их подзадачами.
+func Subtree(quests []player.Quest, ids ...string) map[string]bool {
+	children := make(map[string][]string)
+	for _, q := range quests {
+		if q.ParentID != "" {
+			children[q.ParentID] = append(children[q.ParentID], q.ID)
+		}
+	}
+
+	result := make(map[string]bool)
+	var walk func(id string)
+	walk = func(id string) {
+		if result[id] {
+			return
+		}
+		result[id] = true
+		for _, child := range children[id] {
+			walk(child)
+		}
+	}
+	for _, id := range ids {
+		walk(id)
+	}
+	return result
+}
+
+// Roots возвращает те из ids, которые не лежат в поддереве другого квеста
+// из ids, в порядке списка квестов. Перенос или удаление корней переносит
+// или удаляет и остальные.
+func Roots(quests []player.Quest, ids map[string]bool) []string {
+	parents := make(map[string]string, len(quests))
+	for _, q := range quests {
+		parents[q.ID] = q.ParentID
+	}
+
+	var roots []string
+	for _, q := range quests {
+		if !ids[q.ID] {
+			continue
+		}
+		nested := false
+		for p := parents[q.ID]; p != "" && !nested; p = parents[p] {
+			nested = ids[p]
+		}
+		if !nested {
+			roots = append(roots, q.ID)
+		}
+	}
+	return roots
+}
+
+// Move переносит квест под родителя parentID ("" — на верхний уровень)
+// и ставит его последним среди новых соседей. Квест нельзя перенести под
+// себя или свою подзадачу, а невыполненный квест (или квест с
+// невыполненными подзадачами) — под выполненный.
+func Move(quests []player.Quest, id, parentID string) error {
+	return moveAfter(quests, id, parentID, "")
+}
+
+// Outdent выносит квест на уровень выше: он становится соседом своего
+// родителя и встает сразу после него.
+func Outdent(quests []player.Quest, id string) error {
+	i := indexOf(quests, id)
+	if i < 0 {
+		return ErrQuestNotFound
+	}
+	parentID := quests[i].ParentID
+	if parentID == "" {
+		return ErrTopLevel
+	}
+	p := indexOf(quests, parentID)
+	if p < 0 {
+		return ErrQuestNotFound
+	}
+	return moveAfter(quests, id, quests[p].ParentID, parentID)
+}
+
+// moveAfter переносит квест под parentID и ставит его сразу после соседа
+// afterID ("" — в конец).
+func moveAfter(quests []player.Quest, id, parentID, afterID string) error {
+	i := indexOf(quests, id)
+	p := indexOf(quests, parentID)
+	if i < 0 || (parentID != "" && p < 0) {
+		return ErrQuestNotFound
+	}
+	if parentID != "" {
+		moved := Subtree(quests, id)
+		if moved[parentID] {
+			return ErrCycle
+		}
+		if quests[p].Completed && HasOpen(quests, moved) {
+			return ErrParentDone
+		}
+	}
+	quests[i].ParentID = parentID
+
+	var order []int
+	for _, j := range siblings(quests, parentID) {
+		if j == i {
+			continue
+		}
+		order = append(order, j)
+		if quests[j].ID == afterID {
+			order = append(order, i)
+		}
+	}
+	if !slices.Contains(order, i) {
+		order = append(order, i)
+	}
+	renumber(quests, order)
+	return nil
+}
+
+// HasOpen сообщает, что среди квестов ids есть невыполненные.
+func HasOpen(quests []player.Quest, ids map[string]bool) bool {
+	for _, q := range quests {
+		if ids[q.ID] && !q.Completed {
+			return true
+		}
+	}
+	return false
+}
+
+// Swap меняет местами двух соседей по родителю в ручном порядке.
+func Swap(quests []player.Quest, id, otherID string) error {
+	i, j := indexOf(quests, id), indexOf(quests, otherID)
+	if i < 0 || j < 0 {
+		return ErrQuestNotFound
+	}
+	if quests[i].ParentID != quests[j].ParentID {
+		return ErrNotSiblings
+	}
+	order := siblings(quests, quests[i].ParentID)
+	a, b := slices.Index(order, i), slices.Index(order, j)
+	order[a], order[b] = order[b], order[a]
+	renumber(quests, order)
+	return nil
+}
+
+// Sort возвращает копию квестов, в которой соседи по родителю идут в ручном
+// порядке. Квесты без порядка (новые) идут после упорядоченных в порядке
+// файла.
+func Sort(quests []player.Quest) []player.Quest {
+	sorted := slices.Clone(quests)
+	slices.SortStableFunc(sorted, func(a, b player.Quest) int {
+		return orderKey(a) - orderKey(b)
+	})
+	return sorted
+}
+
+// siblings возвращает индексы квестов с родителем parentID в ручном порядке.
+func siblings(quests []player.Quest, parentID string) []int {
+	var result []int
+	for i, q := range quests {
+		if q.ParentID == parentID {
+			result = append(result, i)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b int) int {
+		return orderKey(quests[a]) - orderKey(quests[b])
+	})
+	return result
+}
+
+// renumber записывает порядок 1..n квестам с индексами order.
+func renumber(quests []player.Quest, order []int) {
+	for n, i := range order {
+		quests[i].Order = n + 1
+	}
+}
+
+func orderKey(q player.Quest) int {
+	if q.Order <= 0 {
+		return math.MaxInt32
+	}
+	return q.Order
+}
+
+func indexOf(quests []player.Quest, id string) int {
+	return slices.IndexFunc(quests, func(q player.Quest) bool { return q.ID == id })
+}
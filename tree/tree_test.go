@@ -0,0 +1,106 @@
+package tree
+
+import (
+	"magus/player"
+	"reflect"
+	"testing"
+)
+
+func sample() []player.Quest {
+	return []player.Quest{
+		{ID: "goal"},
+		{ID: "a", ParentID: "goal"},
+		{ID: "a1", ParentID: "a"},
+		{ID: "b", ParentID: "goal"},
+		{ID: "other"},
+	}
+}
+
+func TestSubtreeAndRoots(t *testing.T) {
+	quests := sample()
+
+	got := Subtree(quests, "a", "other")
+	if len(got) != 3 || !got["a1"] || got["goal"] {
+		t.Errorf("Subtree() = %v", got)
+	}
+
+	roots := Roots(quests, map[string]bool{"a": true, "a1": true, "b": true})
+	if want := []string{"a", "b"}; !reflect.DeepEqual(roots, want) {
+		t.Errorf("Roots() = %v, want %v", roots, want)
+	}
+}
+
+func TestMove(t *testing.T) {
+	quests := sample()
+
+	if err := Move(quests, "a", "other"); err != nil || quests[1].ParentID != "other" {
+		t.Fatalf("Move(a, other) = %v, parent %q", err, quests[1].ParentID)
+	}
+	if err := Move(quests, "a", ""); err != nil || quests[1].ParentID != "" {
+		t.Fatalf("Move(a, top) = %v, parent %q", err, quests[1].ParentID)
+	}
+	if err := Move(quests, "goal", "b"); err != ErrCycle {
+		t.Errorf("Move(goal, b) = %v, want ErrCycle", err)
+	}
+	if err := Move(quests, "a", "a"); err != ErrCycle {
+		t.Errorf("Move(a, a) = %v, want ErrCycle", err)
+	}
+	if err := Move(quests, "a", "missing"); err != ErrQuestNotFound {
+		t.Errorf("Move(a, missing) = %v, want ErrQuestNotFound", err)
+	}
+
+	// Под выполненный квест переносятся только выполненные ветки
+	quests[4].Completed = true
+	if err := Move(quests, "a", "other"); err != ErrParentDone {
+		t.Errorf("Move(a, done) = %v, want ErrParentDone", err)
+	}
+	quests[1].Completed, quests[2].Completed = true, true
+	if err := Move(quests, "a", "other"); err != nil {
+		t.Errorf("Move(done a, done) = %v", err)
+	}
+}
+
+// children возвращает ID подзадач parentID в порядке Sort.
+func children(quests []player.Quest, parentID string) []string {
+	var result []string
+	for _, q := range Sort(quests) {
+		if q.ParentID == parentID {
+			result = append(result, q.ID)
+		}
+	}
+	return result
+}
+
+func TestManualOrder(t *testing.T) {
+	quests := sample()
+
+	if err := Swap(quests, "a", "b"); err != nil {
+		t.Fatalf("Swap(a, b) = %v", err)
+	}
+	if got, want := children(quests, "goal"), []string{"b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Sort() after swap = %v, want %v", got, want)
+	}
+	if err := Swap(quests, "a", "other"); err != ErrNotSiblings {
+		t.Errorf("Swap(a, other) = %v, want ErrNotSiblings", err)
+	}
+
+	// Вынесенный квест встает сразу после бывшего родителя
+	if err := Outdent(quests, "a1"); err != nil {
+		t.Fatalf("Outdent(a1) = %v", err)
+	}
+	if got, want := children(quests, "goal"), []string{"b", "a", "a1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Sort() after outdent = %v, want %v", got, want)
+	}
+	if quests[2].ParentID != "goal" {
+		t.Errorf("a1 parent = %q, want goal", quests[2].ParentID)
+	}
+	if err := Outdent(quests, "b"); err != nil {
+		t.Fatalf("Outdent(b) = %v", err)
+	}
+	if got, want := children(quests, ""), []string{"goal", "b", "other"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Sort() after second outdent = %v, want %v", got, want)
+	}
+	if err := Outdent(quests, "b"); err != ErrTopLevel {
+		t.Errorf("Outdent(top level) = %v, want ErrTopLevel", err)
+	}
+}
@@ -4,12 +4,18 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"magus/deadline"
+	"magus/i18n"
 	"magus/player"
+	"magus/recur"
 	"magus/storage"
+	"magus/tags"
+	"magus/templates"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -17,12 +23,16 @@ import (
 
 type AddQuestState struct {
 	inputs         []textinput.Model
+	description    textarea.Model
 	focusIdx       int
 	typeIdx        int
 	subtypeIdx     int
 	questTypes     []player.QuestType
 	ritualSubtypes []player.RitualType
 	parentId       string // To pre-fill if adding a sub-quest
+	errMsg         string
+	templates      []templates.Template
+	templateIdx    int // 0 — без шаблона, иначе templates[templateIdx-1]
 }
 
 const (
@@ -33,36 +43,88 @@ const (
 	fieldXP
 	fieldTags
 	fieldDeadline
+	fieldRecurrence
+	fieldDescription
+	fieldLinks
+	fieldTemplate
 	fieldButton
 )
 
-// numFields - теперь это не константа, а функция, зависящая от типа квеста
-func (s *AddQuestState) numFields() int {
+// fieldInputs сопоставляет текстовые поля формы с индексами в s.inputs.
+var fieldInputs = map[int]int{
+	fieldTitle:      0,
+	fieldHP:         1,
+	fieldXP:         2,
+	fieldTags:       3,
+	fieldDeadline:   4,
+	fieldRecurrence: 5,
+	fieldLinks:      6,
+}
+
+// fields возвращает поля формы в порядке обхода; набор зависит от типа
+// квеста. Выбор шаблона есть, только если шаблоны найдены; с шаблоном
+// название подставляется в {{name}}, а остальное берется из шаблона.
+func (s *AddQuestState) fields() []int {
+	fields := []int{fieldTitle}
+	if len(s.templates) > 0 {
+		fields = append(fields, fieldTemplate)
+		if s.templateIdx > 0 {
+			return append(fields, fieldButton)
+		}
+	}
 	switch s.questTypes[s.typeIdx] {
-	case player.TypeGoal:
-		return 3 // Title, Type, Button
 	case player.TypeRitual:
-		return 4 // Title, Type, Subtype, Button
+		return append(fields, fieldType, fieldRitualSubtype, fieldRecurrence, fieldDescription, fieldLinks, fieldButton)
 	case player.TypeFocus:
-		return 7 // Title, Type, HP, XP, Tags, Deadline, Button
+		return append(fields, fieldType, fieldHP, fieldXP, fieldTags, fieldDeadline, fieldRecurrence, fieldDescription, fieldLinks, fieldButton)
 	default:
-		return 3
+		return append(fields, fieldType, fieldDescription, fieldLinks, fieldButton)
+	}
+}
+
+// template возвращает выбранный шаблон.
+func (s *AddQuestState) template() (templates.Template, bool) {
+	if s.templateIdx == 0 {
+		return templates.Template{}, false
+	}
+	return s.templates[s.templateIdx-1], true
+}
+
+// focusField ставит фокус на поле field.
+func (s *AddQuestState) focusField(field int) {
+	for i, f := range s.fields() {
+		if f == field {
+			s.focusIdx = i
+			return
+		}
 	}
 }
 
+// numFields - теперь это не константа, а функция, зависящая от типа квеста
+func (s *AddQuestState) numFields() int {
+	return len(s.fields())
+}
+
+// current возвращает поле, на котором стоит фокус.
+func (s *AddQuestState) current() int {
+	return s.fields()[s.focusIdx]
+}
+
 func NewAddQuestState(m *Model, parentId ...string) State {
-	inputs := make([]textinput.Model, 5) // Title, HP, XP, Tags, Deadline
+	inputs := make([]textinput.Model, 7) // Title, HP, XP, Tags, Deadline, Recurrence, Links
 	for i := range inputs {
 		inputs[i] = textinput.New()
 		inputs[i].Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
 		inputs[i].CharLimit = 120
 	}
 
-	inputs[0].Placeholder = "Название квеста"
+	inputs[0].Placeholder = i18n.T("tui.add.placeholder_title")
 	inputs[1].Placeholder = "100" // HP
 	inputs[2].Placeholder = "50"  // XP
-	inputs[3].Placeholder = "работа,дом"
-	inputs[4].Placeholder = "ГГГГ-ММ-ДД"
+	inputs[3].Placeholder = i18n.T("tui.add.placeholder_tags")
+	inputs[4].Placeholder = i18n.T("tui.add.placeholder_deadline")
+	inputs[5].Placeholder = i18n.T("tui.add.placeholder_recurrence")
+	inputs[6].Placeholder = i18n.T("tui.add.placeholder_links")
 	inputs[0].Focus()
 
 	pid := ""
@@ -70,8 +132,12 @@ func NewAddQuestState(m *Model, parentId ...string) State {
 		pid = parentId[0]
 	}
 
+	list, _ := templates.List()
+
 	return &AddQuestState{
+		templates:      list,
 		inputs:         inputs,
+		description:    newDescriptionArea(i18n.T("tui.add.placeholder_description")),
 		questTypes:     []player.QuestType{player.TypeFocus, player.TypeRitual, player.TypeGoal},
 		ritualSubtypes: []player.RitualType{player.RitualRestoration, player.RitualMaintenance},
 		parentId:       pid,
@@ -83,12 +149,34 @@ func (s *AddQuestState) Init() tea.Cmd {
 }
 
 func (s *AddQuestState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
+	// В описании enter переносит строку, а стрелки двигают курсор:
+	// поле покидают только по tab/shift+tab, форма сохраняется по ctrl+s
+	if key, ok := msg.(tea.KeyMsg); ok && s.current() == fieldDescription {
+		switch key.String() {
+		case "esc":
+			return PopState{}, nil
+		case "ctrl+s":
+			return s.saveQuest(m)
+		case "tab":
+			s.focusIdx = (s.focusIdx + 1) % s.numFields()
+			return s.syncFocus()
+		case "shift+tab":
+			s.focusIdx--
+			return s.syncFocus()
+		}
+		var cmd tea.Cmd
+		s.description, cmd = s.description.Update(msg)
+		return s, cmd
+	}
+
 	if key, ok := msg.(tea.KeyMsg); ok {
 		switch key.String() {
+		case "ctrl+s":
+			return s.saveQuest(m)
 		case "q", "esc":
 			return PopState{}, nil
 		case "tab", "down", "enter":
-			if key.String() == "enter" && s.focusIdx == s.numFields()-1 {
+			if key.String() == "enter" && s.current() == fieldButton {
 				return s.saveQuest(m)
 			}
 			s.focusIdx = (s.focusIdx + 1) % s.numFields()
@@ -100,7 +188,7 @@ func (s *AddQuestState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 			}
 			return s.syncFocus()
 		case "left", "right":
-			if s.focusIdx == fieldType {
+			if s.current() == fieldType {
 				if key.String() == "left" {
 					s.typeIdx--
 					if s.typeIdx < 0 {
@@ -110,10 +198,20 @@ func (s *AddQuestState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 					s.typeIdx = (s.typeIdx + 1) % len(s.questTypes)
 				}
 				// Сбрасываем фокус, чтобы пересчитать количество полей
-				s.focusIdx = fieldType
+				s.focusField(fieldType)
 				return s.syncFocus()
 			}
-			if s.focusIdx == fieldRitualSubtype {
+			if s.current() == fieldTemplate {
+				n := len(s.templates) + 1
+				if key.String() == "left" {
+					s.templateIdx = (s.templateIdx + n - 1) % n
+				} else {
+					s.templateIdx = (s.templateIdx + 1) % n
+				}
+				s.focusField(fieldTemplate)
+				return s.syncFocus()
+			}
+			if s.current() == fieldRitualSubtype {
 				if key.String() == "left" {
 					s.subtypeIdx--
 					if s.subtypeIdx < 0 {
@@ -136,38 +234,82 @@ func (s *AddQuestState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 
 func (s *AddQuestState) View(m *Model) string {
 	var b strings.Builder
-	b.WriteString("📝 Новый квест\n\n")
+	b.WriteString(i18n.T("tui.add.title") + "\n\n")
 
 	currentType := s.questTypes[s.typeIdx]
 
+	// С шаблоном поле названия задает {{name}}, а вместо полей — дерево квестов
+	if tmpl, ok := s.template(); ok {
+		b.WriteString(s.fieldView(i18n.T("tui.add.template_name"), s.inputs[0], s.current() == fieldTitle))
+		b.WriteString(s.typeSelectorView(i18n.T("tui.field.template"), tmpl.Name, s.current() == fieldTemplate))
+		b.WriteString(s.templatePreview(m, tmpl))
+		return s.footerView(m, &b)
+	}
+
 	// Title (always shown)
-	b.WriteString(s.fieldView("Название", s.inputs[0], s.focusIdx == fieldTitle))
+	b.WriteString(s.fieldView(i18n.T("tui.field.title"), s.inputs[0], s.current() == fieldTitle))
+	if len(s.templates) > 0 {
+		b.WriteString(s.typeSelectorView(i18n.T("tui.field.template"), i18n.T("tui.add.no_template"), s.current() == fieldTemplate))
+	}
 	// Type (always shown)
-	b.WriteString(s.typeSelectorView("Тип", s.questTypes[s.typeIdx].String(), s.focusIdx == fieldType))
+	b.WriteString(s.typeSelectorView(i18n.T("tui.field.type"), i18n.T("quest.type."+s.questTypes[s.typeIdx].String()), s.current() == fieldType))
 
 	switch currentType {
 	case player.TypeFocus:
-		b.WriteString(s.fieldView("HP", s.inputs[1], s.focusIdx == fieldHP))
-		b.WriteString(s.fieldView("XP", s.inputs[2], s.focusIdx == fieldXP))
-		b.WriteString(s.fieldView("Теги (через запятую)", s.inputs[3], s.focusIdx == fieldTags))
-		b.WriteString(s.fieldView("Дедлайн (ГГГГ-ММ-ДД)", s.inputs[4], s.focusIdx == fieldDeadline))
+		b.WriteString(s.fieldView("HP", s.inputs[1], s.current() == fieldHP))
+		b.WriteString(s.fieldView("XP", s.inputs[2], s.current() == fieldXP))
+		b.WriteString(s.fieldView(i18n.T("tui.field.tags"), s.inputs[3], s.current() == fieldTags))
+		b.WriteString(s.fieldView(i18n.T("tui.field.deadline"), s.inputs[4], s.current() == fieldDeadline))
+		b.WriteString(s.fieldView(i18n.T("tui.field.recurrence"), s.inputs[5], s.current() == fieldRecurrence))
 	case player.TypeRitual:
-		b.WriteString(s.typeSelectorView("Подтип", string(s.ritualSubtypes[s.subtypeIdx]), s.focusIdx == fieldRitualSubtype))
+		b.WriteString(s.typeSelectorView(i18n.T("tui.field.subtype"), i18n.T("quest.ritual."+string(s.ritualSubtypes[s.subtypeIdx])), s.current() == fieldRitualSubtype))
+		b.WriteString(s.fieldView(i18n.T("tui.field.recurrence"), s.inputs[5], s.current() == fieldRecurrence))
 	case player.TypeGoal:
 		// No extra fields needed
 	}
+	b.WriteString(s.areaView(i18n.T("tui.field.description"), s.description, s.current() == fieldDescription))
+	b.WriteString(s.fieldView(i18n.T("tui.field.links"), s.inputs[6], s.current() == fieldLinks))
 
-	// Button
+	return s.footerView(m, &b)
+}
+
+// footerView дописывает кнопку, ошибку и подсказку и оформляет экран.
+func (s *AddQuestState) footerView(m *Model, b *strings.Builder) string {
 	saveButtonStyle := lipgloss.NewStyle().Padding(0, 1)
-	if s.focusIdx == s.numFields()-1 {
+	if s.current() == fieldButton {
 		saveButtonStyle = saveButtonStyle.Background(lipgloss.Color("205")).Foreground(lipgloss.Color("0"))
 	}
-	b.WriteString(fmt.Sprintf("\n%s\n", saveButtonStyle.Render("[ Сохранить ]")))
-	b.WriteString("\n" + m.styles.FaintQuestCardStyle.Render("esc - отмена"))
+	b.WriteString(fmt.Sprintf("\n%s\n", saveButtonStyle.Render(i18n.T("tui.add.save_button"))))
+	if s.errMsg != "" {
+		b.WriteString("\n" + m.styles.DeadlineStyle.Render(s.errMsg) + "\n")
+	}
+	b.WriteString("\n" + m.styles.FaintQuestCardStyle.Render(i18n.T("tui.add.help")))
 
 	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
 }
 
+// templatePreview показывает дерево квестов, которое создаст шаблон.
+func (s *AddQuestState) templatePreview(m *Model, tmpl templates.Template) string {
+	var b strings.Builder
+	if tmpl.Description != "" {
+		b.WriteString("  " + m.styles.StatusMessageStyle.Render(tmpl.Description) + "\n")
+	}
+	vars := templates.Vars(s.inputs[0].Value(), time.Now())
+	var walk func(n templates.Node, depth int)
+	walk = func(n templates.Node, depth int) {
+		line := strings.Repeat("  ", depth+1) + templates.Expand(n.Title, vars)
+		if n.DueInDays > 0 {
+			line += " " + m.styles.DeadlineStyle.Render(i18n.T("tui.add.template_due", n.DueInDays))
+		}
+		b.WriteString(line + "\n")
+		for _, child := range n.Children {
+			walk(child, depth+1)
+		}
+	}
+	walk(tmpl.Quest, 0)
+	return b.String() + "\n"
+}
+
 func (s *AddQuestState) fieldView(label string, input textinput.Model, focused bool) string {
 	cursor := "  "
 	if focused {
@@ -176,6 +318,14 @@ func (s *AddQuestState) fieldView(label string, input textinput.Model, focused b
 	return fmt.Sprintf("%s%s\n  %s\n\n", cursor, label, input.View())
 }
 
+func (s *AddQuestState) areaView(label string, area textarea.Model, focused bool) string {
+	cursor := "  "
+	if focused {
+		cursor = "> "
+	}
+	return fmt.Sprintf("%s%s\n%s\n\n", cursor, label, lipgloss.NewStyle().MarginLeft(2).Render(area.View()))
+}
+
 func (s *AddQuestState) typeSelectorView(label, value string, focused bool) string {
 	cursor := "  "
 	if focused {
@@ -192,35 +342,22 @@ func (s *AddQuestState) syncFocus() (*AddQuestState, tea.Cmd) {
 	for i := range s.inputs {
 		s.inputs[i].Blur()
 	}
+	s.description.Blur()
 
 	var cmd tea.Cmd
-	currentType := s.questTypes[s.typeIdx]
-
-	switch s.focusIdx {
-	case fieldTitle:
-		cmd = s.inputs[0].Focus()
-	case fieldHP:
-		if currentType == player.TypeFocus {
-			cmd = s.inputs[1].Focus()
-		}
-	case fieldXP:
-		if currentType == player.TypeFocus {
-			cmd = s.inputs[2].Focus()
-		}
-	case fieldTags:
-		if currentType == player.TypeFocus {
-			cmd = s.inputs[3].Focus()
-		}
-	case fieldDeadline:
-		if currentType == player.TypeFocus {
-			cmd = s.inputs[4].Focus()
-		}
+	if idx, ok := fieldInputs[s.current()]; ok {
+		cmd = s.inputs[idx].Focus()
+	} else if s.current() == fieldDescription {
+		cmd = s.description.Focus()
 	}
 	return s, cmd
 }
 
 func (s *AddQuestState) saveQuest(m *Model) (State, tea.Cmd) {
 	title := s.inputs[0].Value()
+	if tmpl, ok := s.template(); ok {
+		return s.saveTemplate(m, tmpl, title)
+	}
 	if title == "" {
 		return s, nil // TODO: Show status message
 	}
@@ -231,12 +368,17 @@ func (s *AddQuestState) saveQuest(m *Model) (State, tea.Cmd) {
 	}
 	id := hex.EncodeToString(bytes)
 
+	// Просрочка новых квестов учитывается с момента создания
+	created := time.Now()
 	newQuest := player.Quest{
-		ID:        id,
-		Title:     title,
-		ParentID:  s.parentId,
-		Type:      s.questTypes[s.typeIdx],
-		CreatedAt: time.Now(),
+		ID:          id,
+		Title:       title,
+		ParentID:    s.parentId,
+		Type:        s.questTypes[s.typeIdx],
+		CreatedAt:   created,
+		PenaltyAt:   &created,
+		Description: strings.TrimSpace(s.description.Value()),
+		Links:       parseLinks(s.inputs[6].Value()),
 	}
 
 	switch newQuest.Type {
@@ -253,17 +395,16 @@ func (s *AddQuestState) saveQuest(m *Model) (State, tea.Cmd) {
 		}
 		newQuest.XP = xp
 
-		tagsStr := s.inputs[3].Value()
-		if tagsStr != "" {
-			newQuest.Tags = strings.Split(tagsStr, ",")
-		}
+		newQuest.Tags = tags.Parse(s.inputs[3].Value())
 
-		deadlineStr := s.inputs[4].Value()
-		if deadlineStr != "" {
-			t, err := time.Parse("2006-01-02", deadlineStr)
-			if err == nil {
-				newQuest.Deadline = &t
+		if deadlineStr := strings.TrimSpace(s.inputs[4].Value()); deadlineStr != "" {
+			t, timed, err := deadline.Parse(deadlineStr)
+			if err != nil {
+				s.errMsg = i18n.T("tui.add.err_deadline", deadlineStr)
+				return s, nil
 			}
+			newQuest.Deadline = &t
+			newQuest.DeadlineTime = timed
 		}
 	case player.TypeRitual:
 		newQuest.RitualSubtype = s.ritualSubtypes[s.subtypeIdx]
@@ -272,9 +413,37 @@ func (s *AddQuestState) saveQuest(m *Model) (State, tea.Cmd) {
 		newQuest.XP = 100 // Цели дают много опыта при завершении
 	}
 
+	if every := strings.TrimSpace(s.inputs[5].Value()); every != "" && newQuest.Type != player.TypeGoal {
+		rule, err := recur.Parse(every)
+		if err != nil {
+			s.errMsg = i18n.T("tui.add.err_recurrence", err)
+			return s, nil
+		}
+		newQuest.Recurrence = rule.String()
+		if newQuest.Deadline == nil {
+			rule.Start = newQuest.CreatedAt
+			due := rule.First(newQuest.CreatedAt)
+			newQuest.Deadline = &due
+		}
+	}
+
 	m.Quests = append(m.Quests, newQuest)
 	storage.SaveAllQuests(m.Quests)
 
 	// Возвращаемся и обновляем список квестов
 	return PopState{refreshQuests: true}, nil
 }
+
+// saveTemplate создает дерево квестов по шаблону.
+func (s *AddQuestState) saveTemplate(m *Model, tmpl templates.Template, name string) (State, tea.Cmd) {
+	now := time.Now()
+	quests, err := templates.Instantiate(tmpl, s.parentId, templates.Vars(name, now), now)
+	if err != nil {
+		s.errMsg = i18n.T("tui.add.err_template", err)
+		return s, nil
+	}
+
+	m.Quests = append(m.Quests, quests...)
+	storage.SaveAllQuests(m.Quests)
+	return PopState{refreshQuests: true}, nil
+}
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"fmt"
+	"magus/agenda"
+	"magus/i18n"
+	"magus/player"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// AgendaState — экран "Сегодня": что нужно сделать за текущий день.
+type AgendaState struct {
+	agenda agenda.Agenda
+	items  []player.Quest // Все квесты экрана в порядке отображения, для навигации
+	cursor int
+}
+
+func NewAgendaState(m *Model) *AgendaState {
+	a := agenda.Build(m.Quests, m.Player, m.Effects.ManaCost, time.Now(), m.settings().DayStartHour)
+
+	s := &AgendaState{agenda: a}
+	s.items = append(s.items, a.Rituals...)
+	s.items = append(s.items, a.Due...)
+	s.items = append(s.items, a.InProgress...)
+	return s
+}
+
+func (s *AgendaState) Init() tea.Cmd {
+	return nil
+}
+
+func (s *AgendaState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "up", "k":
+			if s.cursor > 0 {
+				s.cursor--
+			}
+		case "down", "j":
+			if s.cursor < len(s.items)-1 {
+				s.cursor++
+			}
+		case "enter":
+			if len(s.items) > 0 {
+				qs := NewQuestsState(m)
+				qs.selectQuest(s.items[s.cursor].ID)
+				return qs, nil
+			}
+		case "p":
+			// Переходим к подготовке данжа с квестами из плана
+			prep := NewDungeonPrepState(m).(*dungeonPrepModel)
+			for _, session := range s.agenda.Plan {
+				prep.selectedQuests[session.Quest.ID] = struct{}{}
+			}
+			return prep, nil
+		case "q", "esc":
+			return PopState{}, nil
+		}
+	}
+	return s, nil
+}
+
+func (s *AgendaState) View(m *Model) string {
+	var b strings.Builder
+	a := s.agenda
+	b.WriteString(m.styles.TitleStyle.Render(i18n.T("tui.agenda.title", a.DayStart.Format("2006-01-02"))) + "\n\n")
+
+	if a.IsEmpty() {
+		b.WriteString(i18n.T("cmd.agenda.empty") + "\n")
+	}
+
+	idx := 0
+	section := func(header string, quests []player.Quest, detail func(q player.Quest) string) {
+		if len(quests) == 0 {
+			return
+		}
+		b.WriteString(m.styles.MetaStyle.Render(header) + "\n")
+		for _, q := range quests {
+			cursor := "  "
+			style := lipgloss.NewStyle()
+			if idx == s.cursor {
+				cursor = "> "
+				style = style.Foreground(lipgloss.Color("205"))
+			}
+			line := q.Title
+			if d := detail(q); d != "" {
+				line += " " + m.styles.StatusMessageStyle.Render(d)
+			}
+			b.WriteString(cursor + style.Render(line) + "\n")
+			idx++
+		}
+		b.WriteString("\n")
+	}
+
+	section(i18n.T("agenda.rituals"), a.Rituals, func(q player.Quest) string { return "" })
+	section(i18n.T("agenda.due"), a.Due, func(q player.Quest) string {
+		if agenda.IsOverdue(q, time.Now()) {
+			return "(" + i18n.T("agenda.overdue") + ")"
+		}
+		return "(" + i18n.T("agenda.due_today") + ")"
+	})
+	section(i18n.T("agenda.in_progress"), a.InProgress, func(q player.Quest) string {
+		return fmt.Sprintf("HP: %d/%d", q.Progress, q.HP)
+	})
+
+	b.WriteString(m.styles.FocusStyle.Render(i18n.T("agenda.plan", m.Player.Mana)) + "\n")
+	if len(a.Plan) == 0 {
+		b.WriteString("  " + i18n.T("agenda.plan_empty") + "\n")
+	} else {
+		for i, session := range a.Plan {
+			b.WriteString(fmt.Sprintf("  %d. %s\n", i+1, i18n.T("agenda.session", int(session.Duration.Minutes()), session.Quest.Title, session.ManaCost)))
+		}
+		b.WriteString("  " + m.styles.StatusMessageStyle.Render(i18n.T("agenda.mana_left", a.ManaLeft)) + "\n")
+	}
+
+	b.WriteString("\n" + m.styles.StatusMessageStyle.Render(i18n.T("tui.agenda.help")))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
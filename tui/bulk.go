@@ -0,0 +1,552 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"magus/deadline"
+	"magus/deps"
+	"magus/game"
+	"magus/i18n"
+	"magus/player"
+	"magus/storage"
+	"magus/tags"
+	"magus/tree"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bulkAction — массовая операция над отмеченными квестами.
+type bulkAction int
+
+const (
+	bulkNone bulkAction = iota
+	bulkComplete
+	bulkDelete
+	bulkAddTag
+	bulkRemoveTag
+	bulkDeadline
+	bulkMove
+)
+
+// bulkStage — шаг массовой операции.
+type bulkStage int
+
+const (
+	stageInput   bulkStage = iota // Ввод тега или срока
+	stagePick                     // Выбор новой цели курсором
+	stageConfirm                  // Подтверждение
+)
+
+// undoStep — состояние до последней массовой операции. Отменить ее можно,
+// пока квесты не менялись после нее.
+type undoStep struct {
+	quests []byte // Квесты до операции
+	player []byte // Игрок до операции
+	after  []byte // Квесты сразу после операции
+}
+
+// toggleMark отмечает выбранный квест или снимает отметку и переводит
+// курсор ниже, чтобы отмечать квесты подряд.
+func (s *QuestsState) toggleMark() {
+	item, ok := s.list.SelectedItem().(QuestListItem)
+	if !ok {
+		return
+	}
+	if s.marked[item.ID] {
+		delete(s.marked, item.ID)
+	} else {
+		s.marked[item.ID] = true
+	}
+	s.list.CursorDown()
+	s.updateTitle()
+}
+
+// toggleRange начинает диапазон от выбранного квеста или закрепляет его.
+func (s *QuestsState) toggleRange() {
+	if s.rangeFrom >= 0 {
+		s.rangeFrom = -1
+		s.updateTitle()
+		return
+	}
+	s.rangeFrom = s.list.Index()
+	s.rangeBase = maps.Clone(s.marked)
+	s.extendRange()
+}
+
+// extendRange отмечает квесты от начала диапазона до курсора.
+func (s *QuestsState) extendRange() {
+	if s.rangeFrom < 0 {
+		return
+	}
+	clear(s.marked)
+	maps.Copy(s.marked, s.rangeBase)
+	items := s.list.Items()
+	from, to := min(s.rangeFrom, s.list.Index()), max(s.rangeFrom, s.list.Index())
+	for i := from; i <= to && i < len(items); i++ {
+		if qli, ok := items[i].(QuestListItem); ok {
+			s.marked[qli.ID] = true
+		}
+	}
+	s.updateTitle()
+}
+
+// clearMarks снимает все отметки.
+func (s *QuestsState) clearMarks() {
+	clear(s.marked)
+	s.rangeFrom = -1
+	s.updateTitle()
+}
+
+// updateTitle показывает в заголовке число отмеченных квестов.
+func (s *QuestsState) updateTitle() {
+	switch {
+	case s.rangeFrom >= 0:
+		s.list.Title = i18n.T("tui.bulk.title_range", len(s.marked))
+	case len(s.marked) > 0:
+		s.list.Title = i18n.T("tui.bulk.title_marked", len(s.marked))
+	default:
+		s.list.Title = i18n.T("tui.quests.title")
+	}
+}
+
+// targets возвращает отмеченные квесты в порядке списка, а без отметок —
+// выбранный квест.
+func (s *QuestsState) targets() []player.Quest {
+	selected := ""
+	if len(s.marked) == 0 {
+		if item, ok := s.list.SelectedItem().(QuestListItem); ok {
+			selected = item.ID
+		}
+	}
+	var list []player.Quest
+	for _, q := range s.allQuests {
+		if s.marked[q.ID] || q.ID == selected {
+			list = append(list, q)
+		}
+	}
+	return list
+}
+
+// bulkQuests возвращает квесты текущей массовой операции в порядке списка.
+func (s *QuestsState) bulkQuests() []player.Quest {
+	var list []player.Quest
+	for _, q := range s.allQuests {
+		if slices.Contains(s.bulkIDs, q.ID) {
+			list = append(list, q)
+		}
+	}
+	return list
+}
+
+// startBulk начинает массовую операцию: спрашивает тег, срок или новую
+// цель, если они нужны, и подтверждение.
+func (s *QuestsState) startBulk(action bulkAction) (State, tea.Cmd) {
+	// Квесты операции запоминаются сразу: при выборе цели курсор уходит с них
+	s.bulkIDs = nil
+	for _, q := range s.targets() {
+		s.bulkIDs = append(s.bulkIDs, q.ID)
+	}
+	n := len(s.bulkIDs)
+	if n == 0 {
+		return s, nil
+	}
+	s.rangeFrom = -1
+	s.bulk = action
+	s.bulkArg = ""
+
+	switch action {
+	case bulkAddTag, bulkRemoveTag, bulkDeadline:
+		s.bulkStage = stageInput
+		s.bulkInput = textinput.New()
+		s.bulkInput.CharLimit = 30
+		s.bulkInput.Width = 30
+		s.bulkInput.Placeholder = "work/backend"
+		prompt := "tui.bulk.prompt_add_tag"
+		if action == bulkRemoveTag {
+			prompt = "tui.bulk.prompt_remove_tag"
+		}
+		if action == bulkDeadline {
+			s.bulkInput.Placeholder = deadline.DateTimeLayout
+			prompt = "tui.bulk.prompt_deadline"
+		}
+		s.bulkInput.Focus()
+		s.list.Title = i18n.T(prompt, n)
+		return s, textinput.Blink
+	case bulkMove:
+		s.bulkStage = stagePick
+		s.list.Title = i18n.T("tui.bulk.pick_parent", n)
+		return s, nil
+	}
+	s.confirm()
+	return s, nil
+}
+
+// confirm переходит к подтверждению операции.
+func (s *QuestsState) confirm() {
+	s.bulkStage = stageConfirm
+	n := len(s.bulkIDs)
+	switch s.bulk {
+	case bulkComplete:
+		s.list.Title = i18n.T("tui.bulk.confirm_complete", n)
+	case bulkDelete:
+		s.list.Title = i18n.T("tui.bulk.confirm_delete", n)
+	case bulkAddTag:
+		s.list.Title = i18n.T("tui.bulk.confirm_add_tag", s.bulkArg, n)
+	case bulkRemoveTag:
+		s.list.Title = i18n.T("tui.bulk.confirm_remove_tag", s.bulkArg, n)
+	case bulkDeadline:
+		if s.bulkArg == "" {
+			s.list.Title = i18n.T("tui.bulk.confirm_clear_deadline", n)
+		} else {
+			s.list.Title = i18n.T("tui.bulk.confirm_deadline", s.bulkArg, n)
+		}
+	case bulkMove:
+		parent := i18n.T("tui.bulk.top_level")
+		for _, q := range s.allQuests {
+			if q.ID == s.bulkArg {
+				parent = q.Title
+			}
+		}
+		s.list.Title = i18n.T("tui.bulk.confirm_move", n, parent)
+	}
+}
+
+// endBulk завершает или отменяет массовую операцию.
+func (s *QuestsState) endBulk() {
+	s.bulk = bulkNone
+	s.bulkIDs = nil
+	s.bulkArg = ""
+	s.bulkInput.Blur()
+	s.updateTitle()
+}
+
+// updateBulk обрабатывает клавиши во время массовой операции.
+func (s *QuestsState) updateBulk(m *Model, msg tea.KeyMsg) (State, tea.Cmd) {
+	// q в списке означает выход из программы, поэтому тоже отменяет операцию
+	if msg.String() == "esc" || msg.String() == "q" && s.bulkStage != stageInput {
+		s.endBulk()
+		return s, s.list.NewStatusMessage(i18n.T("tui.bulk.cancelled"))
+	}
+
+	var cmd tea.Cmd
+	switch s.bulkStage {
+	case stageInput:
+		if msg.String() != "enter" {
+			s.bulkInput, cmd = s.bulkInput.Update(msg)
+			return s, cmd
+		}
+		value := s.bulkInput.Value()
+		if s.bulk == bulkDeadline {
+			if value != "" {
+				if _, _, err := deadline.Parse(value); err != nil {
+					return s, s.list.NewStatusMessage(i18n.T("tui.add.err_deadline", value))
+				}
+			}
+			s.bulkArg = value
+		} else {
+			if s.bulkArg = tags.Normalize(value); s.bulkArg == "" {
+				return s, nil
+			}
+		}
+		s.bulkInput.Blur()
+		s.confirm()
+	case stagePick:
+		switch msg.String() {
+		case "enter":
+			item, ok := s.list.SelectedItem().(QuestListItem)
+			if !ok {
+				return s, nil
+			}
+			moved := tree.Subtree(s.allQuests, s.bulkIDs...)
+			if moved[item.ID] {
+				return s, s.list.NewStatusMessage(i18n.T("tui.bulk.err_cycle", item.Title))
+			}
+			if item.Completed && tree.HasOpen(s.allQuests, moved) {
+				return s, s.list.NewStatusMessage(i18n.T("tui.bulk.err_parent_done", item.Title))
+			}
+			s.bulkArg = item.ID
+			s.confirm()
+		case "0":
+			s.bulkArg = ""
+			s.confirm()
+		default:
+			s.list, cmd = s.list.Update(msg)
+			return s, cmd
+		}
+	case stageConfirm:
+		if msg.String() == "y" || msg.String() == "enter" {
+			return s.applyBulk(m)
+		}
+		s.endBulk()
+		return s, s.list.NewStatusMessage(i18n.T("tui.bulk.cancelled"))
+	}
+	return s, nil
+}
+
+// applyBulk выполняет подтвержденную операцию одним шагом, который можно
+// отменить клавишей u.
+func (s *QuestsState) applyBulk(m *Model) (State, tea.Cmd) {
+	action, arg := s.bulk, s.bulkArg
+	targets := s.bulkQuests()
+	s.endBulk()
+	step := m.snapshot()
+
+	var statusMsg string
+	var levelUp bool
+	switch action {
+	case bulkComplete:
+		statusMsg, levelUp = s.completeAll(m, targets)
+	case bulkDelete:
+		statusMsg = s.deleteAll(targets)
+	case bulkAddTag, bulkRemoveTag:
+		statusMsg = s.tagAll(targets, arg, action == bulkAddTag)
+	case bulkDeadline:
+		statusMsg = s.setDeadlines(targets, arg)
+	case bulkMove:
+		statusMsg, levelUp = s.moveAll(m, targets, arg)
+	}
+
+	m.Quests = s.allQuests
+	storage.SaveAllQuests(m.Quests)
+	step.after, _ = json.Marshal(m.Quests)
+	m.undo = step
+
+	s.clearMarks()
+	s.list.SetItems(s.buildItems(s.list.Items()))
+	if s.list.Index() >= len(s.list.Items()) && len(s.list.Items()) > 0 {
+		s.list.Select(len(s.list.Items()) - 1)
+	}
+
+	if levelUp {
+		levelUpState, status := NewLevelUpState(m)
+		if levelUpState != nil {
+			return levelUpState, nil
+		}
+		if status != "" {
+			statusMsg += " " + status
+		}
+	}
+	s.statusMessage = statusMsg
+	return s, s.list.NewStatusMessage(statusMsg)
+}
+
+// completeAll завершает квесты по правилам игры: сначала подзадачи, потом
+// цели. Квесты, которые завершить нельзя, пропускаются.
+func (s *QuestsState) completeAll(m *Model, targets []player.Quest) (string, bool) {
+	parents := make(map[string]string, len(s.allQuests))
+	for _, q := range s.allQuests {
+		parents[q.ID] = q.ParentID
+	}
+	depth := func(id string) int {
+		d := 0
+		for p := parents[id]; p != ""; p = parents[p] {
+			d++
+		}
+		return d
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		return depth(targets[i].ID) > depth(targets[j].ID)
+	})
+
+	w := &game.World{Player: m.Player, Quests: s.allQuests, DayStartHour: m.settings().DayStartHour, Tags: m.Tags, Effects: m.Effects, Curve: m.settings().Curve()}
+	now := time.Now()
+	var events []game.Event
+	done, skipped := 0, 0
+	for _, q := range targets {
+		e, err := game.CompleteQuest(w, q.ID, now)
+		switch {
+		case err == nil:
+			done++
+			events = append(events, e...)
+		case errors.Is(err, game.ErrAlreadyCompleted) && !q.Completed:
+			done++ // Цель завершилась вместе с последней подзадачей
+		default:
+			skipped++
+		}
+	}
+	s.allQuests = w.Quests
+	if m.Player != nil {
+		player.SavePlayer(m.Player)
+	}
+
+	statusMsg := i18n.T("tui.bulk.completed", done, game.Total(events, game.EventXPGained))
+	if skipped > 0 {
+		statusMsg += " " + i18n.T("tui.bulk.skipped", skipped)
+	}
+	return statusMsg, game.Has(events, game.EventLevelUpReady)
+}
+
+// deleteAll удаляет квесты вместе с подзадачами.
+func (s *QuestsState) deleteAll(targets []player.Quest) string {
+	var ids []string
+	for _, q := range targets {
+		ids = append(ids, q.ID)
+	}
+	doomed := tree.Subtree(s.allQuests, ids...)
+
+	var kept []player.Quest
+	for _, q := range s.allQuests {
+		if !doomed[q.ID] {
+			kept = append(kept, q)
+		}
+	}
+	deps.Prune(kept) // Удаленные квесты больше никого не блокируют
+	s.allQuests = kept
+	return i18n.T("tui.bulk.deleted", len(doomed))
+}
+
+// tagAll добавляет тег квестам или убирает его вместе с вложенными тегами.
+func (s *QuestsState) tagAll(targets []player.Quest, tag string, add bool) string {
+	ids := make(map[string]bool)
+	for _, q := range targets {
+		ids[q.ID] = true
+	}
+	changed := 0
+	for i := range s.allQuests {
+		q := &s.allQuests[i]
+		if !ids[q.ID] {
+			continue
+		}
+		if add {
+			if !slices.Contains(q.Tags, tag) {
+				q.Tags = append(q.Tags, tag)
+				changed++
+			}
+			continue
+		}
+		var kept []string
+		for _, t := range q.Tags {
+			if !tags.Within(t, tag) {
+				kept = append(kept, t)
+			}
+		}
+		if len(kept) != len(q.Tags) {
+			q.Tags = kept
+			changed++
+		}
+	}
+	if add {
+		return i18n.T("tui.bulk.tag_added", tag, changed)
+	}
+	return i18n.T("tui.bulk.tag_removed", tag, changed)
+}
+
+// setDeadlines ставит квестам срок или убирает его, если value пусто.
+// Срок уже проверен при вводе.
+func (s *QuestsState) setDeadlines(targets []player.Quest, value string) string {
+	// Новый срок — новый счет дней просрочки, без срока считать нечего
+	var due, penaltyAt *time.Time
+	var timed bool
+	if value != "" {
+		t, hasTime, _ := deadline.Parse(value)
+		now := time.Now()
+		due, timed, penaltyAt = &t, hasTime, &now
+	}
+	ids := make(map[string]bool)
+	for _, q := range targets {
+		ids[q.ID] = true
+	}
+	for i := range s.allQuests {
+		if ids[s.allQuests[i].ID] {
+			s.allQuests[i].Deadline = due
+			s.allQuests[i].DeadlineTime = timed
+			s.allQuests[i].PenaltyAt = penaltyAt
+		}
+	}
+	if due == nil {
+		return i18n.T("tui.bulk.deadline_cleared", len(ids))
+	}
+	return i18n.T("tui.bulk.deadline_set", deadline.Format(*due, timed), len(ids))
+}
+
+// moveAll переносит квесты под цель parentID ("" — на верхний уровень).
+// Подзадачи отмеченных квестов переезжают вместе с ними. Прежние цели, в
+// которых остались только выполненные подзадачи, завершаются.
+func (s *QuestsState) moveAll(m *Model, targets []player.Quest, parentID string) (string, bool) {
+	ids := make(map[string]bool)
+	for _, q := range targets {
+		ids[q.ID] = true
+	}
+	parents := make(map[string]string, len(s.allQuests))
+	for _, q := range s.allQuests {
+		parents[q.ID] = q.ParentID
+	}
+	moved := 0
+	var from []string
+	for _, id := range tree.Roots(s.allQuests, ids) {
+		if tree.Move(s.allQuests, id, parentID) == nil {
+			moved++
+			from = append(from, parents[id])
+		}
+	}
+
+	statusMsg := i18n.T("tui.bulk.moved", moved)
+	events := s.closeGoals(m, from...)
+	if msg := eventsMessage(events); msg != "" {
+		statusMsg += " " + msg
+	}
+	return statusMsg, game.Has(events, game.EventLevelUpReady)
+}
+
+// sendToDungeon открывает подготовку к данжу с отмеченными фокус-квестами.
+func (s *QuestsState) sendToDungeon(m *Model) (State, tea.Cmd) {
+	targets := s.targets()
+	prep := NewDungeonPrepState(m).(*dungeonPrepModel)
+	for _, q := range targets {
+		if q.Type == player.TypeFocus && !q.Completed {
+			prep.selectedQuests[q.ID] = struct{}{}
+		}
+	}
+	if len(prep.selectedQuests) == 0 {
+		return s, s.list.NewStatusMessage(i18n.T("tui.bulk.no_focus"))
+	}
+	s.clearMarks()
+	return prep, nil
+}
+
+// snapshot запоминает квесты и игрока перед массовой операцией.
+func (m *Model) snapshot() *undoStep {
+	step := &undoStep{}
+	step.quests, _ = json.Marshal(m.Quests)
+	if m.Player != nil {
+		step.player, _ = json.Marshal(m.Player)
+	}
+	return step
+}
+
+// undoBulk отменяет последнюю массовую операцию.
+func (s *QuestsState) undoBulk(m *Model) (State, tea.Cmd) {
+	step := m.undo
+	if step == nil {
+		return s, s.list.NewStatusMessage(i18n.T("tui.bulk.nothing_to_undo"))
+	}
+	if current, _ := json.Marshal(m.Quests); !bytes.Equal(current, step.after) {
+		m.undo = nil
+		return s, s.list.NewStatusMessage(i18n.T("tui.bulk.undo_stale"))
+	}
+
+	var quests []player.Quest
+	if err := json.Unmarshal(step.quests, &quests); err != nil {
+		return s, s.list.NewStatusMessage(err.Error())
+	}
+	// Игрока восстанавливаем, только если операция его изменила (завершение)
+	if current, _ := json.Marshal(m.Player); step.player != nil && !bytes.Equal(current, step.player) {
+		var p player.Player
+		if err := json.Unmarshal(step.player, &p); err == nil {
+			m.Player = &p
+			player.SavePlayer(m.Player)
+		}
+	}
+	m.undo = nil
+	m.Quests = quests
+	s.allQuests = quests
+	storage.SaveAllQuests(m.Quests)
+	s.list.SetItems(s.buildItems(s.list.Items()))
+	return s, s.list.NewStatusMessage(i18n.T("tui.bulk.undone"))
+}
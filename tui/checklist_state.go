@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"magus/checklist"
+	"magus/game"
+	"magus/i18n"
+	"magus/player"
+	"magus/storage"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ChecklistState — экран чек-листа квеста: отметка, добавление и удаление пунктов.
+type ChecklistState struct {
+	questID string
+	cursor  int
+	input   textinput.Model
+	adding  bool // Вводится текст нового пункта
+	message string
+}
+
+func NewChecklistState(m *Model, questID string) *ChecklistState {
+	ti := textinput.New()
+	ti.Placeholder = i18n.T("tui.checklist.placeholder")
+	ti.CharLimit = 100
+	ti.Width = 50
+	return &ChecklistState{questID: questID, input: ti}
+}
+
+func (s *ChecklistState) Init() tea.Cmd {
+	return nil
+}
+
+// quest возвращает квест из общего списка или nil, если его больше нет.
+func (s *ChecklistState) quest(m *Model) *player.Quest {
+	for i := range m.Quests {
+		if m.Quests[i].ID == s.questID {
+			return &m.Quests[i]
+		}
+	}
+	return nil
+}
+
+func (s *ChecklistState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
+	q := s.quest(m)
+	if q == nil {
+		return PopState{}, nil
+	}
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return s, nil
+	}
+
+	if s.adding {
+		switch key.String() {
+		case "esc":
+			s.adding = false
+			s.input.Blur()
+			s.input.SetValue("")
+			return s, nil
+		case "enter":
+			if err := checklist.Add(q, s.input.Value()); err != nil {
+				s.message = i18n.T("tui.checklist.err_empty")
+				return s, nil
+			}
+			storage.SaveAllQuests(m.Quests)
+			s.cursor = len(q.Checklist) - 1
+			s.input.SetValue("")
+			s.message = ""
+			return s, nil
+		}
+		var cmd tea.Cmd
+		s.input, cmd = s.input.Update(msg)
+		return s, cmd
+	}
+
+	switch key.String() {
+	case "esc", "q":
+		return PopState{}, nil
+	case "up", "k":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+	case "down", "j":
+		if s.cursor < len(q.Checklist)-1 {
+			s.cursor++
+		}
+	case "a":
+		s.adding = true
+		s.message = ""
+		return s, s.input.Focus()
+	case "d", "delete":
+		if checklist.Remove(q, s.cursor) == nil {
+			storage.SaveAllQuests(m.Quests)
+			if s.cursor >= len(q.Checklist) && s.cursor > 0 {
+				s.cursor--
+			}
+		}
+	case " ", "x", "enter":
+		return s.toggle(m)
+	}
+	return s, nil
+}
+
+// toggle переключает пункт под курсором по правилам игры.
+func (s *ChecklistState) toggle(m *Model) (State, tea.Cmd) {
+	w := &game.World{Player: m.Player, Quests: m.Quests, DayStartHour: m.settings().DayStartHour, Tags: m.Tags, Effects: m.Effects, Curve: m.settings().Curve()}
+	events, err := game.ToggleCheck(w, s.questID, s.cursor, m.settings().ChecklistProgress, time.Now())
+	if errors.Is(err, checklist.ErrNoSuchItem) {
+		return s, nil
+	}
+	if err != nil {
+		s.message = err.Error()
+		return s, nil
+	}
+
+	m.Quests = w.Quests
+	storage.SaveAllQuests(m.Quests)
+	if m.Player != nil {
+		player.SavePlayer(m.Player)
+	}
+
+	// Побежденный квест мог принести новый уровень
+	var status string
+	if game.Has(events, game.EventLevelUpReady) {
+		var levelUpState *LevelUpState
+		if levelUpState, status = NewLevelUpState(m); levelUpState != nil {
+			return levelUpState, nil
+		}
+	}
+	s.message = strings.TrimSpace(eventsMessage(events) + " " + status)
+	return s, nil
+}
+
+func (s *ChecklistState) View(m *Model) string {
+	q := s.quest(m)
+	if q == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	title := i18n.T("tui.checklist.title", q.Title)
+	if summary := checklist.Summary(*q); summary != "" {
+		title += " " + summary
+	}
+	b.WriteString(m.styles.TitleStyle.Render(title) + "\n\n")
+
+	if len(q.Checklist) == 0 {
+		b.WriteString(m.styles.StatusMessageStyle.Render(i18n.T("tui.checklist.empty")) + "\n")
+	}
+	for i, item := range q.Checklist {
+		cursor := "  "
+		text := item.Text
+		if i == s.cursor && !s.adding {
+			cursor = "> "
+			text = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Render(text)
+		}
+		mark := "[ ]"
+		if item.Done {
+			mark = "[" + m.styles.CompletedIcon + "]"
+		}
+		b.WriteString(fmt.Sprintf("%s%s %s\n", cursor, mark, text))
+	}
+
+	if s.adding {
+		b.WriteString("\n" + s.input.View() + "\n")
+	}
+	if s.message != "" {
+		b.WriteString("\n" + m.styles.StatusMessageStyle.Render(s.message) + "\n")
+	}
+
+	help := i18n.T("tui.checklist.help")
+	if s.adding {
+		help = i18n.T("tui.checklist.help_adding")
+	}
+	b.WriteString("\n" + m.styles.StatusMessageStyle.Render(help))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"magus/i18n"
 	"magus/player"
 	"magus/rpg"
 	"strings"
@@ -13,12 +14,15 @@ import (
 type ClassChoiceState struct {
 	choices []rpg.Class
 	cursor  int
+	err     error // Бонусы классов не загрузились: выбор возможен и без них
 }
 
 func NewClassChoiceState(m *Model) *ClassChoiceState {
+	classes, err := rpg.GetAvailableClasses()
 	return &ClassChoiceState{
-		choices: rpg.GetAvailableClasses(),
+		choices: classes,
 		cursor:  0,
+		err:     err,
 	}
 }
 
@@ -39,8 +43,9 @@ func (s *ClassChoiceState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 			}
 		case "enter":
 			chosenClass := s.choices[s.cursor]
-			m.Player.Class = chosenClass.Name
+			m.Player.Class = chosenClass.ID
 			player.SavePlayer(m.Player)
+			m.refreshEffects() // Класс дает свои эффекты
 			// После выбора класса возвращаемся на главный экран
 			return NewHomepageState(m), nil
 		}
@@ -50,15 +55,22 @@ func (s *ClassChoiceState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 
 func (s *ClassChoiceState) View(m *Model) string {
 	var b strings.Builder
-	b.WriteString("⚔️ Пришло время выбрать свой путь!\n\n")
-	b.WriteString("Выберите класс:\n\n")
+	b.WriteString(i18n.T("tui.class.title") + "\n\n")
+	b.WriteString(i18n.T("tui.class.prompt") + "\n\n")
 	for i, class := range s.choices {
 		cursor := " "
 		if s.cursor == i {
 			cursor = ">"
 		}
 		b.WriteString(fmt.Sprintf("%s %s: %s\n", cursor, class.Name, class.Description))
+		// Пассивные бонусы класса из data/classes.json
+		for _, passive := range class.Passives {
+			b.WriteString(fmt.Sprintf("    • %s — %s\n", m.styles.MetaStyle.Render(passive.Name), passive.Description))
+		}
+	}
+	if s.err != nil {
+		b.WriteString("\n" + i18n.T("tui.class.err_load", s.err) + "\n")
 	}
-	b.WriteString("\nНажмите 'enter' для выбора. Этот выбор нельзя будет изменить.\n")
+	b.WriteString("\n" + i18n.T("tui.class.help") + "\n")
 	return lipgloss.NewStyle().Border(lipgloss.DoubleBorder(), true).Padding(2).Render(b.String())
 }
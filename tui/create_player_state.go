@@ -1,7 +1,8 @@
 package tui
 
 import (
-	"fmt"
+	"magus/game"
+	"magus/i18n"
 	"magus/player"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -15,7 +16,7 @@ type CreatePlayerState struct {
 
 func NewCreatePlayerState() *CreatePlayerState {
 	ti := textinput.New()
-	ti.Placeholder = "Имя твоего героя"
+	ti.Placeholder = i18n.T("tui.create.placeholder")
 	ti.Focus()
 	ti.CharLimit = 50
 	ti.Width = 50
@@ -39,17 +40,19 @@ func (s *CreatePlayerState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 			return s, nil
 		}
 		m.Player = p // Обновляем глобального игрока
-		return NewHomepageState(m), nil
+		// Порог первого уровня — по кривой опыта из настроек
+		if game.SyncLevel(&game.World{Player: p, Curve: m.settings().Curve()}) {
+			player.SavePlayer(p)
+		}
+		// Новый игрок сразу выбирает класс
+		return NewClassChoiceState(m), nil
 	}
 	s.input, cmd = s.input.Update(msg)
 	return s, cmd
 }
 
 func (s *CreatePlayerState) View(m *Model) string {
-	content := fmt.Sprintf(
-		"Добро пожаловать в Magus!\n\nДавай создадим твоего персонажа.\n\n%s\n\nНажми Enter, чтобы начать.",
-		s.input.View(),
-	)
+	content := i18n.T("tui.create.welcome", s.input.View())
 	// The main TUI now handles placing content in the center, so we just return the content.
 	return lipgloss.NewStyle().
 		Width(m.TerminalWidth).
@@ -3,7 +3,11 @@ package tui
 import (
 	"fmt"
 	"io"
+	"magus/dungeon"
+	"magus/i18n"
 	"magus/player"
+	"magus/snooze"
+	"magus/urgency"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -16,10 +20,11 @@ import (
 
 type durationItem struct {
 	duration time.Duration
+	manaCost int // С учетом эффектов навыков
 }
 
-func (i durationItem) Title() string       { return fmt.Sprintf("%d минут", int(i.duration.Minutes())) }
-func (i durationItem) Description() string { return "Длительность фокус-сессии" }
+func (i durationItem) Title() string       { return i18n.T("tui.prep.minutes", int(i.duration.Minutes())) }
+func (i durationItem) Description() string { return i18n.T("tui.prep.duration_desc", i.manaCost) }
 func (i durationItem) FilterValue() string { return i.Title() }
 
 // --- delegate for quest list ---
@@ -46,16 +51,26 @@ func (d prepQuestDelegate) Render(w io.Writer, m list.Model, index int, listItem
 	indent := lipgloss.NewStyle().PaddingLeft(item.Depth * 2).String()
 
 	var title string
-	if item.Type == player.TypeGoal {
+	switch {
+	case item.Type == player.TypeGoal:
 		title = item.Title
-	} else {
+	case item.Blocked():
+		// Заблокированные квесты нельзя взять в подземелье
+		title = "⛔  " + item.Title
+	default:
 		title = fmt.Sprintf("%s %s", check, item.Title)
 	}
+	if item.Urgency != 0 {
+		title += fmt.Sprintf("  ⚡%.1f", item.Urgency)
+	}
 
 	var style lipgloss.Style
-	if index == m.Index() {
+	switch {
+	case index == m.Index():
 		style = d.styles.SelectedTitle
-	} else {
+	case item.Blocked():
+		style = d.styles.DimmedTitle
+	default:
 		style = d.styles.NormalTitle
 	}
 
@@ -79,21 +94,22 @@ type dungeonPrepModel struct {
 	selectedQuests map[string]struct{}
 	focused        prepFocusable
 	statusMessage  string
+	byUrgency      bool // Квесты отсортированы по срочности
+	coef           urgency.Coefficients
 }
 
 func NewDungeonPrepState(m *Model) State {
 	selectedQuests := make(map[string]struct{})
 
-	durations := []list.Item{
-		durationItem{duration: 15 * time.Minute},
-		durationItem{duration: 25 * time.Minute},
-		durationItem{duration: 45 * time.Minute},
+	var durations []list.Item
+	for _, d := range dungeon.SessionDurations {
+		durations = append(durations, durationItem{duration: d, manaCost: m.Effects.ManaCost(d)})
 	}
 
 	durationDelegate := list.NewDefaultDelegate()
 	durationDelegate.Styles.SelectedTitle.Foreground(lipgloss.Color("205"))
 	durationList := list.New(durations, durationDelegate, 0, 0)
-	durationList.Title = "Длительность"
+	durationList.Title = i18n.T("tui.prep.duration")
 	durationList.SetShowHelp(false)
 	durationList.SetShowStatusBar(true)
 
@@ -105,16 +121,17 @@ func NewDungeonPrepState(m *Model) State {
 	}
 
 	questList := list.New(nil, questDelegate, 0, 0)
-	questList.Title = "Квесты для сессии"
+	questList.Title = i18n.T("tui.prep.quests")
 	questList.SetShowHelp(false)
 	questList.SetShowStatusBar(true)
 
 	s := &dungeonPrepModel{
 		durationList:   durationList,
 		questList:      questList,
-		allQuests:      m.Quests,
+		allQuests:      snooze.Active(m.Quests, time.Now()), // Отложенные квесты ждут своей даты
 		selectedQuests: selectedQuests,
 		focused:        prepFocusDuration,
+		coef:           urgency.WithOverrides(m.settings().Urgency),
 	}
 	s.questList.SetItems(BuildQuestListItems(s.allQuests, s.questList.Items()))
 	return s
@@ -143,19 +160,30 @@ func (s *dungeonPrepModel) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
 			if s.focused == prepFocusButton {
 				selectedDuration := s.durationList.SelectedItem().(durationItem).duration
-				manaCost := int(selectedDuration.Minutes() / 5)
+				manaCost := m.Effects.ManaCost(selectedDuration)
 				if m.Player.Mana < manaCost {
-					s.statusMessage = fmt.Sprintf("Недостаточно маны! Нужно %d, у вас %d.", manaCost, m.Player.Mana)
+					s.statusMessage = i18n.T("tui.prep.no_mana", manaCost, m.Player.Mana)
 					return s, nil
 				}
 				m.Player.Mana -= manaCost
 				player.SavePlayer(m.Player)
-				return NewDungeonState(m, selectedDuration), nil
+				return NewDungeonState(m, selectedDuration, s.selectedQuestIDs()), nil
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("s"))):
+			if s.focused == prepFocusQuests {
+				s.byUrgency = !s.byUrgency
+				if s.byUrgency {
+					s.questList.SetItems(BuildUrgencyListItems(s.allQuests, s.questList.Items(), s.coef))
+				} else {
+					s.questList.SetItems(BuildQuestListItems(s.allQuests, s.questList.Items()))
+				}
+				s.questList.Select(0)
+				return s, nil
 			}
 		case key.Matches(msg, key.NewBinding(key.WithKeys(" "))):
 			if s.focused == prepFocusQuests {
 				item, ok := s.questList.SelectedItem().(QuestListItem)
-				if ok && item.Type == player.TypeFocus {
+				if ok && item.Type == player.TypeFocus && !item.Blocked() {
 					if _, exists := s.selectedQuests[item.ID]; exists {
 						delete(s.selectedQuests, item.ID)
 					} else {
@@ -177,6 +205,17 @@ func (s *dungeonPrepModel) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 	return s, tea.Batch(cmds...)
 }
 
+// selectedQuestIDs возвращает выбранные квесты в порядке списка квестов.
+func (s *dungeonPrepModel) selectedQuestIDs() []string {
+	var ids []string
+	for _, q := range s.allQuests {
+		if _, ok := s.selectedQuests[q.ID]; ok {
+			ids = append(ids, q.ID)
+		}
+	}
+	return ids
+}
+
 func (s *dungeonPrepModel) View(m *Model) string {
 	// Set the size of the lists before rendering
 	availableWidth := m.TerminalWidth - 4  // Account for padding
@@ -200,9 +239,9 @@ func (s *dungeonPrepModel) View(m *Model) string {
 		questStyle = m.styles.QuestCardStyle
 	}
 
-	button := "[ Начать ]"
+	button := i18n.T("tui.prep.start")
 	if s.focused == prepFocusButton {
-		button = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Render("> Начать <")
+		button = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Render(i18n.T("tui.prep.start_focused"))
 	}
 
 	lists := lipgloss.JoinHorizontal(lipgloss.Top,
@@ -214,7 +253,7 @@ func (s *dungeonPrepModel) View(m *Model) string {
 	if s.statusMessage != "" {
 		bottomContent = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(s.statusMessage)
 	} else {
-		bottomContent = m.styles.StatusMessageStyle.Render("tab: сменить фокус | space: выбрать квест | enter: начать | q/esc: назад")
+		bottomContent = m.styles.StatusMessageStyle.Render(i18n.T("tui.prep.help"))
 	}
 
 	mainContent := lipgloss.JoinVertical(lipgloss.Center,
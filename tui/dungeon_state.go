@@ -1,7 +1,7 @@
 package tui
 
 import (
-	"fmt"
+	"magus/i18n"
 	"magus/player"
 	"math/rand"
 	"time"
@@ -33,9 +33,10 @@ type dungeonModel struct {
 	duration           time.Duration
 	distractionAttacks int // Количество симулированных атак на концентрацию
 	isConfirmingExit   bool
+	questIDs           []string // Квесты, над которыми идет работа
 }
 
-func NewDungeonState(m *Model, duration time.Duration) State {
+func NewDungeonState(m *Model, duration time.Duration, questIDs []string) State {
 	p := *m.Player // Создаем копию, чтобы не менять глобальное состояние до конца сессии
 
 	return &dungeonModel{
@@ -44,6 +45,7 @@ func NewDungeonState(m *Model, duration time.Duration) State {
 		duration:           duration,
 		distractionAttacks: 0,
 		isConfirmingExit:   false,
+		questIDs:           questIDs,
 	}
 }
 
@@ -64,6 +66,7 @@ func (s *dungeonModel) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 					Duration:           s.duration - s.timer.Timeout, // Фиксируем, сколько времени прошло
 					DistractionAttacks: s.distractionAttacks,
 					Success:            false, // Сессия не была успешной
+					QuestIDs:           s.questIDs,
 				}
 				return NewDungeonSummaryState(m, result), nil
 			case "n", "N", "esc":
@@ -80,18 +83,14 @@ func (s *dungeonModel) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 			Duration:           s.duration,
 			DistractionAttacks: s.distractionAttacks,
 			Success:            true,
+			QuestIDs:           s.questIDs,
 		}
 		return NewDungeonSummaryState(m, result), nil
 
 	case distractionTickMsg:
 		// Логика "атаки-отвлечения"
-		// Шанс на атаку зависит от навыка "Концентрация"
-		focusSkill := s.player.Skills["Концентрация"]
-		// Чем выше навык, тем меньше шанс. Примерная формула:
-		chance := 50 - (focusSkill * 2)
-		if chance < 5 {
-			chance = 5 // Минимальный шанс 5%
-		}
+		// Шанс на атаку снижают «Концентрация», уклонение и класс (см. пакет effects)
+		chance := m.Effects.DistractionChance()
 
 		if rand.Intn(100) < chance {
 			s.distractionAttacks++
@@ -114,9 +113,9 @@ func (s *dungeonModel) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 }
 
 func (s *dungeonModel) View(m *Model) string {
-	timerView := lipgloss.NewStyle().Bold(true).Render("Осталось времени: ", s.timer.View())
-	focusMessage := "Вы в подземелье. Сконцентрируйтесь на задаче."
-	attacksView := fmt.Sprintf("Атаки на концентрацию: %d", s.distractionAttacks)
+	timerView := lipgloss.NewStyle().Bold(true).Render(i18n.T("tui.dungeon.time_left"), s.timer.View())
+	focusMessage := i18n.T("tui.dungeon.focus")
+	attacksView := i18n.T("tui.dungeon.attacks", s.distractionAttacks)
 
 	mainView := lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -125,15 +124,15 @@ func (s *dungeonModel) View(m *Model) string {
 		focusMessage,
 		attacksView,
 		"\n\n",
-		m.styles.StatusMessageStyle.Render("Нажмите 'q' или 'esc' для досрочного завершения."),
+		m.styles.StatusMessageStyle.Render(i18n.T("tui.dungeon.help")),
 	)
 
 	if s.isConfirmingExit {
 		dialogBox := m.styles.QuestCardStyle.Copy().BorderForeground(lipgloss.Color("202")).Render(
 			lipgloss.JoinVertical(
 				lipgloss.Center,
-				"Вы уверены, что хотите прервать фокус-сессию?",
-				"Прогресс не будет засчитан.",
+				i18n.T("tui.dungeon.confirm_exit"),
+				i18n.T("tui.dungeon.confirm_exit_note"),
 				"\n(y/n)",
 			),
 		)
@@ -1,7 +1,11 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
+	"magus/dungeon"
+	"magus/game"
+	"magus/i18n"
 	"magus/player"
 	"magus/storage"
 	"strconv"
@@ -18,8 +22,10 @@ type summaryFocusable int
 
 const (
 	focusDistInput summaryFocusable = iota
+	focusSplitInputs
 	focusReflectionArea
 	focusSummaryButton
+	summaryFocusCount
 )
 
 type dungeonSummaryModel struct {
@@ -27,6 +33,10 @@ type dungeonSummaryModel struct {
 	distInput      textinput.Model
 	reflectionArea textarea.Model
 	focused        summaryFocusable
+	quests         []player.Quest    // Квесты сессии
+	splitInputs    []textinput.Model // Минуты по квестам, если квестов несколько
+	splitFocus     int               // Активное поле среди splitInputs
+	errMsg         string
 }
 
 func NewDungeonSummaryState(m *Model, result DungeonResult) State {
@@ -38,15 +48,40 @@ func NewDungeonSummaryState(m *Model, result DungeonResult) State {
 	distInput.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 	
 	reflectionArea := textarea.New()
-	reflectionArea.Placeholder = "Что было сделано? Какие возникли трудности?"
+	reflectionArea.Placeholder = i18n.T("tui.summary.reflection_placeholder")
 	reflectionArea.SetHeight(5) // Высота фиксирована
 
-	return &dungeonSummaryModel{
+	s := &dungeonSummaryModel{
 		result:         result,
 		distInput:      distInput,
 		reflectionArea: reflectionArea,
 		focused:        focusDistInput,
 	}
+	for _, id := range result.QuestIDs {
+		for _, q := range m.Quests {
+			if q.ID == id {
+				s.quests = append(s.quests, q)
+			}
+		}
+	}
+
+	// Время нескольких квестов можно поделить вручную, по умолчанию — поровну
+	if len(s.quests) > 1 {
+		for _, minutes := range dungeon.Split(s.minutes(), len(s.quests)) {
+			input := textinput.New()
+			input.SetValue(strconv.Itoa(minutes))
+			input.CharLimit = 4
+			input.Width = 5
+			input.PromptStyle = distInput.PromptStyle
+			s.splitInputs = append(s.splitInputs, input)
+		}
+	}
+	return s
+}
+
+// minutes возвращает длительность сессии в целых минутах.
+func (s *dungeonSummaryModel) minutes() int {
+	return int(s.result.Duration.Minutes())
 }
 
 func (s *dungeonSummaryModel) Init() tea.Cmd {
@@ -63,12 +98,10 @@ func (s *dungeonSummaryModel) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("q", "esc"))):
 			return NewHomepageState(m), nil
 		case key.Matches(msg, key.NewBinding(key.WithKeys("tab"))):
-			s.focused = (s.focused + 1) % 3
-			s.updateFocus()
+			s.moveFocus(1)
 			return s, nil
 		case key.Matches(msg, key.NewBinding(key.WithKeys("shift+tab"))):
-			s.focused = (s.focused - 1 + 3) % 3
-			s.updateFocus()
+			s.moveFocus(-1)
 			return s, nil
 		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
 			if s.focused == focusSummaryButton {
@@ -80,6 +113,9 @@ func (s *dungeonSummaryModel) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 	if s.focused == focusDistInput {
 		s.distInput, cmd = s.distInput.Update(msg)
 		cmds = append(cmds, cmd)
+	} else if s.focused == focusSplitInputs {
+		s.splitInputs[s.splitFocus], cmd = s.splitInputs[s.splitFocus].Update(msg)
+		cmds = append(cmds, cmd)
 	} else if s.focused == focusReflectionArea {
 		s.reflectionArea, cmd = s.reflectionArea.Update(msg)
 		cmds = append(cmds, cmd)
@@ -88,57 +124,190 @@ func (s *dungeonSummaryModel) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 	return s, tea.Batch(cmds...)
 }
 
+// moveFocus переводит фокус вперед (delta = 1) или назад (delta = -1),
+// проходя по каждому полю разделения времени.
+func (s *dungeonSummaryModel) moveFocus(delta int) {
+	if s.focused == focusSplitInputs {
+		next := s.splitFocus + delta
+		if next >= 0 && next < len(s.splitInputs) {
+			s.splitFocus = next
+			s.updateFocus()
+			return
+		}
+	}
+
+	s.focused = (s.focused + summaryFocusable(delta) + summaryFocusCount) % summaryFocusCount
+	if s.focused == focusSplitInputs {
+		if len(s.splitInputs) == 0 {
+			s.focused = (s.focused + summaryFocusable(delta) + summaryFocusCount) % summaryFocusCount
+		} else if delta > 0 {
+			s.splitFocus = 0
+		} else {
+			s.splitFocus = len(s.splitInputs) - 1
+		}
+	}
+	s.updateFocus()
+}
+
 func (s *dungeonSummaryModel) updateFocus() {
-	if s.focused == focusDistInput {
+	s.distInput.Blur()
+	s.reflectionArea.Blur()
+	for i := range s.splitInputs {
+		s.splitInputs[i].Blur()
+	}
+
+	switch s.focused {
+	case focusDistInput:
 		s.distInput.Focus()
-		s.reflectionArea.Blur()
-	} else if s.focused == focusReflectionArea {
-		s.distInput.Blur()
+	case focusSplitInputs:
+		s.splitInputs[s.splitFocus].Focus()
+	case focusReflectionArea:
 		s.reflectionArea.Focus()
-	} else {
-		s.distInput.Blur()
-		s.reflectionArea.Blur()
 	}
 }
 
-func (s *dungeonSummaryModel) finalizeSession(m *Model) (State, tea.Cmd) {
-	// 1. Рассчитать урон и XP
-	realDistractions, _ := strconv.Atoi(s.distInput.Value())
-	hpLoss := (realDistractions - s.result.DistractionAttacks) * 5
-	if hpLoss < 0 {
-		hpLoss = 0
+// allocations возвращает минуты сессии по квестам. Если время поделено
+// вручную, сумма должна совпадать с длительностью сессии.
+func (s *dungeonSummaryModel) allocations() ([]storage.QuestTime, error) {
+	if len(s.quests) == 1 {
+		return []storage.QuestTime{{QuestID: s.quests[0].ID, Minutes: s.minutes()}}, nil
+	}
+
+	var allocs []storage.QuestTime
+	total := 0
+	for i, input := range s.splitInputs {
+		minutes, err := strconv.Atoi(input.Value())
+		if err != nil || minutes < 0 {
+			return nil, errors.New(i18n.T("tui.summary.split_invalid", s.quests[i].Title))
+		}
+		total += minutes
+		if minutes > 0 {
+			allocs = append(allocs, storage.QuestTime{QuestID: s.quests[i].ID, Minutes: minutes})
+		}
+	}
+	if total != s.minutes() {
+		return nil, errors.New(i18n.T("tui.summary.split_mismatch", total, s.minutes()))
+	}
+	return allocs, nil
+}
+
+// realDistractions возвращает число отвлечений, которое ввел игрок.
+func (s *dungeonSummaryModel) realDistractions() int {
+	n, _ := strconv.Atoi(s.distInput.Value())
+	return n
+}
+
+// session собирает итоги сессии для правил игры.
+func (s *dungeonSummaryModel) session(allocs []storage.QuestTime) game.Session {
+	return game.Session{
+		Duration:     s.result.Duration,
+		Success:      s.result.Success,
+		Attacks:      s.result.DistractionAttacks,
+		Distractions: s.realDistractions(),
+		Quests:       allocs,
+	}
+}
+
+// damageView показывает урон квестам и сколько HP у них останется.
+// Итоги считаются на копии состояния, сами квесты не меняются.
+func (s *dungeonSummaryModel) damageView(m *Model) []string {
+	allocs, err := s.allocations()
+	if err != nil {
+		return nil
+	}
+	p := *m.Player
+	w := &game.World{Player: &p, Quests: append([]player.Quest(nil), m.Quests...), Tags: m.Tags, Effects: m.Effects, Curve: m.settings().Curve()}
+	events := game.FinishSession(w, s.session(allocs), time.Now())
+
+	defeated := make(map[string]bool)
+	for _, e := range events {
+		if e.Kind == game.EventQuestDefeated {
+			defeated[e.Quest.ID] = true
+		}
+	}
+
+	var lines []string
+	for _, e := range events {
+		if e.Kind != game.EventQuestDamaged {
+			continue
+		}
+		q := e.Quest
+		remaining := q.HP - q.Progress
+		if defeated[q.ID] {
+			remaining = 0 // У повторяющегося квеста прогресс уже сброшен
+		}
+		lines = append(lines, fmt.Sprintf("%s %s  %s",
+			RenderProgressBar(remaining, q.HP, 20),
+			i18n.T("tui.summary.damage_hp", remaining, q.HP, e.Amount),
+			q.Title))
+	}
+	if len(lines) == 0 {
+		return nil
 	}
+	return append([]string{"\n" + i18n.T("tui.summary.damage")}, lines...)
+}
 
-	xpGained := int(s.result.Duration.Minutes()) * 2
-	if s.result.Success && realDistractions <= s.result.DistractionAttacks {
-		xpGained += 25 // Бонус за хорошую концентрацию
+func (s *dungeonSummaryModel) finalizeSession(m *Model) (State, tea.Cmd) {
+	allocs, err := s.allocations()
+	if err != nil {
+		s.errMsg = err.Error()
+		return s, nil
 	}
 
-	// 2. Обновить данные игрока
-	p, _ := player.LoadPlayer()
-	p.HP -= hpLoss
-	player.SavePlayer(p)
-	m.Player = p
+	// 1. Подвести итоги по правилам игры: XP, HP, урон квестам
+	now := time.Now()
+	w := &game.World{Player: m.Player, Quests: m.Quests, DayStartHour: m.settings().DayStartHour, Tags: m.Tags, Effects: m.Effects, Curve: m.settings().Curve()}
+	events := game.FinishSession(w, s.session(allocs), now)
+	for _, e := range events {
+		switch e.Kind {
+		case game.EventQuestDefeated:
+			m.Notice += i18n.T("tui.summary.defeated", e.Quest.Title, e.Amount) + "\n"
+		case game.EventParentCompleted:
+			m.Notice += i18n.T("tui.quests.parent_done", e.Quest.Title, e.Amount) + "\n"
+		case game.EventStatTrained:
+			m.Notice += statTrained(e) + "\n"
+		case game.EventEarlyBonus:
+			m.Notice += i18n.T("tui.quests.early_bonus", e.Amount) + "\n"
+		}
+	}
 
-	canLevelUp, _ := player.AddXP(xpGained)
-	if canLevelUp {
-		// TODO: Handle level up
+	// 2. Сохранить квесты и игрока
+	m.Quests = w.Quests
+	if len(allocs) > 0 {
+		storage.SaveAllQuests(m.Quests)
 	}
+	player.SavePlayer(m.Player)
 
 	// 3. Сохранить рефлексию
 	reflection := s.reflectionArea.Value()
 	if reflection != "" {
 		note := storage.ReflectionNote{
-			Date:     time.Now(),
+			Date:     now,
 			Duration: s.result.Duration,
 			Content:  reflection,
-			XPEarned: xpGained,
-			HPLoss:   hpLoss,
+			XPEarned: game.Total(events, game.EventXPGained),
+			HPLoss:   game.Total(events, game.EventHPLost),
 		}
 		storage.SaveReflection(note)
 	}
 
-	// 4. Вернуться на главный экран
+	storage.SaveSession(storage.Session{
+		Date:     now,
+		Duration: s.result.Duration,
+		Success:  s.result.Success,
+		Quests:   allocs,
+	})
+
+	// 4. Новый уровень выбирается сразу, иначе — возвращаемся на главный экран
+	if game.Has(events, game.EventLevelUpReady) {
+		levelUpState, status := NewLevelUpState(m)
+		if levelUpState != nil {
+			return levelUpState, nil
+		}
+		if status != "" {
+			m.Notice += status + "\n"
+		}
+	}
 	return NewHomepageState(m), nil
 }
 
@@ -146,29 +315,34 @@ func (s *dungeonSummaryModel) View(m *Model) string {
 	// Set width before rendering
 	s.reflectionArea.SetWidth(m.TerminalWidth - m.styles.QuestCardStyle.GetHorizontalFrameSize()*2 - 4)
 
-	title := m.styles.TitleStyle.Render("Отчет о сессии")
+	title := m.styles.TitleStyle.Render(i18n.T("tui.summary.title"))
 
-	stats := fmt.Sprintf("Длительность: %s\nАтаки на концентрацию: %d",
-		formatDuration(s.result.Duration), s.result.DistractionAttacks)
+	stats := i18n.T("tui.summary.stats", formatDuration(s.result.Duration), s.result.DistractionAttacks)
 
-	distractionPrompt := "Сколько раз вы отвлеклись на самом деле?"
+	distractionPrompt := i18n.T("tui.summary.distraction_prompt")
 
-	button := "[ Завершить ]"
+	button := i18n.T("tui.summary.finish")
 	if s.focused == focusSummaryButton {
-		button = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Render("> Завершить <")
-	}
-
-	content := lipgloss.JoinVertical(lipgloss.Left,
-		title,
-		stats,
-		"\n",
-		distractionPrompt,
-		s.distInput.View(),
-		"\nЗаметки о сессии:",
-		s.reflectionArea.View(),
-		"\n",
-		button,
-	)
+		button = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Render(i18n.T("tui.summary.finish_focused"))
+	}
+
+	parts := []string{title, stats, "\n", distractionPrompt, s.distInput.View()}
+	switch {
+	case len(s.splitInputs) > 0:
+		parts = append(parts, "\n"+i18n.T("tui.summary.split", s.minutes()))
+		for i, input := range s.splitInputs {
+			parts = append(parts, fmt.Sprintf("%s %s", input.View(), s.quests[i].Title))
+		}
+	case len(s.quests) == 1:
+		parts = append(parts, "\n"+i18n.T("tui.summary.quest_time", s.minutes(), s.quests[0].Title))
+	}
+	parts = append(parts, s.damageView(m)...)
+	parts = append(parts, "\n"+i18n.T("tui.summary.notes"), s.reflectionArea.View(), "\n", button)
+	if s.errMsg != "" {
+		parts = append(parts, m.styles.DeadlineStyle.Render(s.errMsg))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, parts...)
 
 	return lipgloss.Place(
 		m.TerminalWidth, m.TerminalHeight,
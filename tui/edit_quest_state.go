@@ -2,12 +2,17 @@ package tui
 
 import (
 	"fmt"
+	"magus/deadline"
+	"magus/i18n"
 	"magus/player"
+	"magus/recur"
 	"magus/storage"
+	"magus/tags"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -16,14 +21,49 @@ import (
 type EditQuestState struct {
 	questToEdit player.Quest
 	inputs      []textinput.Model
+	description textarea.Model
+	note        textarea.Model // Новая заметка; добавляется с текущей датой
 	focusIndex  int
+	errMsg      string
+}
+
+// Поля формы после текстовых: описание и новая заметка.
+const (
+	editFocusDescription = 6
+	editFocusNote        = 7
+	editFocusCount       = 8
+)
+
+// newDescriptionArea создает многострочное поле для описания или заметки.
+func newDescriptionArea(placeholder string) textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = placeholder
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetWidth(60)
+	ta.SetHeight(4)
+	return ta
+}
+
+// parseLinks разбирает ссылки, разделенные запятыми или переводами строк.
+func parseLinks(s string) []string {
+	var links []string
+	for _, link := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if link = strings.TrimSpace(link); link != "" {
+			links = append(links, link)
+		}
+	}
+	return links
 }
 
 func NewEditQuestState(m *Model, quest player.Quest) *EditQuestState {
 	s := &EditQuestState{
 		questToEdit: quest,
-		inputs:      make([]textinput.Model, 4), // Title, XP, Tags, Deadline
+		inputs:      make([]textinput.Model, 6), // Title, XP, Tags, Deadline, Recurrence, Links
+		description: newDescriptionArea(i18n.T("tui.add.placeholder_description")),
+		note:        newDescriptionArea(i18n.T("tui.edit.placeholder_note")),
 	}
+	s.description.SetValue(quest.Description)
 
 	var t textinput.Model
 
@@ -47,15 +87,29 @@ func NewEditQuestState(m *Model, quest player.Quest) *EditQuestState {
 
 	t = textinput.New()
 	t.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
-	t.CharLimit = 10
+	t.CharLimit = 16
 	deadlineStr := ""
 	if s.questToEdit.Deadline != nil && !s.questToEdit.Deadline.IsZero() {
-		deadlineStr = s.questToEdit.Deadline.Format("2006-01-02")
+		deadlineStr = deadline.Format(*s.questToEdit.Deadline, s.questToEdit.DeadlineTime)
 	}
 	t.SetValue(deadlineStr)
-	t.Placeholder = "YYYY-MM-DD"
+	t.Placeholder = i18n.T("tui.add.placeholder_deadline")
 	s.inputs[3] = t
 
+	t = textinput.New()
+	t.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
+	t.CharLimit = 100
+	t.SetValue(s.questToEdit.Recurrence)
+	t.Placeholder = i18n.T("tui.add.placeholder_recurrence")
+	s.inputs[4] = t
+
+	t = textinput.New()
+	t.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
+	t.CharLimit = 500
+	t.SetValue(strings.Join(s.questToEdit.Links, ", "))
+	t.Placeholder = i18n.T("tui.add.placeholder_links")
+	s.inputs[5] = t
+
 	s.inputs[s.focusIndex].Focus()
 	return s
 }
@@ -66,20 +120,27 @@ func (s *EditQuestState) Init() tea.Cmd {
 
 func (s *EditQuestState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 	if key, ok := msg.(tea.KeyMsg); ok {
+		// В многострочных полях enter и стрелки работают внутри текста
+		inArea := s.focusIndex >= editFocusDescription
 		switch key.String() {
 		case "ctrl+c", "esc":
 			return PopState{}, nil
+		case "ctrl+s":
+			return s.saveChanges(m)
 		case "tab", "shift+tab", "up", "down":
+			if inArea && (key.String() == "up" || key.String() == "down") {
+				break
+			}
 			if key.String() == "up" || key.String() == "shift+tab" {
 				s.focusIndex--
 			} else {
 				s.focusIndex++
 			}
 
-			if s.focusIndex > len(s.inputs)-1 {
+			if s.focusIndex > editFocusCount-1 {
 				s.focusIndex = 0
 			} else if s.focusIndex < 0 {
-				s.focusIndex = len(s.inputs) - 1
+				s.focusIndex = editFocusCount - 1
 			}
 
 			cmds := make([]tea.Cmd, len(s.inputs))
@@ -90,9 +151,19 @@ func (s *EditQuestState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 				}
 				s.inputs[i].Blur()
 			}
+			s.description.Blur()
+			s.note.Blur()
+			switch s.focusIndex {
+			case editFocusDescription:
+				cmds = append(cmds, s.description.Focus())
+			case editFocusNote:
+				cmds = append(cmds, s.note.Focus())
+			}
 			return s, tea.Batch(cmds...)
 		case "enter":
-			return s.saveChanges(m)
+			if !inArea {
+				return s.saveChanges(m)
+			}
 		}
 	}
 
@@ -102,9 +173,9 @@ func (s *EditQuestState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 
 func (s *EditQuestState) View(m *Model) string {
 	var b strings.Builder
-	b.WriteString(m.styles.TitleStyle.Render("Редактирование квеста") + "\n\n")
+	b.WriteString(m.styles.TitleStyle.Render(i18n.T("tui.edit.title")) + "\n\n")
 
-	b.WriteString("Название\n")
+	b.WriteString(i18n.T("tui.field.title") + "\n")
 	b.WriteString(s.inputs[0].View())
 	b.WriteString("\n\n")
 
@@ -112,15 +183,35 @@ func (s *EditQuestState) View(m *Model) string {
 	b.WriteString(s.inputs[1].View())
 	b.WriteString("\n\n")
 
-	b.WriteString("Теги (через запятую)\n")
+	b.WriteString(i18n.T("tui.field.tags") + "\n")
 	b.WriteString(s.inputs[2].View())
 	b.WriteString("\n\n")
 
-	b.WriteString("Дедлайн (YYYY-MM-DD)\n")
+	b.WriteString(i18n.T("tui.field.deadline") + "\n")
 	b.WriteString(s.inputs[3].View())
 	b.WriteString("\n\n")
 
-	b.WriteString(m.styles.FaintQuestCardStyle.Render("Enter - сохранить, Esc - отмена"))
+	b.WriteString(i18n.T("tui.field.recurrence") + "\n")
+	b.WriteString(s.inputs[4].View())
+	b.WriteString("\n\n")
+
+	b.WriteString(i18n.T("tui.field.links") + "\n")
+	b.WriteString(s.inputs[5].View())
+	b.WriteString("\n\n")
+
+	b.WriteString(i18n.T("tui.field.description") + "\n")
+	b.WriteString(s.description.View())
+	b.WriteString("\n\n")
+
+	b.WriteString(i18n.T("tui.field.note", len(s.questToEdit.Notes)) + "\n")
+	b.WriteString(s.note.View())
+	b.WriteString("\n\n")
+
+	if s.errMsg != "" {
+		b.WriteString(m.styles.DeadlineStyle.Render(s.errMsg) + "\n\n")
+	}
+
+	b.WriteString(m.styles.FaintQuestCardStyle.Render(i18n.T("tui.edit.help")))
 	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
 }
 
@@ -129,34 +220,60 @@ func (s *EditQuestState) updateInputs(msg tea.Msg) tea.Cmd {
 	for i := range s.inputs {
 		s.inputs[i], cmds[i] = s.inputs[i].Update(msg)
 	}
+	var cmd tea.Cmd
+	s.description, cmd = s.description.Update(msg)
+	cmds = append(cmds, cmd)
+	s.note, cmd = s.note.Update(msg)
+	cmds = append(cmds, cmd)
 	return tea.Batch(cmds...)
 }
 
 func (s *EditQuestState) saveChanges(m *Model) (State, tea.Cmd) {
+	var recurrence string
+	if every := strings.TrimSpace(s.inputs[4].Value()); every != "" {
+		rule, err := recur.Parse(every)
+		if err != nil {
+			s.errMsg = i18n.T("tui.add.err_recurrence", err)
+			return s, nil
+		}
+		recurrence = rule.String()
+	}
+	var due *time.Time
+	var timed bool
+	if deadlineStr := strings.TrimSpace(s.inputs[3].Value()); deadlineStr != "" {
+		dl, hasTime, err := deadline.Parse(deadlineStr)
+		if err != nil {
+			s.errMsg = i18n.T("tui.add.err_deadline", deadlineStr)
+			return s, nil
+		}
+		due, timed = &dl, hasTime
+	}
+
 	for i, q := range m.Quests {
 		if q.ID == s.questToEdit.ID {
 			m.Quests[i].Title = s.inputs[0].Value()
 			xp, _ := strconv.Atoi(s.inputs[1].Value())
 			m.Quests[i].XP = xp
 
-			tagsStr := s.inputs[2].Value()
-			if tagsStr == "" {
-				m.Quests[i].Tags = []string{}
-			} else {
-				m.Quests[i].Tags = strings.Split(tagsStr, ",")
-				for j, tag := range m.Quests[i].Tags {
-					m.Quests[i].Tags[j] = strings.TrimSpace(tag)
-				}
+			m.Quests[i].Tags = tags.Parse(s.inputs[2].Value())
+
+			m.Quests[i].Deadline = due
+			m.Quests[i].DeadlineTime = timed
+
+			m.Quests[i].Links = parseLinks(s.inputs[5].Value())
+			m.Quests[i].Description = strings.TrimSpace(s.description.Value())
+			if note := strings.TrimSpace(s.note.Value()); note != "" {
+				m.Quests[i].Notes = append(m.Quests[i].Notes, player.QuestNote{Date: time.Now(), Text: note})
 			}
 
-			deadlineStr := s.inputs[3].Value()
-			if deadlineStr != "" {
-				dl, err := time.Parse("2006-01-02", deadlineStr)
-				if err == nil {
-					m.Quests[i].Deadline = &dl
+			// Смена расписания пересчитывает срок текущего экземпляра
+			if recurrence != m.Quests[i].Recurrence {
+				m.Quests[i].Recurrence = recurrence
+				if rule, ok := recur.RuleOf(m.Quests[i]); ok && due == nil {
+					due := rule.First(time.Now())
+					m.Quests[i].Deadline = &due
+					m.Quests[i].DeadlineTime = false
 				}
-			} else {
-				m.Quests[i].Deadline = nil
 			}
 			break
 		}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"fmt"
+	"magus/effects"
+	"magus/i18n"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// EffectsState — характеристики игрока с разбором: какой навык, ранг
+// или класс дает каждую надбавку.
+type EffectsState struct{}
+
+func NewEffectsState(m *Model) State {
+	m.refreshEffects()
+	return &EffectsState{}
+}
+
+func (s *EffectsState) Init() tea.Cmd {
+	return nil
+}
+
+func (s *EffectsState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "q", "esc", "enter":
+			return PopState{}, nil
+		}
+	}
+	return s, nil
+}
+
+func (s *EffectsState) View(m *Model) string {
+	var b strings.Builder
+	b.WriteString(m.styles.TitleStyle.Render(i18n.T("tui.effects.title")) + "\n\n")
+
+	for _, stat := range effects.Stats {
+		b.WriteString(fmt.Sprintf("%s: %s\n", effects.StatName(stat), m.styles.MetaStyle.Render(m.Effects.Value(stat))))
+		breakdown := m.Effects.Breakdown(stat)
+		if len(breakdown) == 0 {
+			b.WriteString("    " + m.styles.StatusMessageStyle.Render(i18n.T("tui.effects.base")) + "\n")
+		}
+		for _, c := range breakdown {
+			b.WriteString(fmt.Sprintf("    %s  %s\n", m.styles.DifficultyStyle.Render(effects.Format(c.Stat, c.Value)), c.Source))
+		}
+	}
+
+	b.WriteString("\n" + m.styles.StatusMessageStyle.Render(i18n.T("tui.effects.help")))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
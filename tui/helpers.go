@@ -2,6 +2,9 @@ package tui
 
 import (
 	"fmt"
+	"magus/deadline"
+	"magus/i18n"
+	"magus/player"
 	"strings"
 	"time"
 
@@ -86,16 +89,25 @@ func ansiGradient(text string, startRGB, endRGB [3]uint8) string {
 	return builder.String()
 }
 
-func deadlineStatus(deadline *time.Time) string {
-	if deadline == nil {
+// deadlineStatus показывает, сколько осталось до срока: в днях, а в
+// последние сутки — в часах и минутах.
+func deadlineStatus(q player.Quest) string {
+	if q.Deadline == nil {
 		return ""
 	}
-	remaining := time.Until(*deadline)
-	if remaining < 0 {
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("(Просрочено)")
+	remaining := time.Until(deadline.Due(*q.Deadline, q.DeadlineTime))
+	if remaining <= 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(i18n.T("tui.deadline.overdue"))
+	}
+	minutes := int((remaining + time.Minute - 1) / time.Minute) // Неполная минута считается целой
+	switch {
+	case minutes < 60:
+		return i18n.T("tui.deadline.minutes_left", minutes)
+	case remaining < 24*time.Hour:
+		return i18n.T("tui.deadline.hours_left", minutes/60, minutes%60)
 	}
 	days := int(remaining.Hours() / 24)
-	return fmt.Sprintf("(осталось %d д)", days)
+	return i18n.T("tui.deadline.days_left", days)
 }
 
 func formatDuration(d time.Duration) string {
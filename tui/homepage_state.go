@@ -2,7 +2,10 @@ package tui
 
 import (
 	"fmt"
+	"magus/dungeon"
+	"magus/i18n"
 	"magus/player"
+	"magus/rpg"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/progress"
@@ -11,6 +14,26 @@ import (
 	"github.com/mattn/go-runewidth"
 )
 
+// homeMenuItem — пункт меню главного экрана.
+type homeMenuItem struct {
+	label string               // Ключ каталога сообщений
+	open  func(m *Model) State // nil означает выход из программы
+}
+
+func homeMenu() []homeMenuItem {
+	return []homeMenuItem{
+		{"tui.home.menu_quests", func(m *Model) State { return NewQuestsState(m) }},
+		{"tui.home.menu_agenda", func(m *Model) State { return NewAgendaState(m) }},
+		{"tui.home.menu_upcoming", func(m *Model) State { return NewUpcomingState(m) }},
+		{"tui.home.menu_skills", NewSkillsState},
+		{"tui.home.menu_effects", NewEffectsState},
+		{"tui.home.menu_dungeon", NewDungeonPrepState},
+		{"tui.home.menu_search", func(m *Model) State { return NewSearchState(m) }},
+		{"tui.home.menu_journal", func(m *Model) State { return NewJournalState(m, -1) }},
+		{"tui.home.menu_exit", nil},
+	}
+}
+
 // HomepageState представляет собой состояние главного экрана.
 type HomepageState struct {
 	cursor      int
@@ -45,20 +68,18 @@ func (s *HomepageState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 				s.cursor--
 			}
 		case "down", "j":
-			if s.cursor < 3 { // 4 пункта меню: 0, 1, 2, 3
+			if s.cursor < len(homeMenu())-1 {
 				s.cursor++
 			}
 		case "enter":
-			switch s.cursor {
-			case 0:
-				return NewQuestsState(m), nil
-			case 1:
-				return NewSkillsState(m), nil
-			case 2:
-				return NewDungeonPrepState(m), nil
-			case 3:
+			m.Notice = "" // Сообщение показано, дальше оно не нужно
+			item := homeMenu()[s.cursor]
+			if item.open == nil {
 				return s, tea.Quit
 			}
+			return item.open(m), nil
+		case "/":
+			return NewSearchState(m), nil
 		case "q", "esc":
 			return s, tea.Quit
 		}
@@ -77,13 +98,14 @@ func (s *HomepageState) playerInfoView(p *player.Player) string {
 		icon string
 		text string
 	}{
-		{"🧙", fmt.Sprintf("Игрок: %s (Уровень: %d)", p.Name, p.Level)},
-		{"🛡", fmt.Sprintf("Класс: %s", p.Class)},
-		{"❤", fmt.Sprintf("HP: %d / %d", p.HP, p.MaxHP)},
-		{"💧", fmt.Sprintf("Мана: %d / %d", p.Mana, p.MaxMana)},
-		{"💰", fmt.Sprintf("Золото: %d", p.Gold)},
-		{"🎁", fmt.Sprintf("Навыки: %d", len(p.UnlockedSkills))},
-		{"✨", fmt.Sprintf("Очки навыков: %d", p.SkillPoints)},
+		{"🧙", i18n.T("tui.home.player", p.Name, p.Level)},
+		{"🛡", i18n.T("tui.home.class", rpg.ClassName(p.Class))},
+		{"❤", i18n.T("tui.home.hp", p.HP, p.MaxHP)},
+		{"💧", i18n.T("tui.home.mana", p.Mana, p.MaxMana)},
+		{"💰", i18n.T("tui.home.gold", p.Gold)},
+		{"🎁", i18n.T("tui.home.skills", len(p.UnlockedSkills))},
+		{"✨", i18n.T("tui.home.skill_points", p.SkillPoints)},
+		{"⏱", i18n.T("tui.home.focus_time", dungeon.FormatMinutes(p.History.FocusMinutes))},
 	}
 
 	var iconLines []string
@@ -98,7 +120,8 @@ func (s *HomepageState) playerInfoView(p *player.Player) string {
 	for _, line := range lines {
 		if (line.icon == "🛡" && p.Class == player.ClassNone) ||
 			(line.icon == "🎁" && len(p.UnlockedSkills) == 0) ||
-			(line.icon == "✨" && p.SkillPoints == 0) {
+			(line.icon == "✨" && p.SkillPoints == 0) ||
+			(line.icon == "⏱" && p.History.FocusMinutes == 0) {
 			continue
 		}
 		padding := strings.Repeat(" ", maxIconWidth-runewidth.StringWidth(line.icon))
@@ -110,7 +133,7 @@ func (s *HomepageState) playerInfoView(p *player.Player) string {
 	textsBlock := lipgloss.JoinVertical(lipgloss.Left, textLines...)
 	playerStats := lipgloss.JoinHorizontal(lipgloss.Top, iconsBlock, " ", textsBlock)
 
-	xpText := fmt.Sprintf("📈 XP: %d / %d", p.XP, p.NextLevelXP)
+	xpText := i18n.T("tui.home.xp", p.XP, p.NextLevelXP)
 	xpBlock := lipgloss.JoinHorizontal(lipgloss.Bottom,
 		lipgloss.NewStyle().PaddingRight(1).Render(xpText),
 		s.progressBar.ViewAs(float64(p.XP)/float64(p.NextLevelXP)),
@@ -124,13 +147,12 @@ func (s *HomepageState) View(m *Model) string {
 	playerInfoBox := s.playerInfoView(m.Player)
 
 	var menuLines []string
-	menuItems := []string{"Активные квесты", "Дерево навыков", "Отправиться в данж", "Выход"}
-	for i, item := range menuItems {
+	for i, item := range homeMenu() {
 		cursor := " "
 		if s.cursor == i {
 			cursor = ">"
 		}
-		menuLines = append(menuLines, fmt.Sprintf("%s %s", cursor, item))
+		menuLines = append(menuLines, fmt.Sprintf("%s %s", cursor, i18n.T(item.label)))
 	}
 	menuContent := strings.Join(menuLines, "\n")
 	menuBox := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("205")).Padding(1, 2).Render(menuContent)
@@ -149,7 +171,11 @@ func (s *HomepageState) View(m *Model) string {
 `
 	ui := lipgloss.JoinHorizontal(lipgloss.Top, playerInfoBox, menuBox)
 	artBox := lipgloss.NewStyle().Align(lipgloss.Center).Width(m.TerminalWidth).PaddingTop(1).Render(ansiGradient(art, [3]uint8{255, 0, 255}, [3]uint8{0, 0, 255}))
-	
-	// TODO: Добавить статусное сообщение из глобальной модели
-	return lipgloss.JoinVertical(lipgloss.Left, artBox, lipgloss.PlaceHorizontal(m.TerminalWidth, lipgloss.Center, ui))
+
+	view := lipgloss.JoinVertical(lipgloss.Left, artBox, lipgloss.PlaceHorizontal(m.TerminalWidth, lipgloss.Center, ui))
+	if m.Notice != "" {
+		notice := m.styles.StatusMessageStyle.Render(strings.TrimRight(m.Notice, "\n"))
+		view = lipgloss.JoinVertical(lipgloss.Left, view, "", lipgloss.PlaceHorizontal(m.TerminalWidth, lipgloss.Center, notice))
+	}
+	return view
 }
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"magus/i18n"
+	"magus/storage"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// JournalState — экран журнала рефлексии после фокус-сессий.
+type JournalState struct {
+	notes  []storage.ReflectionNote
+	cursor int
+}
+
+// NewJournalState открывает журнал на записи с индексом selected.
+func NewJournalState(m *Model, selected int) *JournalState {
+	notes, _ := storage.LoadReflections()
+	if selected < 0 || selected >= len(notes) {
+		selected = len(notes) - 1
+	}
+	if selected < 0 {
+		selected = 0
+	}
+	return &JournalState{notes: notes, cursor: selected}
+}
+
+func (s *JournalState) Init() tea.Cmd {
+	return nil
+}
+
+func (s *JournalState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "up", "k":
+			if s.cursor > 0 {
+				s.cursor--
+			}
+		case "down", "j":
+			if s.cursor < len(s.notes)-1 {
+				s.cursor++
+			}
+		case "q", "esc":
+			return PopState{}, nil
+		}
+	}
+	return s, nil
+}
+
+func (s *JournalState) View(m *Model) string {
+	var b strings.Builder
+	b.WriteString(m.styles.TitleStyle.Render(i18n.T("tui.journal.title")) + "\n\n")
+
+	if len(s.notes) == 0 {
+		b.WriteString(i18n.T("tui.journal.empty") + "\n")
+		b.WriteString("\n" + m.styles.StatusMessageStyle.Render(i18n.T("tui.journal.help")))
+		return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+	}
+
+	var entries []string
+	for i, n := range s.notes {
+		cursor := " "
+		style := lipgloss.NewStyle()
+		if i == s.cursor {
+			cursor = ">"
+			style = style.Foreground(lipgloss.Color("205"))
+		}
+		entries = append(entries, style.Render(fmt.Sprintf("%s %s", cursor, n.Date.Format("2006-01-02 15:04"))))
+	}
+	listBox := m.styles.QuestCardStyle.Render(strings.Join(entries, "\n"))
+
+	note := s.notes[s.cursor]
+	meta := i18n.T("tui.journal.meta", formatDuration(note.Duration), note.XPEarned, note.HPLoss)
+	contentWidth := Max(m.TerminalWidth-lipgloss.Width(listBox)-8, 20)
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		m.styles.DifficultyStyle.Render(meta),
+		"",
+		lipgloss.NewStyle().Width(contentWidth).Render(note.Content),
+	)
+	noteBox := m.styles.QuestCardStyle.Render(content)
+
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, listBox, " ", noteBox))
+	b.WriteString("\n\n" + m.styles.StatusMessageStyle.Render(i18n.T("tui.journal.help")))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
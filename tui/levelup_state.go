@@ -2,48 +2,76 @@ package tui
 
 import (
 	"fmt"
+	"magus/game"
+	"magus/i18n"
 	"magus/player"
 	"magus/rpg"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// LevelUpState — выбор навыка на новом уровне. Когда опыта хватает
+// на несколько уровней, экран показывается для каждого по очереди.
 type LevelUpState struct {
 	skillChoices []player.SkillNode
+	skills       map[string]player.SkillNode
 	cursor       int
 }
 
-func NewLevelUpState(m *Model) (*LevelUpState, error) {
-	s := &LevelUpState{}
+// NewLevelUpState повышает уровень на один и показывает экран нового
+// уровня. Экран показывается для каждого уровня, на который хватает опыта,
+// даже если на нем изучить нечего. Если навыков нет ни на одном из новых
+// уровней, уровни повышаются сразу, а вместо экрана возвращается сообщение
+// для строки статуса.
+func NewLevelUpState(m *Model) (*LevelUpState, string) {
+	if m.Player == nil || game.PendingLevels(&game.World{Player: m.Player, Curve: m.settings().Curve()}) == 0 {
+		return nil, ""
+	}
 	skillTrees, err := rpg.LoadSkillTrees(m.Player)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка загрузки дерева навыков: %w", err)
-	}
-
-	allSkills := make(map[string]player.SkillNode)
-	for id, node := range skillTrees.Common {
-		allSkills[id] = node
+		// Без дерева выбирать не из чего — просто повышаем уровни
+		for m.levelUp() {
+		}
+		return nil, i18n.T("tui.levelup.err_tree", err)
 	}
-	for id, node := range skillTrees.Class {
-		allSkills[id] = node
+	skills := skillTrees.AllSkills()
+	if !skillsAhead(m, skills) {
+		for m.levelUp() {
+		}
+		return nil, i18n.T("tui.quests.level_no_skills")
 	}
+	return nextLevel(m, skills), ""
+}
 
-	for _, node := range allSkills {
-		if rpg.IsSkillAvailable(m.Player, node, allSkills) {
+// nextLevel повышает уровень на один и собирает навыки, доступные на нем.
+func nextLevel(m *Model, skills map[string]player.SkillNode) *LevelUpState {
+	m.levelUp()
+	s := &LevelUpState{skills: skills}
+	for _, node := range s.skills {
+		if rpg.IsSkillAvailable(m.Player, node, s.skills) {
 			s.skillChoices = append(s.skillChoices, node)
 		}
 	}
+	sort.Slice(s.skillChoices, func(i, j int) bool { return s.skillChoices[i].ID < s.skillChoices[j].ID })
+	return s
+}
 
-	if len(s.skillChoices) == 0 {
-		// Если нет доступных навыков, просто повышаем уровень и выходим
-		player.LevelUpPlayer("")
-		player.SavePlayer(m.Player)
-		return nil, fmt.Errorf("нет доступных навыков для изучения")
+// skillsAhead сообщает, что хотя бы на одном из уровней, на которые хватает
+// опыта, найдется навык для изучения. Уровни повышаются на копии игрока.
+func skillsAhead(m *Model, skills map[string]player.SkillNode) bool {
+	p := *m.Player
+	w := &game.World{Player: &p, Effects: m.Effects, Curve: m.settings().Curve()}
+	for game.LevelUp(w) != nil {
+		for _, node := range skills {
+			if rpg.IsSkillAvailable(&p, node, skills) {
+				return true
+			}
+		}
 	}
-
-	return s, nil
+	return false
 }
 
 func (s *LevelUpState) Init() tea.Cmd {
@@ -62,13 +90,17 @@ func (s *LevelUpState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 				s.cursor++
 			}
 		case "enter":
-			chosenSkill := s.skillChoices[s.cursor]
-			player.LevelUpPlayer(chosenSkill.ID)
-			// No need to save here, LevelUpPlayer does it.
-			// We need to reload the player to get the updated state.
-			reloadedPlayer, err := player.LoadPlayer()
-			if err == nil {
-				m.Player = reloadedPlayer
+			// Выбранный навык изучается за очки нового уровня
+			if len(s.skillChoices) > 0 {
+				chosen := s.skillChoices[s.cursor]
+				if _, err := game.UnlockSkill(&game.World{Player: m.Player}, chosen, s.skills); err == nil {
+					player.SavePlayer(m.Player)
+					m.refreshEffects()
+				}
+			}
+			// Следующий уровень из очереди, если опыта хватает еще
+			if game.PendingLevels(&game.World{Player: m.Player, Curve: m.settings().Curve()}) > 0 {
+				return nextLevel(m, s.skills), nil
 			}
 			return NewHomepageState(m), nil
 		}
@@ -78,8 +110,15 @@ func (s *LevelUpState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 
 func (s *LevelUpState) View(m *Model) string {
 	var b strings.Builder
-	b.WriteString("🔥 Поздравляем! Новый уровень!\n\n")
-	b.WriteString("Выберите навык для изучения:\n\n")
+	b.WriteString(i18n.T("tui.levelup.title", m.Player.Level) + "\n\n")
+	if more := game.PendingLevels(&game.World{Player: m.Player, Curve: m.settings().Curve()}); more > 0 {
+		b.WriteString(i18n.T("tui.levelup.more", more) + "\n\n")
+	}
+	if len(s.skillChoices) == 0 {
+		b.WriteString(i18n.T("tui.levelup.no_skills") + "\n\n" + i18n.T("tui.levelup.help_next") + "\n")
+		return lipgloss.NewStyle().Border(lipgloss.DoubleBorder(), true).Padding(2).Render(b.String())
+	}
+	b.WriteString(i18n.T("tui.levelup.prompt") + "\n\n")
 	for i, skill := range s.skillChoices {
 		cursor := " "
 		if s.cursor == i {
@@ -87,6 +126,6 @@ func (s *LevelUpState) View(m *Model) string {
 		}
 		b.WriteString(fmt.Sprintf("%s %s: %s\n", cursor, skill.Name, skill.Description))
 	}
-	b.WriteString("\nНажмите 'enter' для выбора.\n")
+	b.WriteString("\n" + i18n.T("tui.levelup.help") + "\n")
 	return lipgloss.NewStyle().Border(lipgloss.DoubleBorder(), true).Padding(2).Render(b.String())
 }
@@ -1,48 +1,71 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
-	"sort"
+	"slices"
+	"strconv"
 	"strings"
 
+	"magus/i18n"
 	"magus/player"
 	"magus/storage"
+	"magus/tags"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// tagField — настройка тега, которая вводится в поле ввода.
+type tagField int
+
+const (
+	tagFieldNone tagField = iota
+	tagFieldRename
+	tagFieldColor
+	tagFieldMultiplier
+)
+
+// ManageTagsState — дерево тегов со статистикой: переименование, слияние,
+// удаление и настройки тегов (цвет, множитель XP, характеристика).
 type ManageTagsState struct {
-	cursor         int
-	allTags        []string
-	renameTagInput textinput.Model
+	cursor    int
+	archive   []player.Quest // Архив тоже учитывается в статистике и переименованиях
+	usage     []tags.Usage
+	input     textinput.Model
+	editing   tagField
+	mergeFrom string // Тег, который сольется с выбранным следующим
+	message   string
+	errMsg    string
 }
 
 func NewManageTagsState(m *Model) *ManageTagsState {
 	s := &ManageTagsState{}
+	s.archive, _ = storage.LoadArchive()
 	s.buildTagList(m)
 
 	ti := textinput.New()
-	ti.Placeholder = "Новое имя тега"
 	ti.CharLimit = 30
-	s.renameTagInput = ti
+	s.input = ti
 
 	return s
 }
 
+// buildTagList пересчитывает дерево тегов и удерживает курсор в пределах списка.
 func (s *ManageTagsState) buildTagList(m *Model) {
-	tagSet := make(map[string]bool)
-	for _, q := range m.Quests {
-		for _, tag := range q.Tags {
-			tagSet[tag] = true
-		}
+	s.usage = tags.Collect(append(append([]player.Quest(nil), m.Quests...), s.archive...))
+	if s.cursor >= len(s.usage) {
+		s.cursor = max(len(s.usage)-1, 0)
 	}
-	s.allTags = make([]string, 0, len(tagSet))
-	for tag := range tagSet {
-		s.allTags = append(s.allTags, tag)
+}
+
+// selected возвращает тег под курсором.
+func (s *ManageTagsState) selected() (string, bool) {
+	if s.cursor < len(s.usage) {
+		return s.usage[s.cursor].Tag, true
 	}
-	sort.Strings(s.allTags)
+	return "", false
 }
 
 func (s *ManageTagsState) Init() tea.Cmd {
@@ -52,96 +75,248 @@ func (s *ManageTagsState) Init() tea.Cmd {
 func (s *ManageTagsState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 	var cmd tea.Cmd
 
-	if s.renameTagInput.Focused() {
-		if key, ok := msg.(tea.KeyMsg); ok && key.String() == "enter" {
-			oldTag := s.allTags[s.cursor]
-			newTag := s.renameTagInput.Value()
-			if newTag != "" && newTag != oldTag {
-				for i := range m.Quests {
-					for j, tag := range m.Quests[i].Tags {
-						if tag == oldTag {
-							m.Quests[i].Tags[j] = newTag
-						}
-					}
-				}
-				storage.SaveAllQuests(m.Quests)
-				s.buildTagList(m) // Rebuild our own list
+	if s.editing != tagFieldNone {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			switch key.String() {
+			case "enter":
+				s.apply(m)
+				return s, nil
+			case "esc":
+				s.stopEditing()
+				return s, nil
 			}
-			s.renameTagInput.Blur()
-			s.renameTagInput.Reset()
-		} else {
-			s.renameTagInput, cmd = s.renameTagInput.Update(msg)
 		}
+		s.input, cmd = s.input.Update(msg)
 		return s, cmd
 	}
 
-	if key, ok := msg.(tea.KeyMsg); ok {
-		switch key.String() {
-		case "up", "k":
-			if s.cursor > 0 {
-				s.cursor--
-			}
-		case "down", "j":
-			if s.cursor < len(s.allTags)-1 {
-				s.cursor++
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return s, nil
+	}
+	tag, hasTag := s.selected()
+	s.message, s.errMsg = "", ""
+	switch key.String() {
+	case "up", "k":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+	case "down", "j":
+		if s.cursor < len(s.usage)-1 {
+			s.cursor++
+		}
+	case "d":
+		if hasTag {
+			tags.Delete(m.Quests, tag)
+			tags.Delete(s.archive, tag)
+			s.save(m, m.Tags.Delete(tag))
+		}
+	case "r":
+		if hasTag {
+			s.startEditing(tagFieldRename, tag, i18n.T("tui.tags.placeholder"))
+		}
+	case "c":
+		if hasTag {
+			s.startEditing(tagFieldColor, m.Tags[tag].Color, "#E67E22")
+		}
+	case "x":
+		if hasTag {
+			value := ""
+			if mult := m.Tags[tag].XPMultiplier; mult > 0 {
+				value = strconv.FormatFloat(mult, 'g', -1, 64)
 			}
-		case "d":
-			if len(s.allTags) > 0 {
-				tagToDelete := s.allTags[s.cursor]
-				var updatedQuests []player.Quest
-				for _, quest := range m.Quests {
-					var newTags []string
-					for _, tag := range quest.Tags {
-						if tag != tagToDelete {
-							newTags = append(newTags, tag)
-						}
-					}
-					quest.Tags = newTags
-					updatedQuests = append(updatedQuests, quest)
-				}
-				m.Quests = updatedQuests
-				storage.SaveAllQuests(m.Quests)
-				s.buildTagList(m) // Rebuild
-				if s.cursor >= len(s.allTags) && len(s.allTags) > 0 {
-					s.cursor = len(s.allTags) - 1
-				}
+			s.startEditing(tagFieldMultiplier, value, "1.5")
+		}
+	case "s":
+		if hasTag {
+			s.update(m, tag, func(info *tags.Info) { info.Stat = nextStat(info.Stat) })
+		}
+	case "m", "enter":
+		if !hasTag {
+			break
+		}
+		if s.mergeFrom == "" {
+			if key.String() == "m" {
+				s.mergeFrom = tag
 			}
-		case "r":
-			if len(s.allTags) > 0 {
-				s.renameTagInput.Focus()
-				s.renameTagInput.SetValue(s.allTags[s.cursor])
-				s.renameTagInput.CursorEnd()
+			break
+		}
+		s.rename(m, s.mergeFrom, tag)
+		s.mergeFrom = ""
+	case "q", "esc":
+		if s.mergeFrom != "" {
+			s.mergeFrom = ""
+			break
+		}
+		return PopState{}, nil
+	}
+	return s, nil
+}
+
+// startEditing открывает поле ввода для настройки field.
+func (s *ManageTagsState) startEditing(field tagField, value, placeholder string) {
+	s.editing = field
+	s.input.Placeholder = placeholder
+	s.input.SetValue(value)
+	s.input.CursorEnd()
+	s.input.Focus()
+}
+
+func (s *ManageTagsState) stopEditing() {
+	s.editing = tagFieldNone
+	s.input.Blur()
+	s.input.Reset()
+}
+
+// apply применяет значение из поля ввода к тегу под курсором.
+func (s *ManageTagsState) apply(m *Model) {
+	tag, ok := s.selected()
+	if !ok {
+		s.stopEditing()
+		return
+	}
+	value := strings.TrimSpace(s.input.Value())
+	switch s.editing {
+	case tagFieldRename:
+		s.rename(m, tag, value)
+	case tagFieldColor:
+		s.update(m, tag, func(info *tags.Info) { info.Color = value })
+	case tagFieldMultiplier:
+		mult := 0.0
+		if value != "" {
+			var err error
+			mult, err = strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+			if err != nil || mult <= 0 {
+				s.errMsg = i18n.T("tui.tags.err_multiplier", value)
+				return
 			}
-		case "q", "esc":
-			return PopState{}, nil // Signal to pop the state
 		}
+		s.update(m, tag, func(info *tags.Info) { info.XPMultiplier = mult })
 	}
+	s.stopEditing()
+}
 
-	return s, nil
+// rename переименовывает тег old вместе с вложенными тегами. Если тег
+// с новым именем уже есть, теги сливаются.
+func (s *ManageTagsState) rename(m *Model, old, new string) {
+	new = tags.Normalize(new)
+	n, err := tags.Rename(m.Quests, old, new)
+	if errors.Is(err, tags.ErrIntoItself) {
+		s.errMsg = i18n.T("tui.tags.err_into_itself", old, new)
+		return
+	}
+	archived, _ := tags.Rename(s.archive, old, new)
+	n += archived
+	if n == 0 {
+		return
+	}
+	s.save(m, m.Tags.Rename(old, new))
+	s.message = i18n.T("tui.tags.renamed", old, new, n)
+	// Курсор остается на переименованном теге
+	for i, u := range s.usage {
+		if u.Tag == new {
+			s.cursor = i
+		}
+	}
+}
+
+// update меняет собственные настройки тега и сохраняет файл тегов.
+func (s *ManageTagsState) update(m *Model, tag string, change func(*tags.Info)) {
+	if m.Tags == nil {
+		m.Tags = tags.Registry{}
+	}
+	info := m.Tags[tag]
+	change(&info)
+	if info == (tags.Info{}) {
+		delete(m.Tags, tag)
+	} else {
+		m.Tags[tag] = info
+	}
+	if err := tags.Save(m.Tags); err != nil {
+		s.errMsg = err.Error()
+	}
+}
+
+// save сохраняет квесты и, если они изменились, настройки тегов после
+// переименования или удаления.
+func (s *ManageTagsState) save(m *Model, registryChanged bool) {
+	storage.SaveAllQuests(m.Quests)
+	if len(s.archive) > 0 {
+		storage.SaveArchive(s.archive)
+	}
+	if registryChanged {
+		tags.Save(m.Tags)
+	}
+	s.buildTagList(m)
+}
+
+// nextStat возвращает следующую характеристику по кругу; после последней
+// тег перестает тренировать характеристики.
+func nextStat(stat string) string {
+	i := slices.Index(player.StatNames, stat)
+	if i == len(player.StatNames)-1 {
+		return ""
+	}
+	return player.StatNames[i+1]
 }
 
 func (s *ManageTagsState) View(m *Model) string {
 	var b strings.Builder
-	b.WriteString(m.styles.TitleStyle.Render("Управление тегами") + "\n\n")
+	b.WriteString(m.styles.TitleStyle.Render(i18n.T("tui.tags.title")) + "\n\n")
 
-	if len(s.allTags) == 0 {
-		b.WriteString("У вас пока нет тегов.\n")
+	if len(s.usage) == 0 {
+		b.WriteString(i18n.T("tui.tags.empty") + "\n")
 	}
 
-	for i, tag := range s.allTags {
-		cursor := " "
-		style := lipgloss.NewStyle()
+	for i, u := range s.usage {
+		cursor := "  "
 		if s.cursor == i {
-			cursor = ">"
-			style = style.Foreground(lipgloss.Color("205"))
+			cursor = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Render("> ")
+		}
+		info := m.Tags.Lookup(u.Tag)
+		style := m.styles.TagStyle
+		if info.Color != "" {
+			style = style.Foreground(lipgloss.Color(info.Color))
+		}
+		name := u.Tag
+		if parent := tags.Parent(u.Tag); parent != "" {
+			name = u.Tag[len(parent)+1:]
 		}
-		b.WriteString(style.Render(fmt.Sprintf("%s %s\n", cursor, tag)))
+
+		line := fmt.Sprintf("%s%s%s  %s", cursor, strings.Repeat("  ", tags.Depth(u.Tag)), style.Render("#"+name),
+			m.styles.StatusMessageStyle.Render(i18n.T("tui.tags.usage", u.Open, u.Completed, u.XP)))
+		// Показываем только собственные настройки: унаследованные видны у предка
+		own := m.Tags[u.Tag]
+		if own.XPMultiplier > 0 {
+			line += "  " + m.styles.DifficultyStyle.Render(fmt.Sprintf("×%g", own.XPMultiplier))
+		}
+		if own.Stat != "" {
+			line += "  " + m.styles.RitualStyle.Render(i18n.T("tui.tags.trains", i18n.T("stat."+own.Stat)))
+		}
+		if u.Tag == s.mergeFrom {
+			line += "  " + m.styles.MetaStyle.Render(i18n.T("tui.tags.merging"))
+		}
+		b.WriteString(line + "\n")
 	}
 
-	if s.renameTagInput.Focused() {
-		b.WriteString("\nПереименовать в: " + s.renameTagInput.View())
+	switch s.editing {
+	case tagFieldRename:
+		b.WriteString("\n" + i18n.T("tui.tags.rename_to") + s.input.View())
+	case tagFieldColor:
+		b.WriteString("\n" + i18n.T("tui.tags.color_to") + s.input.View())
+	case tagFieldMultiplier:
+		b.WriteString("\n" + i18n.T("tui.tags.multiplier_to") + s.input.View())
+	}
+	if s.errMsg != "" {
+		b.WriteString("\n\n" + m.styles.DeadlineStyle.Render(s.errMsg))
+	} else if s.message != "" {
+		b.WriteString("\n\n" + m.styles.StatusMessageStyle.Render(s.message))
 	}
 
-	b.WriteString("\n\nНавигация: ↑/↓, 'd' - удалить, 'r' - переименовать, 'q' - назад.")
+	help := i18n.T("tui.tags.help")
+	if s.mergeFrom != "" {
+		help = i18n.T("tui.tags.merge_help", s.mergeFrom)
+	}
+	b.WriteString("\n\n" + m.styles.StatusMessageStyle.Render(help))
 	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
 }
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"fmt"
+	"magus/checklist"
+	"magus/i18n"
+	"magus/markdown"
+	"magus/player"
+	"magus/snooze"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// QuestDetailState — подробности квеста: описание в Markdown, заметки и ссылки.
+type QuestDetailState struct {
+	questID  string
+	viewport viewport.Model
+}
+
+func NewQuestDetailState(m *Model, questID string) *QuestDetailState {
+	return &QuestDetailState{questID: questID, viewport: viewport.New(0, 0)}
+}
+
+func (s *QuestDetailState) Init() tea.Cmd {
+	return nil
+}
+
+// quest возвращает квест из общего списка или nil, если его больше нет.
+func (s *QuestDetailState) quest(m *Model) *player.Quest {
+	for i := range m.Quests {
+		if m.Quests[i].ID == s.questID {
+			return &m.Quests[i]
+		}
+	}
+	return nil
+}
+
+func (s *QuestDetailState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
+	q := s.quest(m)
+	if q == nil {
+		return PopState{}, nil
+	}
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "esc", "q":
+			return PopState{}, nil
+		case "e":
+			return NewEditQuestState(m, *q), nil
+		case "x":
+			return NewChecklistState(m, q.ID), nil
+		}
+	}
+
+	var cmd tea.Cmd
+	s.viewport, cmd = s.viewport.Update(msg)
+	return s, cmd
+}
+
+func (s *QuestDetailState) View(m *Model) string {
+	q := s.quest(m)
+	if q == nil {
+		return ""
+	}
+
+	frame := lipgloss.NewStyle().Margin(1, 2)
+	h, v := frame.GetFrameSize()
+	width := m.TerminalWidth - h
+	s.viewport.Width = width
+	s.viewport.Height = m.TerminalHeight - v - 4 // Заголовок и подсказка
+	s.viewport.SetContent(s.content(m, *q, width))
+
+	var b strings.Builder
+	b.WriteString(m.styles.TitleStyle.Render(q.Title) + "\n\n")
+	b.WriteString(s.viewport.View() + "\n\n")
+	b.WriteString(m.styles.StatusMessageStyle.Render(i18n.T("tui.detail.help")))
+	return frame.Render(b.String())
+}
+
+// content собирает текст подробностей квеста шириной width.
+func (s *QuestDetailState) content(m *Model, q player.Quest, width int) string {
+	var b strings.Builder
+
+	meta := []string{i18n.T("quest.type." + string(q.Type))}
+	if q.Type == player.TypeFocus && q.HP > 0 {
+		meta = append(meta, fmt.Sprintf("HP: %d/%d", q.Progress, q.HP))
+	}
+	if q.XP > 0 {
+		meta = append(meta, fmt.Sprintf("XP: %d", q.XP))
+	}
+	if summary := checklist.Summary(q); summary != "" {
+		meta = append(meta, "☑ "+summary)
+	}
+	if len(q.Tags) > 0 {
+		meta = append(meta, "#"+strings.Join(q.Tags, " #"))
+	}
+	if snooze.Deferred(q, time.Now()) {
+		meta = append(meta, i18n.T("tui.detail.deferred", q.StartAt.Format("2006-01-02 15:04")))
+	}
+	b.WriteString(m.styles.MetaStyle.Render(strings.Join(meta, "  ")) + "\n\n")
+
+	if q.Description != "" {
+		b.WriteString(markdown.Render(q.Description, width) + "\n\n")
+	} else {
+		b.WriteString(m.styles.StatusMessageStyle.Render(i18n.T("tui.detail.no_description")) + "\n\n")
+	}
+
+	if len(q.Notes) > 0 {
+		b.WriteString(m.styles.TitleStyle.Render(i18n.T("tui.detail.notes")) + "\n")
+		// Свежие заметки сверху
+		for i := len(q.Notes) - 1; i >= 0; i-- {
+			n := q.Notes[i]
+			b.WriteString(m.styles.DifficultyStyle.Render(n.Date.Format("2006-01-02 15:04")) + "\n")
+			b.WriteString(lipgloss.NewStyle().Width(width).Render(n.Text) + "\n\n")
+		}
+	}
+
+	if len(q.Links) > 0 {
+		b.WriteString(m.styles.TitleStyle.Render(i18n.T("tui.detail.links")) + "\n")
+		for _, link := range q.Links {
+			b.WriteString("🔗 " + link + "\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -3,8 +3,19 @@ package tui
 import (
 	"fmt"
 	"io"
+	"magus/checklist"
+	"magus/deps"
+	"magus/dungeon"
+	"magus/game"
+	"magus/i18n"
 	"magus/player"
+	"magus/recur"
+	"magus/ritual"
+	"magus/tags"
+	"magus/tree"
+	"magus/urgency"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,6 +28,14 @@ type QuestListItem struct {
 	IsExpanded bool
 	Depth      int
 	HasKids    bool
+	Blockers   []string // Названия невыполненных квестов, которые блокируют этот (см. пакет deps)
+	Urgency    float64  // Срочность; заполняется только при сортировке по срочности
+	Rollup     float64  // Доля выполненного по подзадачам (0..1), если они есть
+}
+
+// Blocked сообщает, что квест ждет другие квесты и пока недоступен.
+func (i QuestListItem) Blocked() bool {
+	return len(i.Blockers) > 0
 }
 
 // Переопределяем FilterValue, чтобы list.Model мог использовать его
@@ -25,11 +44,14 @@ func (i QuestListItem) FilterValue() string {
 }
 
 type QuestDelegate struct {
-	Styles *Styles
+	Styles       *Styles
+	DayStartHour int             // Граница игрового дня для перезарядки ритуалов
+	Tags         tags.Registry   // Цвета тегов
+	Marked       map[string]bool // Квесты, отмеченные для массовых операций
 }
 
-func NewQuestDelegate(styles *Styles) list.ItemDelegate {
-	return &QuestDelegate{Styles: styles}
+func NewQuestDelegate(styles *Styles, dayStartHour int, registry tags.Registry, marked map[string]bool) list.ItemDelegate {
+	return &QuestDelegate{Styles: styles, DayStartHour: dayStartHour, Tags: registry, Marked: marked}
 }
 
 // Height рассчитывает реальную высоту элемента: 2 строки текста + 2 строки рамки
@@ -44,14 +66,14 @@ func (d QuestDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	}
 
 	isCompleted := item.Completed
-	// Для ритуалов "завершение" может сбрасывать��я, поэтому нужна другая логика,
+	// Для ритуалов "завершение" может сбрасываться, поэтому нужна другая логика,
 	// но пока оставим так для консистентности.
 	isSelected := index == m.Index()
 
 	cardStyle := d.Styles.QuestCardStyle
 	if isSelected {
 		cardStyle = d.Styles.SelectedQuestCardStyle
-	} else if isCompleted {
+	} else if isCompleted || item.Blocked() {
 		cardStyle = d.Styles.FaintQuestCardStyle
 	}
 
@@ -63,23 +85,50 @@ func (d QuestDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	case player.TypeGoal:
 		questTypeStyle = d.Styles.MetaStyle // Используем стиль для мета-целей
 		icon = d.Styles.GoalIcon
-		info = append(info, questTypeStyle.Render("[Цель]"))
+		info = append(info, questTypeStyle.Render("["+i18n.T("quest.type.goal")+"]"))
 	case player.TypeRitual:
 		questTypeStyle = d.Styles.RitualStyle // Используем стиль для ритуалов/рутины
 		icon = d.Styles.RitualIcon
-		info = append(info, questTypeStyle.Render(fmt.Sprintf("[%s]", item.RitualSubtype)))
+		info = append(info, questTypeStyle.Render("["+i18n.T("quest.ritual."+string(item.RitualSubtype))+"]"))
+		if item.Streak > 0 {
+			info = append(info, d.Styles.DifficultyStyle.Render(i18n.T("tui.ritual.streak_card", item.Streak, item.BestStreak)))
+		}
+		if !ritual.Ready(item.Quest, time.Now(), d.DayStartHour) {
+			readyAt := ritual.AvailableAt(item.Quest, d.DayStartHour)
+			info = append(info, d.Styles.StatusMessageStyle.Render(i18n.T("tui.ritual.cooldown", readyAt.Format("15:04"))))
+		}
 	case player.TypeFocus:
 		questTypeStyle = d.Styles.FocusStyle
 		icon = d.Styles.FocusIcon
-		info = append(info, questTypeStyle.Render("[Фокус]"))
+		info = append(info, questTypeStyle.Render("["+i18n.T("quest.type.focus")+"]"))
 		if item.HP > 0 {
 			progress := fmt.Sprintf("HP: %d/%d", item.Progress, item.HP)
 			info = append(info, d.Styles.DifficultyStyle.Render(progress))
 		}
 	}
+	if item.HasKids {
+		percent := int(item.Rollup * 100)
+		info = append(info, RenderProgressBar(percent, 100, 10)+fmt.Sprintf(" %d%%", percent))
+	}
+	if summary := checklist.Summary(item.Quest); summary != "" {
+		info = append(info, d.Styles.MetaStyle.Render("☑ "+summary))
+	}
+	if item.FocusMinutes > 0 {
+		info = append(info, d.Styles.FocusStyle.Render("⏱ "+dungeon.FormatMinutes(item.FocusMinutes)))
+	}
+
+	if item.Priority != player.PriorityNone {
+		info = append(info, d.Styles.DifficultyStyle.Render(i18n.T("tui.quests.priority", i18n.T("quest.priority."+string(item.Priority)))))
+	}
+	if item.Urgency != 0 {
+		info = append(info, d.Styles.StatusMessageStyle.Render(fmt.Sprintf("⚡%.1f", item.Urgency)))
+	}
 
 	if isCompleted {
 		icon = d.Styles.CompletedIcon
+	} else if item.Blocked() {
+		icon = d.Styles.BlockedIcon
+		info = append(info, d.Styles.StatusMessageStyle.Render(i18n.T("tui.quests.blocked_by", strings.Join(item.Blockers, ", "))))
 	}
 
 	// Иконка раскрытия/сворачивания
@@ -93,14 +142,20 @@ func (d QuestDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	}
 
 	var content strings.Builder
+	if d.Marked[item.ID] {
+		icon = d.Styles.MarkedIcon + " " + icon
+	}
 	titleLine := fmt.Sprintf("%s %s %s", expander, icon, item.Title)
 	content.WriteString(titleLine)
 	content.WriteString("\n")
 
 	if len(item.Tags) > 0 {
-		info = append(info, d.Styles.TagStyle.Render("#"+strings.Join(item.Tags, " #")))
+		info = append(info, d.renderTags(item.Tags))
+	}
+	if summary := recur.Summary(item.Quest); summary != "" {
+		info = append(info, d.Styles.RitualStyle.Render("🔁 "+summary))
 	}
-	if dl := deadlineStatus(item.Deadline); dl != "" {
+	if dl := deadlineStatus(item.Quest); dl != "" {
 		info = append(info, d.Styles.DeadlineStyle.Render(dl))
 	}
 	content.WriteString("  " + strings.Join(info, "  "))
@@ -115,13 +170,34 @@ func (d QuestDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	fmt.Fprint(w, finalRender)
 }
 
-// BuildQuestListItems создает плоский список QuestListItem из иерархии квестов
+// renderTags рисует теги квеста, каждый своим цветом из настроек тегов.
+func (d QuestDelegate) renderTags(questTags []string) string {
+	rendered := make([]string, len(questTags))
+	for i, tag := range questTags {
+		style := d.Styles.TagStyle
+		if color := d.Tags.Lookup(tag).Color; color != "" {
+			style = style.Foreground(lipgloss.Color(color))
+		}
+		rendered[i] = style.Render("#" + tag)
+	}
+	return strings.Join(rendered, " ")
+}
+
+// BuildQuestListItems создает плоский список QuestListItem из иерархии квестов.
+// Соседи по родителю идут в ручном порядке (см. tree.Sort).
 func BuildQuestListItems(allQuests []player.Quest, existingItems []list.Item) []list.Item {
+	return buildTreeItems(tree.Sort(allQuests), existingItems)
+}
+
+// buildTreeItems строит дерево элементов, сохраняя порядок соседей из allQuests.
+func buildTreeItems(allQuests []player.Quest, existingItems []list.Item) []list.Item {
 	var items []list.Item
 	questMap := make(map[string][]player.Quest)
 	for _, q := range allQuests {
 		questMap[q.ParentID] = append(questMap[q.ParentID], q)
 	}
+	index := deps.Index(allQuests)
+	progress := game.ProgressMap(allQuests)
 
 	// Сохраняем состояние isExpanded из существующего списка
 	expandedState := make(map[string]bool)
@@ -146,6 +222,8 @@ func BuildQuestListItems(allQuests []player.Quest, existingItems []list.Item) []
 				Depth:      depth,
 				HasKids:    hasKids,
 				IsExpanded: isExpanded,
+				Blockers:   blockerTitles(q, index),
+				Rollup:     progress[q.ID].Ratio(),
 			})
 			if isExpanded {
 				addChildren(q.ID, depth+1)
@@ -156,3 +234,28 @@ func BuildQuestListItems(allQuests []player.Quest, existingItems []list.Item) []
 	addChildren("", 0) // Начинаем с квестов верхнего уровня
 	return items
 }
+
+// BuildUrgencyListItems строит список как BuildQuestListItems, но квесты
+// одного уровня упорядочены по убыванию срочности.
+func BuildUrgencyListItems(allQuests []player.Quest, existingItems []list.Item, coef urgency.Coefficients) []list.Item {
+	scorer := urgency.NewScorer(allQuests, time.Now(), coef)
+	items := buildTreeItems(scorer.Sort(allQuests), existingItems)
+	for i, item := range items {
+		qli := item.(QuestListItem)
+		qli.Urgency = scorer.Score(qli.Quest)
+		items[i] = qli
+	}
+	return items
+}
+
+// blockerTitles возвращает названия невыполненных квестов, которые блокируют q.
+func blockerTitles(q player.Quest, index map[string]player.Quest) []string {
+	if q.Completed {
+		return nil
+	}
+	var titles []string
+	for _, blocker := range deps.OpenBlockers(q, index) {
+		titles = append(titles, blocker.Title)
+	}
+	return titles
+}
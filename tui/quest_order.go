@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"errors"
+	"time"
+
+	"magus/deps"
+	"magus/game"
+	"magus/i18n"
+	"magus/player"
+	"magus/storage"
+	"magus/tree"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sibling ищет в списке ближайшего видимого соседа выбранного квеста по
+// родителю: выше (step = -1) или ниже (step = 1). Подзадачи соседей
+// пропускаются, выход из поддерева родителя завершает поиск.
+func (s *QuestsState) sibling(step int) (QuestListItem, QuestListItem, bool) {
+	selected, ok := s.list.SelectedItem().(QuestListItem)
+	if !ok {
+		return QuestListItem{}, QuestListItem{}, false
+	}
+	items := s.list.Items()
+	start := -1
+	for i, item := range items {
+		if item.(QuestListItem).ID == selected.ID {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return selected, QuestListItem{}, false
+	}
+	for i := start + step; i >= 0 && i < len(items); i += step {
+		qli := items[i].(QuestListItem)
+		if qli.Depth < selected.Depth {
+			break
+		}
+		if qli.Depth == selected.Depth && qli.ParentID == selected.ParentID {
+			return selected, qli, true
+		}
+	}
+	return selected, QuestListItem{}, false
+}
+
+// shiftQuest двигает выбранный квест выше или ниже среди соседей.
+func (s *QuestsState) shiftQuest(m *Model, step int) (State, tea.Cmd) {
+	if s.byUrgency {
+		return s, s.list.NewStatusMessage(i18n.T("tui.quests.order_urgency"))
+	}
+	selected, other, ok := s.sibling(step)
+	if !ok {
+		return s, nil
+	}
+	if err := tree.Swap(s.allQuests, selected.ID, other.ID); err != nil {
+		return s, s.list.NewStatusMessage(err.Error())
+	}
+	return s.saveOrder(m, selected.ID, "", nil)
+}
+
+// indentQuest делает выбранный квест последней подзадачей соседа над ним.
+func (s *QuestsState) indentQuest(m *Model) (State, tea.Cmd) {
+	if s.byUrgency {
+		return s, s.list.NewStatusMessage(i18n.T("tui.quests.order_urgency"))
+	}
+	selected, parent, ok := s.sibling(-1)
+	if !ok {
+		return s, s.list.NewStatusMessage(i18n.T("tui.quests.indent_none"))
+	}
+	switch err := tree.Move(s.allQuests, selected.ID, parent.ID); {
+	case errors.Is(err, tree.ErrCycle):
+		return s, s.list.NewStatusMessage(i18n.T("tui.bulk.err_cycle", parent.Title))
+	case errors.Is(err, tree.ErrParentDone):
+		return s, s.list.NewStatusMessage(i18n.T("tui.bulk.err_parent_done", parent.Title))
+	case err != nil:
+		return s, s.list.NewStatusMessage(err.Error())
+	}
+	statusMsg := i18n.T("tui.quests.indented", selected.Title, parent.Title)
+	return s.saveOrder(m, selected.ID, statusMsg, s.closeGoals(m, selected.ParentID))
+}
+
+// outdentQuest выносит выбранный квест на уровень выше, сразу за родителя.
+func (s *QuestsState) outdentQuest(m *Model) (State, tea.Cmd) {
+	if s.byUrgency {
+		return s, s.list.NewStatusMessage(i18n.T("tui.quests.order_urgency"))
+	}
+	selected, ok := s.list.SelectedItem().(QuestListItem)
+	if !ok {
+		return s, nil
+	}
+	switch err := tree.Outdent(s.allQuests, selected.ID); {
+	case errors.Is(err, tree.ErrTopLevel):
+		return s, s.list.NewStatusMessage(i18n.T("tui.quests.outdent_top"))
+	case errors.Is(err, tree.ErrParentDone):
+		index := deps.Index(s.allQuests)
+		grandparent := index[index[selected.ParentID].ParentID]
+		return s, s.list.NewStatusMessage(i18n.T("tui.bulk.err_parent_done", grandparent.Title))
+	case err != nil:
+		return s, s.list.NewStatusMessage(err.Error())
+	}
+	statusMsg := i18n.T("tui.quests.outdented", selected.Title)
+	return s.saveOrder(m, selected.ID, statusMsg, s.closeGoals(m, selected.ParentID))
+}
+
+// closeGoals завершает по правилам игры прежние цели перенесенных квестов,
+// в которых остались только выполненные подзадачи.
+func (s *QuestsState) closeGoals(m *Model, parentIDs ...string) []game.Event {
+	w := &game.World{Player: m.Player, Quests: s.allQuests, DayStartHour: m.settings().DayStartHour, Tags: m.Tags, Effects: m.Effects, Curve: m.settings().Curve()}
+	now := time.Now()
+	var events []game.Event
+	for _, id := range parentIDs {
+		events = append(events, game.CloseGoals(w, id, now)...)
+	}
+	s.allQuests = w.Quests
+	if len(events) > 0 && m.Player != nil {
+		player.SavePlayer(m.Player)
+	}
+	return events
+}
+
+// saveOrder сохраняет новый порядок и оставляет курсор на перемещенном
+// квесте. events — завершение целей после переноса (см. closeGoals).
+func (s *QuestsState) saveOrder(m *Model, questID, statusMsg string, events []game.Event) (State, tea.Cmd) {
+	m.Quests = s.allQuests
+	storage.SaveAllQuests(m.Quests)
+	s.selectQuest(questID)
+	if msg := eventsMessage(events); msg != "" {
+		statusMsg += " " + msg
+	}
+	if game.Has(events, game.EventLevelUpReady) {
+		levelUpState, status := NewLevelUpState(m)
+		if levelUpState != nil {
+			return levelUpState, nil
+		}
+		if status != "" {
+			statusMsg += " " + status
+		}
+	}
+	if statusMsg == "" {
+		return s, nil
+	}
+	return s, s.list.NewStatusMessage(statusMsg)
+}
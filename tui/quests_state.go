@@ -1,13 +1,22 @@
 package tui
 
 import (
-	"fmt"
+	"errors"
+	"magus/deps"
+	"magus/game"
+	"magus/i18n"
 	"magus/player"
+	"magus/ritual"
+	"magus/snooze"
 	"magus/storage"
+	"magus/tree"
+	"magus/urgency"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -16,31 +25,67 @@ type QuestsState struct {
 	list          list.Model
 	allQuests     []player.Quest // Мастер-список всех квестов
 	statusMessage string
+	linkFrom      string // ID квеста, для которого выбирается блокирующий квест
+	byUrgency     bool   // Сортировка по срочности вместо порядка в файле
+	showCompleted bool   // Показывать выполненные квесты
+	coef          urgency.Coefficients
+
+	marked    map[string]bool // Отмеченные квесты для массовых операций
+	rangeFrom int             // Индекс начала диапазона V или -1
+	rangeBase map[string]bool // Отметки до начала диапазона
+	bulk      bulkAction      // Массовая операция в процессе
+	bulkStage bulkStage
+	bulkIDs   []string // Квесты массовой операции
+	bulkArg   string   // Тег, срок или ID новой цели
+	bulkInput textinput.Model
 }
 
 func NewQuestsState(m *Model) *QuestsState {
-	s := &QuestsState{allQuests: m.Quests}
+	s := &QuestsState{
+		allQuests: m.Quests,
+		coef:      urgency.WithOverrides(m.settings().Urgency),
+		marked:    make(map[string]bool),
+		rangeFrom: -1,
+	}
 
-	delegate := NewQuestDelegate(&m.styles)
+	delegate := NewQuestDelegate(&m.styles, m.settings().DayStartHour, m.Tags, s.marked)
 	questList := list.New(nil, delegate, 0, 0) // Start with an empty list
-	questList.Title = "Активные квесты"
+	questList.Title = i18n.T("tui.quests.title")
 	questList.Styles.Title = m.styles.TitleStyle
 	questList.SetShowStatusBar(false)
 	questList.SetShowHelp(true)
 	questList.AdditionalShortHelpKeys = func() []key.Binding {
 		return []key.Binding{
-			key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "добавить")),
-			key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "удалить")),
-			key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "развернуть")),
+			key.NewBinding(key.WithKeys("a"), key.WithHelp("a", i18n.T("tui.quests.key_add"))),
+			key.NewBinding(key.WithKeys("d"), key.WithHelp("d", i18n.T("tui.quests.key_delete"))),
+			key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", i18n.T("tui.quests.key_expand"))),
+			key.NewBinding(key.WithKeys("b"), key.WithHelp("b/B", i18n.T("tui.quests.key_block"))),
+			key.NewBinding(key.WithKeys("p"), key.WithHelp("p", i18n.T("tui.quests.key_priority"))),
+			key.NewBinding(key.WithKeys("o"), key.WithHelp("o", i18n.T("tui.quests.key_sort"))),
+			key.NewBinding(key.WithKeys("s"), key.WithHelp("s", i18n.T("tui.quests.key_snooze"))),
+			key.NewBinding(key.WithKeys("U"), key.WithHelp("U", i18n.T("tui.quests.key_upcoming"))),
+			key.NewBinding(key.WithKeys("c"), key.WithHelp("c", i18n.T("tui.quests.key_completed"))),
+			key.NewBinding(key.WithKeys("x"), key.WithHelp("x", i18n.T("tui.quests.key_checklist"))),
+			key.NewBinding(key.WithKeys("i"), key.WithHelp("i", i18n.T("tui.quests.key_details"))),
+			key.NewBinding(key.WithKeys("T"), key.WithHelp("T", i18n.T("tui.quests.key_tags"))),
+			key.NewBinding(key.WithKeys(" "), key.WithHelp("space/V", i18n.T("tui.quests.key_mark"))),
 		}
 	}
 	questList.AdditionalFullHelpKeys = func() []key.Binding {
-		return questList.AdditionalShortHelpKeys()
+		return append(questList.AdditionalShortHelpKeys(),
+			key.NewBinding(key.WithKeys("K"), key.WithHelp("K/J", i18n.T("tui.quests.key_reorder"))),
+			key.NewBinding(key.WithKeys(">"), key.WithHelp(">/<", i18n.T("tui.quests.key_indent"))),
+			key.NewBinding(key.WithKeys("+"), key.WithHelp("+/-", i18n.T("tui.quests.key_bulk_tag"))),
+			key.NewBinding(key.WithKeys("D"), key.WithHelp("D", i18n.T("tui.quests.key_bulk_deadline"))),
+			key.NewBinding(key.WithKeys("M"), key.WithHelp("M", i18n.T("tui.quests.key_bulk_move"))),
+			key.NewBinding(key.WithKeys("F"), key.WithHelp("F", i18n.T("tui.quests.key_bulk_dungeon"))),
+			key.NewBinding(key.WithKeys("u"), key.WithHelp("u", i18n.T("tui.quests.key_undo"))),
+		)
 	}
 
 	s.list = questList
 	// Инициализируем список с самого начала
-	s.list.SetItems(BuildQuestListItems(s.allQuests, s.list.Items()))
+	s.list.SetItems(s.buildItems(s.list.Items()))
 	return s
 }
 
@@ -57,17 +102,115 @@ func (s *QuestsState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 		if s.list.FilterState() == list.Filtering {
 			break
 		}
+		if s.bulk != bulkNone {
+			return s.updateBulk(m, msg)
+		}
+		if s.linkFrom != "" {
+			switch msg.String() {
+			case "b", "enter":
+				return s.linkBlocker(m)
+			case "esc", "q":
+				s.endLink()
+				return s, s.list.NewStatusMessage(i18n.T("tui.quests.link_cancelled"))
+			}
+			break
+		}
 		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("b"))):
+			// Режим выбора: курсором выбираем квест, которого нужно ждать
+			if item, ok := s.list.SelectedItem().(QuestListItem); ok {
+				s.linkFrom = item.ID
+				s.list.Title = i18n.T("tui.quests.link_pick", item.Title)
+			}
+			return s, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("B"))):
+			return s.clearBlockers(m)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("p"))):
+			return s.cyclePriority(m)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("s"))):
+			if item, ok := s.list.SelectedItem().(QuestListItem); ok {
+				return NewSnoozeState(m, item.ID, item.Title), nil
+			}
+			return s, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("U"))):
+			return NewUpcomingState(m), nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("T"))):
+			return NewManageTagsState(m), nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys(" "))):
+			if s.rangeFrom >= 0 {
+				s.toggleRange() // Пробел закрепляет диапазон, как и V
+			} else {
+				s.toggleMark()
+			}
+			return s, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("V"))):
+			s.toggleRange()
+			return s, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("K", "shift+up"))):
+			return s.shiftQuest(m, -1)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("J", "shift+down"))):
+			return s.shiftQuest(m, 1)
+		case key.Matches(msg, key.NewBinding(key.WithKeys(">"))):
+			return s.indentQuest(m)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("<"))):
+			return s.outdentQuest(m)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("+"))):
+			return s.startBulk(bulkAddTag)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("-"))):
+			return s.startBulk(bulkRemoveTag)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("D"))):
+			return s.startBulk(bulkDeadline)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("M"))):
+			return s.startBulk(bulkMove)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("F"))):
+			return s.sendToDungeon(m)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("u"))):
+			return s.undoBulk(m)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("o"))):
+			s.byUrgency = !s.byUrgency
+			s.list.SetItems(s.buildItems(s.list.Items()))
+			statusMsg := i18n.T("tui.quests.sort_file")
+			if s.byUrgency {
+				statusMsg = i18n.T("tui.quests.sort_urgency")
+			}
+			return s, s.list.NewStatusMessage(statusMsg)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+			s.showCompleted = !s.showCompleted
+			s.list.SetItems(s.buildItems(s.list.Items()))
+			statusMsg := i18n.T("tui.quests.completed_hidden")
+			if s.showCompleted {
+				statusMsg = i18n.T("tui.quests.completed_shown")
+			}
+			return s, s.list.NewStatusMessage(statusMsg)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("i"))):
+			if item, ok := s.list.SelectedItem().(QuestListItem); ok {
+				return NewQuestDetailState(m, item.ID), nil
+			}
+			return s, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+			if item, ok := s.list.SelectedItem().(QuestListItem); ok {
+				return NewChecklistState(m, item.ID), nil
+			}
+			return s, nil
 		case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
 			// Новое состояние для добавления квеста
 			return NewAddQuestState(m), nil
 		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			if len(s.marked) > 0 {
+				return s.startBulk(bulkComplete)
+			}
 			return s.completeQuest(m)
 		case key.Matches(msg, key.NewBinding(key.WithKeys("d", "delete"))):
+			if len(s.marked) > 0 {
+				return s.startBulk(bulkDelete)
+			}
 			return s.deleteQuest(m)
 		case key.Matches(msg, key.NewBinding(key.WithKeys("tab"))):
 			s.toggleQuestExpansion()
 			return s, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))) && (len(s.marked) > 0 || s.rangeFrom >= 0):
+			s.clearMarks()
+			return s, nil
 		case key.Matches(msg, key.NewBinding(key.WithKeys("q", "esc"))):
 			return PopState{}, nil
 		}
@@ -76,6 +219,7 @@ func (s *QuestsState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 	newListModel, cmd := s.list.Update(msg)
 	s.list = newListModel
 	cmds = append(cmds, cmd)
+	s.extendRange() // Диапазон V растет вслед за курсором
 
 	return s, tea.Batch(cmds...)
 }
@@ -83,10 +227,67 @@ func (s *QuestsState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
 func (s *QuestsState) View(m *Model) string {
 	// Set the size of the list before rendering
 	h, v := lipgloss.NewStyle().Margin(1, 2).GetFrameSize()
+	if s.bulk != bulkNone && s.bulkStage == stageInput {
+		// Под списком — поле ввода тега или срока
+		s.list.SetSize(m.TerminalWidth-h, m.TerminalHeight-v-2)
+		return lipgloss.NewStyle().Margin(1, 2).Render(s.list.View() + "\n\n" + s.bulkInput.View())
+	}
 	s.list.SetSize(m.TerminalWidth-h, m.TerminalHeight-v)
 	return lipgloss.NewStyle().Margin(1, 2).Render(s.list.View())
 }
 
+// buildItems строит элементы списка в текущем порядке сортировки.
+// Отложенные квесты видны только среди предстоящих. Скрытые выполненные
+// подзадачи все равно учитываются в прогрессе целей.
+func (s *QuestsState) buildItems(existingItems []list.Item) []list.Item {
+	quests := snooze.Active(s.allQuests, time.Now())
+	if !s.showCompleted {
+		quests = withoutCompleted(quests)
+	}
+
+	var items []list.Item
+	if s.byUrgency {
+		items = BuildUrgencyListItems(quests, existingItems, s.coef)
+	} else {
+		items = BuildQuestListItems(quests, existingItems)
+	}
+	// Прогресс целей считается по всем квестам в любом режиме: скрытые
+	// выполненные и отложенные подзадачи тоже входят в него
+	progress := game.ProgressMap(s.allQuests)
+	for i, item := range items {
+		qli := item.(QuestListItem)
+		qli.Rollup = progress[qli.ID].Ratio()
+		items[i] = qli
+	}
+	return items
+}
+
+// withoutCompleted убирает выполненные квесты, кроме тех, под которыми
+// остались невыполненные подзадачи: иначе подзадачи потеряли бы родителя.
+func withoutCompleted(quests []player.Quest) []player.Quest {
+	parents := make(map[string]string, len(quests))
+	for _, q := range quests {
+		parents[q.ID] = q.ParentID
+	}
+	keep := make(map[string]bool)
+	for _, q := range quests {
+		if q.Completed {
+			continue
+		}
+		for id := q.ID; id != "" && !keep[id]; id = parents[id] {
+			keep[id] = true
+		}
+	}
+
+	var visible []player.Quest
+	for _, q := range quests {
+		if keep[q.ID] {
+			visible = append(visible, q)
+		}
+	}
+	return visible
+}
+
 func (s *QuestsState) toggleQuestExpansion() {
 	selectedItem, ok := s.list.SelectedItem().(QuestListItem)
 	if !ok || !selectedItem.HasKids {
@@ -104,7 +305,106 @@ func (s *QuestsState) toggleQuestExpansion() {
 	}
 
 	// Перестраиваем список на основе обновленных состояний isExpanded
-	s.list.SetItems(BuildQuestListItems(s.allQuests, s.list.Items()))
+	s.list.SetItems(s.buildItems(s.list.Items()))
+}
+
+// selectQuest раскрывает всех предков квеста и ставит на него курсор.
+// Выполненный квест делает видимыми выполненные квесты.
+func (s *QuestsState) selectQuest(questID string) {
+	parents := make(map[string]string)
+	for _, q := range s.allQuests {
+		parents[q.ID] = q.ParentID
+		if q.ID == questID && q.Completed {
+			s.showCompleted = true
+		}
+	}
+
+	// BuildQuestListItems берет состояние раскрытия из существующих элементов
+	expanded := s.list.Items()
+	for id := parents[questID]; id != ""; id = parents[id] {
+		expanded = append(expanded, QuestListItem{Quest: player.Quest{ID: id}, IsExpanded: true})
+	}
+	s.list.SetItems(s.buildItems(expanded))
+
+	for i, item := range s.list.Items() {
+		if qli, ok := item.(QuestListItem); ok && qli.ID == questID {
+			s.list.Select(i)
+			return
+		}
+	}
+}
+
+// linkBlocker делает выбранный квест блокирующим для квеста s.linkFrom.
+func (s *QuestsState) linkBlocker(m *Model) (State, tea.Cmd) {
+	questID := s.linkFrom
+	s.endLink()
+	blocker, ok := s.list.SelectedItem().(QuestListItem)
+	if !ok {
+		return s, nil
+	}
+
+	var statusMsg string
+	switch err := deps.AddBlocker(s.allQuests, questID, blocker.ID); {
+	case errors.Is(err, deps.ErrSelfBlock):
+		statusMsg = i18n.T("tui.quests.link_self")
+	case errors.Is(err, deps.ErrCycle):
+		statusMsg = i18n.T("tui.quests.link_cycle")
+	case err != nil:
+		statusMsg = i18n.T("tui.quests.link_error", err)
+	default:
+		m.Quests = s.allQuests
+		storage.SaveAllQuests(m.Quests)
+		s.list.SetItems(s.buildItems(s.list.Items()))
+		s.selectQuest(questID)
+		statusMsg = i18n.T("tui.quests.linked", blocker.Title)
+	}
+	return s, s.list.NewStatusMessage(statusMsg)
+}
+
+// endLink выходит из режима выбора блокирующего квеста.
+func (s *QuestsState) endLink() {
+	s.linkFrom = ""
+	s.list.Title = i18n.T("tui.quests.title")
+}
+
+// clearBlockers снимает с выбранного квеста все блокировки.
+func (s *QuestsState) clearBlockers(m *Model) (State, tea.Cmd) {
+	item, ok := s.list.SelectedItem().(QuestListItem)
+	if !ok || len(item.BlockedBy) == 0 {
+		return s, nil
+	}
+	for i := range s.allQuests {
+		if s.allQuests[i].ID == item.ID {
+			s.allQuests[i].BlockedBy = nil
+		}
+	}
+	m.Quests = s.allQuests
+	storage.SaveAllQuests(m.Quests)
+	s.list.SetItems(s.buildItems(s.list.Items()))
+	return s, s.list.NewStatusMessage(i18n.T("tui.quests.unlinked", item.Title))
+}
+
+// cyclePriority переключает приоритет выбранного квеста по кругу.
+func (s *QuestsState) cyclePriority(m *Model) (State, tea.Cmd) {
+	item, ok := s.list.SelectedItem().(QuestListItem)
+	if !ok {
+		return s, nil
+	}
+	priority := item.Priority.Next()
+	for i := range s.allQuests {
+		if s.allQuests[i].ID == item.ID {
+			s.allQuests[i].Priority = priority
+		}
+	}
+	m.Quests = s.allQuests
+	storage.SaveAllQuests(m.Quests)
+	s.selectQuest(item.ID) // При сортировке по срочности квест может сместиться
+
+	statusMsg := i18n.T("tui.quests.priority_cleared", item.Title)
+	if priority != player.PriorityNone {
+		statusMsg = i18n.T("tui.quests.priority_set", item.Title, i18n.T("quest.priority."+string(priority)))
+	}
+	return s, s.list.NewStatusMessage(statusMsg)
 }
 
 func (s *QuestsState) deleteQuest(m *Model) (State, tea.Cmd) {
@@ -114,38 +414,29 @@ func (s *QuestsState) deleteQuest(m *Model) (State, tea.Cmd) {
 	}
 
 	// Найти все ID для удаления (выбранный квест + все дочерние)
-	idsToDelete := make(map[string]struct{})
-	var findChildren func(parentID string)
-	findChildren = func(parentID string) {
-		idsToDelete[parentID] = struct{}{}
-		for _, q := range s.allQuests {
-			if q.ParentID == parentID {
-				findChildren(q.ID)
-			}
-		}
-	}
-	findChildren(selectedItem.ID)
+	idsToDelete := tree.Subtree(s.allQuests, selectedItem.ID)
 
 	// Создать новый срез без удаленных квестов
 	var updatedQuests []player.Quest
 	for _, q := range s.allQuests {
-		if _, found := idsToDelete[q.ID]; !found {
+		if !idsToDelete[q.ID] {
 			updatedQuests = append(updatedQuests, q)
 		}
 	}
 
+	deps.Prune(updatedQuests) // Удаленные квесты больше никого не блокируют
 	s.allQuests = updatedQuests
 	m.Quests = updatedQuests // Обновляем мастер-список в главной модели
 	storage.SaveAllQuests(m.Quests)
 
 	// Обновляем UI
-	s.list.SetItems(BuildQuestListItems(s.allQuests, s.list.Items()))
+	s.list.SetItems(s.buildItems(s.list.Items()))
 	// Перемещаем курсор, если он был на последнем элементе, который удалили
 	if s.list.Index() >= len(s.list.Items()) && len(s.list.Items()) > 0 {
 		s.list.Select(len(s.list.Items()) - 1)
 	}
 
-	statusMsg := fmt.Sprintf("🗑️ Квест '%s' и все подзадачи удалены.", selectedItem.Title)
+	statusMsg := i18n.T("tui.quests.deleted", selectedItem.Title)
 	return s, s.list.NewStatusMessage(statusMsg)
 }
 
@@ -155,90 +446,81 @@ func (s *QuestsState) completeQuest(m *Model) (State, tea.Cmd) {
 		return s, nil
 	}
 
-	// Нельзя завершить уже завершенный квест
-	if selectedItem.Completed {
-		return s, s.list.NewStatusMessage("✅ Квест уже выполнен")
-	}
-
-	// Нельзя завершать фокус-квесты из этого меню
-	if selectedItem.Type == player.TypeFocus {
-		return s, s.list.NewStatusMessage("❗ Этот квест выполняется в фокус-сессии (подземелье)")
+	w := &game.World{Player: m.Player, Quests: s.allQuests, DayStartHour: m.settings().DayStartHour, Tags: m.Tags, Effects: m.Effects, Curve: m.settings().Curve()}
+	events, err := game.CompleteQuest(w, selectedItem.ID, time.Now())
+	if err != nil {
+		return s, s.list.NewStatusMessage(completeErrorMessage(w, selectedItem, err))
 	}
 
-	// Нельзя завершить цель напрямую, если у нее есть незавершенные подзадачи
-	if selectedItem.Type == player.TypeGoal {
-		hasIncompleteChildren := false
-		for _, q := range s.allQuests {
-			if q.ParentID == selectedItem.ID && !q.Completed {
-				hasIncompleteChildren = true
-				break
-			}
+	m.Quests = w.Quests
+	storage.SaveAllQuests(m.Quests)
+	player.SavePlayer(m.Player)
+	s.list.SetItems(s.buildItems(s.list.Items()))
+
+	// Новый уровень: выбираем навык, а если выбирать не из чего — просто повышаем
+	s.statusMessage = eventsMessage(events)
+	if game.Has(events, game.EventLevelUpReady) {
+		levelUpState, status := NewLevelUpState(m)
+		if levelUpState != nil {
+			return levelUpState, nil
 		}
-		if hasIncompleteChildren {
-			statusMsg := fmt.Sprintf("❗ Сначала завершите все подзадачи для цели '%s'", selectedItem.Title)
-			return s, s.list.NewStatusMessage(statusMsg)
+		if status != "" {
+			s.statusMessage += " " + status
 		}
 	}
 
-	var xpGained int
-	var manaGained int
-	questCompleted := false
-
-	// Обновляем квест в мастер-списке
-	for i, q := range s.allQuests {
-		if q.ID == selectedItem.ID {
-			switch q.Type {
-			case player.TypeRitual:
-				// Ритуалы восстанавливают ману, не дают XP и не "завершаются" навсегда
-				manaGained = 5 // Примерное значение, можно вынести в конфиг
-				s.statusMessage = fmt.Sprintf("💧 +%d маны за ритуал '%s'", manaGained, q.Title)
-				// Можно добавить кулдаун, но пока просто восстанавливаем ману
-			case player.TypeFocus, player.TypeGoal:
-				// Фокус-квесты и цели завершаются, дают XP
-				s.allQuests[i].Completed = true
-				s.allQuests[i].CompletedAt = time.Now()
-				s.allQuests[i].Progress = s.allQuests[i].HP // Заполняем прогресс при завершении
-				xpGained = s.allQuests[i].XP
-				questCompleted = true
-				s.statusMessage = fmt.Sprintf("✨ +%d XP за квест '%s'!", xpGained, q.Title)
-			}
-			m.Quests[i] = s.allQuests[i] // Обновляем квест в главной модели
-			break
-		}
-	}
+	return s, s.list.NewStatusMessage(s.statusMessage)
+}
 
-	// Обновляем данные игрока
-	p, _ := player.LoadPlayer()
-	p.Mana += manaGained
-	if p.Mana > p.MaxMana {
-		p.Mana = p.MaxMana
+// completeErrorMessage объясняет, почему квест нельзя завершить.
+func completeErrorMessage(w *game.World, item QuestListItem, err error) string {
+	switch {
+	case errors.Is(err, game.ErrAlreadyCompleted):
+		return i18n.T("tui.quests.already_done")
+	case errors.Is(err, game.ErrBlocked):
+		return i18n.T("tui.quests.is_blocked", strings.Join(item.Blockers, ", "))
+	case errors.Is(err, game.ErrNeedsDungeon):
+		return i18n.T("tui.quests.focus_in_dungeon")
+	case errors.Is(err, game.ErrOpenSubquests):
+		return i18n.T("tui.quests.goal_has_children", item.Title)
+	case errors.Is(err, ritual.ErrCooldown):
+		readyAt := ritual.AvailableAt(item.Quest, w.DayStartHour).Format("2006-01-02 15:04")
+		return i18n.T("tui.quests.ritual_cooldown", item.Title, readyAt)
 	}
-	m.Player = p // Обновляем игрока в текущей модели
-	player.SavePlayer(p)
+	return err.Error()
+}
 
-	// Сохраняем и обновляем список
-	storage.SaveAllQuests(m.Quests)
-	s.list.SetItems(BuildQuestListItems(s.allQuests, s.list.Items()))
-
-	// Проверка на повышение уровня, если был получен опыт
-	if xpGained > 0 {
-		canLevelUp, _ := player.AddXP(xpGained)
-		if canLevelUp {
-			levelUpState, err := NewLevelUpState(m)
-			if err != nil {
-				s.statusMessage = "🔮 Новый уровень! Доступных для изучения навыков пока нет."
-				player.LevelUpPlayer("")
-				p, _ := player.LoadPlayer()
-				m.Player = p
-			} else {
-				return levelUpState, nil
-			}
+// eventsMessage собирает события правил игры в одну строку статуса.
+func eventsMessage(events []game.Event) string {
+	var parts []string
+	for _, e := range events {
+		switch e.Kind {
+		case game.EventQuestCompleted:
+			parts = append(parts, i18n.T("tui.quests.xp_gained", e.Amount, e.Quest.Title))
+		case game.EventRecurringDone:
+			parts = append(parts, i18n.T("tui.quests.recurring_done", e.Quest.Title, e.Quest.Deadline.Format("2006-01-02")))
+		case game.EventParentCompleted:
+			parts = append(parts, i18n.T("tui.quests.parent_done", e.Quest.Title, e.Amount))
+		case game.EventEarlyBonus:
+			parts = append(parts, i18n.T("tui.quests.early_bonus", e.Amount))
+		case game.EventManaRestored:
+			parts = append(parts, i18n.T("tui.quests.ritual_mana", e.Amount, e.Quest.Title))
+		case game.EventStreak:
+			parts = append(parts, i18n.T("tui.ritual.streak", e.Amount))
+		case game.EventNewBestStreak:
+			parts = append(parts, i18n.T("tui.ritual.new_best"))
+		case game.EventRitualsDone:
+			parts = append(parts, i18n.T("tui.quests.rituals_done", e.Amount))
+		case game.EventQuestDefeated:
+			parts = append(parts, i18n.T("tui.summary.defeated", e.Quest.Title, e.Amount))
+		case game.EventStatTrained:
+			parts = append(parts, statTrained(e))
 		}
 	}
+	return strings.Join(parts, " ")
+}
 
-	if !questCompleted && manaGained == 0 {
-		return s, nil // Ничего не произошло
-	}
-
-	return s, s.list.NewStatusMessage(s.statusMessage)
-}
\ No newline at end of file
+// statTrained сообщает о характеристике, которую натренировал квест.
+func statTrained(e game.Event) string {
+	return i18n.T("stat.trained", i18n.T("stat."+e.Stat), e.Amount)
+}
@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"fmt"
+	"magus/i18n"
+	"magus/player"
+	"magus/search"
+	"magus/storage"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxSearchResults ограничивает число результатов на экране поиска.
+const maxSearchResults = 20
+
+var searchMarkStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+
+// SearchState — экран полнотекстового поиска по квестам и журналу рефлексии.
+type SearchState struct {
+	input   textinput.Model
+	notes   []storage.ReflectionNote
+	archive []player.Quest
+	results []search.Result
+	cursor  int
+	errMsg  string
+}
+
+func NewSearchState(m *Model) *SearchState {
+	ti := textinput.New()
+	ti.Placeholder = i18n.T("tui.search.placeholder")
+	ti.CharLimit = 100
+	ti.Width = 50
+	ti.Focus()
+
+	notes, _ := storage.LoadReflections()
+	archive, _ := storage.LoadArchive()
+	return &SearchState{input: ti, notes: notes, archive: archive}
+}
+
+func (s *SearchState) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (s *SearchState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "esc":
+			return PopState{}, nil
+		case "up", "ctrl+p":
+			if s.cursor > 0 {
+				s.cursor--
+			}
+			return s, nil
+		case "down", "ctrl+n":
+			if s.cursor < len(s.results)-1 {
+				s.cursor++
+			}
+			return s, nil
+		case "enter":
+			return s.jump(m)
+		}
+	}
+
+	var cmd tea.Cmd
+	prev := s.input.Value()
+	s.input, cmd = s.input.Update(msg)
+	if s.input.Value() != prev {
+		s.runSearch(m)
+	}
+	return s, cmd
+}
+
+func (s *SearchState) runSearch(m *Model) {
+	s.results = search.Search(s.input.Value(), m.Quests, s.archive, s.notes)
+	if len(s.results) > maxSearchResults {
+		s.results = s.results[:maxSearchResults]
+	}
+	s.cursor = 0
+}
+
+// jump открывает найденный квест в списке квестов или запись в журнале.
+// Квест из архива сначала восстанавливается.
+func (s *SearchState) jump(m *Model) (State, tea.Cmd) {
+	if len(s.results) == 0 {
+		return s, nil
+	}
+	r := s.results[s.cursor]
+	switch {
+	case r.Kind == search.KindQuest && r.Archived:
+		if err := s.restore(m, r.QuestID); err != nil {
+			s.errMsg = i18n.T("tui.search.err_restore", err)
+			return s, nil
+		}
+		qs := NewQuestsState(m)
+		qs.selectQuest(r.QuestID)
+		return qs, qs.list.NewStatusMessage(i18n.T("tui.search.restored", r.Title))
+	case r.Kind == search.KindQuest:
+		qs := NewQuestsState(m)
+		qs.selectQuest(r.QuestID)
+		return qs, nil
+	case r.Kind == search.KindReflection:
+		return NewJournalState(m, r.ReflectionIndex), nil
+	}
+	return s, nil
+}
+
+// restore возвращает квест из архива в список квестов.
+func (s *SearchState) restore(m *Model, questID string) error {
+	restored, rest, err := storage.Unarchive(s.archive, questID)
+	if err != nil {
+		return err
+	}
+	quests := append(m.Quests, restored...)
+	if err := storage.SaveAllQuests(quests); err != nil {
+		return err
+	}
+	m.Quests = quests
+	s.archive = rest
+	return storage.SaveArchive(rest)
+}
+
+func (s *SearchState) View(m *Model) string {
+	var b strings.Builder
+	b.WriteString(m.styles.TitleStyle.Render(i18n.T("tui.search.title")) + "\n\n")
+	b.WriteString(s.input.View() + "\n\n")
+	if s.errMsg != "" {
+		b.WriteString(m.styles.DeadlineStyle.Render(s.errMsg) + "\n\n")
+	}
+
+	switch {
+	case strings.TrimSpace(s.input.Value()) == "":
+		b.WriteString(m.styles.StatusMessageStyle.Render(i18n.T("tui.search.empty")) + "\n")
+	case len(s.results) == 0:
+		b.WriteString(m.styles.StatusMessageStyle.Render(i18n.T("tui.search.no_results")) + "\n")
+	}
+
+	for i, r := range s.results {
+		cursor := "  "
+		titleStyle := lipgloss.NewStyle().Bold(true)
+		if i == s.cursor {
+			cursor = "> "
+			titleStyle = titleStyle.Foreground(lipgloss.Color("205"))
+		}
+
+		icon := m.styles.FocusIcon
+		title := r.Title
+		switch {
+		case r.Kind == search.KindReflection:
+			icon = "📓"
+			title = i18n.T("tui.search.reflection", r.Title)
+		case r.Archived:
+			icon = "📦"
+			title = i18n.T("tui.search.archived", r.Title)
+		}
+		snippet := search.Highlight(r.Snippet, r.Highlights, func(s string) string {
+			return searchMarkStyle.Render(s)
+		})
+		b.WriteString(fmt.Sprintf("%s%s %s\n", cursor, icon, titleStyle.Render(title)))
+		b.WriteString(fmt.Sprintf("     %s\n", m.styles.StatusMessageStyle.Render(snippet)))
+	}
+
+	b.WriteString("\n" + m.styles.StatusMessageStyle.Render(i18n.T("tui.search.help")))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
@@ -1,11 +1,11 @@
 package tui
 
 import (
-	"bytes"
 	"fmt"
+	"magus/game"
+	"magus/i18n"
 	"magus/player"
 	"magus/rpg"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -30,12 +30,6 @@ const (
 	viewClass
 )
 
-// Хеш-функция для player.SkillNode, необходимая для библиотеки graph.
-// Она позволяет графу уникально идентифицировать каждую вершину по ее ID.
-func skillNodeHash(s player.SkillNode) string {
-	return s.ID
-}
-
 // SkillsState представляет собой состояние экрана дерева навыков.
 type SkillsState struct {
 	commonSkillGraph graph.Graph[string, player.SkillNode]
@@ -56,49 +50,16 @@ func NewSkillsState(m *Model) State {
 
 	trees, err := rpg.LoadSkillTrees(m.Player)
 	if err != nil {
-		s.statusMessage = "Ошибка загрузки дерева навыков."
+		s.statusMessage = i18n.T("tui.skills.err_load")
 		return s
 	}
 
-	s.commonSkillGraph, s.commonSkillIDs = s.buildGraph(trees.Common)
-	s.classSkillGraph, s.classSkillIDs = s.buildGraph(trees.Class)
+	s.commonSkillGraph, s.commonSkillIDs = rpg.BuildSkillGraph(trees.Common)
+	s.classSkillGraph, s.classSkillIDs = rpg.BuildSkillGraph(trees.Class)
 
 	return s
 }
 
-// buildGraph строит граф для библиотеки dominikbraun/graph
-func (s *SkillsState) buildGraph(skillMap map[string]player.SkillNode) (graph.Graph[string, player.SkillNode], []string) {
-	g := graph.New(skillNodeHash, graph.Directed(), graph.PreventCycles())
-
-	for _, skill := range skillMap {
-		_ = g.AddVertex(skill)
-	}
-
-	for _, skill := range skillMap {
-		for _, reqID := range skill.Requirements {
-			if strings.HasPrefix(reqID, "level_") {
-				continue
-			}
-			if _, err := g.Vertex(reqID); err == nil {
-				_ = g.AddEdge(reqID, skill.ID)
-			}
-		}
-	}
-
-	sortedIDs, _ := graph.TopologicalSort(g)
-	
-	sort.SliceStable(sortedIDs, func(i, j int) bool {
-		skillA, _ := g.Vertex(sortedIDs[i])
-		skillB, _ := g.Vertex(sortedIDs[j])
-		if skillA.Position.Y != skillB.Position.Y {
-			return skillA.Position.Y < skillB.Position.Y
-		}
-		return skillA.Position.X < skillB.Position.X
-	})
-
-	return g, sortedIDs
-}
-
 func (s *SkillsState) Init() tea.Cmd {
 	return nil
 }
@@ -139,7 +100,7 @@ func (s *SkillsState) View(m *Model) string {
 	mainContent := lipgloss.JoinHorizontal(lipgloss.Top, treeContent, infoBox)
 	b.WriteString(mainContent + "\n\n")
 
-	help := "Навигация: ↑↓ | Tab: сменить вид | Enter: изучить | q: назад"
+	help := i18n.T("tui.skills.help")
 	if s.statusMessage != "" {
 		help = s.statusMessage
 	}
@@ -176,169 +137,71 @@ func (s *SkillsState) unlockSkill(m *Model) {
 	g := s.getActiveGraph()
 	node, _ := g.Vertex(skillID)
 
-	// Создаем плоскую карту всех навыков в текущем графе для IsSkillAvailable
-	allSkillsInGraph := make(map[string]player.SkillNode)
-	adjMap, _ := g.AdjacencyMap()
-	for id := range adjMap {
-		v, _ := g.Vertex(id)
-		allSkillsInGraph[id] = v
-	}
-
-	if rpg.IsSkillUnlocked(m.Player, node.ID) {
-		s.statusMessage = "✅ Навык уже изучен."
-		return
-	}
-
-	if !rpg.IsSkillAvailable(m.Player, node, allSkillsInGraph) {
-		if m.Player.SkillPoints <= 0 {
-			s.statusMessage = "❗ Недостаточно очков навыков."
-		} else {
-			s.statusMessage = fmt.Sprintf("🔒 Требования для '%s' не выполнены.", node.Name)
-		}
-		return
-	}
-
-	if m.Player.SkillPoints <= 0 {
-		s.statusMessage = "❗ Недостаточно очков навыков."
-		return
+	_, err := game.UnlockSkill(&game.World{Player: m.Player}, node, rpg.SkillMap(g))
+	switch err {
+	case nil:
+		player.SavePlayer(m.Player)
+		m.refreshEffects()
+		s.statusMessage = i18n.T("skill.learned", node.Name)
+	case rpg.ErrSkillUnlocked:
+		s.statusMessage = i18n.T("skill.already_learned")
+	case rpg.ErrNoSkillPoints:
+		s.statusMessage = i18n.T("skill.no_points")
+	default:
+		s.statusMessage = i18n.T("skill.reqs_not_met", node.Name)
 	}
-
-	m.Player.UnlockedSkills = append(m.Player.UnlockedSkills, node.ID)
-	m.Player.SkillPoints--
-	player.SavePlayer(m.Player)
-	s.statusMessage = fmt.Sprintf("✨ Навык '%s' изучен!", node.Name)
 }
 
 // --- Хелперы для View ---
 
 func (s *SkillsState) getViewTitle(m *Model) string {
-	viewName := "Общие навыки"
+	viewName := i18n.T("tui.skills.common")
 	if s.currentView == viewClass {
-		viewName = fmt.Sprintf("Навыки класса: %s", m.Player.Class)
+		viewName = i18n.T("tui.skills.class", rpg.ClassName(m.Player.Class))
 	}
-	return fmt.Sprintf("🧠 Дерево навыков (%s) | Очки: %d", viewName, m.Player.SkillPoints)
+	return i18n.T("tui.skills.title", viewName, m.Player.SkillPoints)
 }
 
 func (s *SkillsState) renderTree(m *Model) string {
 	g := s.getActiveGraph()
 	ids := s.getActiveIDs()
 	if len(ids) == 0 {
-		return styleInfoBox.Render("Нет доступных навыков в этой категории.")
+		return styleInfoBox.Render(i18n.T("tui.skills.empty"))
 	}
 
-	var b bytes.Buffer
-	
-	var roots []string
-	adjMap, _ := g.AdjacencyMap()
-	for id := range adjMap {
-		// Узел является корневым, если на него никто не ссылается.
-		// Проверяем, есть ли он в качестве цели в каком-либо ребре.
-		isRoot := true
-		for _, edges := range adjMap {
-			if _, ok := edges[id]; ok {
-				isRoot = false
-				break
-			}
-		}
-		if isRoot {
-			roots = append(roots, id)
+	cursorID := ids[s.cursorIndex]
+	allSkillsInGraph := rpg.SkillMap(g)
+	return rpg.RenderSkillTree(g, func(skill player.SkillNode) string {
+		var style lipgloss.Style
+		var icon string
+
+		switch rpg.GetSkillStatus(m.Player, skill, allSkillsInGraph) {
+		case rpg.SkillUnlocked:
+			style = styleSkillUnlocked
+			icon = "✓"
+		case rpg.SkillAvailable:
+			style = styleSkillAvailable
+			icon = "+"
+		case rpg.SkillNoPoints:
+			style = styleSkillLocked
+			icon = "!"
+		default:
+			style = styleSkillUnavailable
+			icon = " "
 		}
-	}
 
-	// Сортируем корневые узлы для стабильного отображения
-	sort.Slice(roots, func(i, j int) bool {
-		sA, _ := g.Vertex(roots[i])
-		sB, _ := g.Vertex(roots[j])
-		if sA.Position.Y != sB.Position.Y {
-			return sA.Position.Y < sB.Position.Y
+		nodeStr := fmt.Sprintf("[%s] %s %s", icon, skill.Icon, skill.Name)
+		if skill.ID == cursorID {
+			return lipgloss.NewStyle().Background(lipgloss.Color("237")).Render(nodeStr)
 		}
-		return sA.Position.X < sB.Position.X
+		return style.Render(nodeStr)
 	})
-
-
-	for _, rootID := range roots {
-		s.drawNode(&b, m, rootID, "", true)
-	}
-
-	return b.String()
-}
-
-func (s *SkillsState) drawNode(b *bytes.Buffer, m *Model, skillID, prefix string, isLast bool) {
-	g := s.getActiveGraph()
-	skill, _ := g.Vertex(skillID)
-
-	isCursor := skillID == s.getActiveIDs()[s.cursorIndex]
-	isUnlocked := rpg.IsSkillUnlocked(m.Player, skill.ID)
-	
-	allSkillsInGraph := make(map[string]player.SkillNode)
-	adjMap, _ := g.AdjacencyMap()
-	for id := range adjMap {
-		v, _ := g.Vertex(id)
-		allSkillsInGraph[id] = v
-	}
-	isAvailable := rpg.IsSkillAvailable(m.Player, skill, allSkillsInGraph)
-
-	var style lipgloss.Style
-	var icon string
-
-	switch {
-	case isUnlocked:
-		style = styleSkillUnlocked
-		icon = "✓"
-	case isAvailable && m.Player.SkillPoints > 0:
-		style = styleSkillAvailable
-		icon = "+"
-	case isAvailable && m.Player.SkillPoints <= 0:
-		style = styleSkillLocked
-		icon = "!"
-	default:
-		style = styleSkillUnavailable
-		icon = " "
-	}
-
-	nodeStr := fmt.Sprintf("[%s] %s %s", icon, skill.Icon, skill.Name)
-	if isCursor {
-		nodeStr = lipgloss.NewStyle().Background(lipgloss.Color("237")).Render(nodeStr)
-	} else {
-		nodeStr = style.Render(nodeStr)
-	}
-
-	b.WriteString(prefix)
-	if isLast {
-		b.WriteString("└─ ")
-		prefix += "   "
-	} else {
-		b.WriteString("├─ ")
-		prefix += "│  "
-	}
-	b.WriteString(nodeStr)
-	b.WriteString("\n")
-
-	childrenMap := adjMap[skillID]
-	
-	var sortedChildren []string
-	for childID := range childrenMap {
-		sortedChildren = append(sortedChildren, childID)
-	}
-	
-	sort.Slice(sortedChildren, func(i, j int) bool {
-		sA, _ := g.Vertex(sortedChildren[i])
-		sB, _ := g.Vertex(sortedChildren[j])
-		if sA.Position.Y != sB.Position.Y {
-			return sA.Position.Y < sB.Position.Y
-		}
-		return sA.Position.X < sB.Position.X
-	})
-
-	for i, childID := range sortedChildren {
-		s.drawNode(b, m, childID, prefix, i == len(sortedChildren)-1)
-	}
 }
 
 func (s *SkillsState) renderInfoBox(m *Model) string {
 	ids := s.getActiveIDs()
 	if len(ids) == 0 || s.cursorIndex >= len(ids) {
-		return styleInfoBox.Width(50).Render("Выберите навык...")
+		return styleInfoBox.Width(50).Render(i18n.T("tui.skills.choose"))
 	}
 	skillID := ids[s.cursorIndex]
 	g := s.getActiveGraph()
@@ -347,28 +210,18 @@ func (s *SkillsState) renderInfoBox(m *Model) string {
 	title := fmt.Sprintf("%s %s", node.Icon, node.Name)
 
 	var status, statusStyled string
-	isUnlocked := rpg.IsSkillUnlocked(m.Player, node.ID)
-	
-	allSkillsInGraph := make(map[string]player.SkillNode)
-	adjMap, _ := g.AdjacencyMap()
-	for id := range adjMap {
-		v, _ := g.Vertex(id)
-		allSkillsInGraph[id] = v
-	}
-	isAvailable := rpg.IsSkillAvailable(m.Player, node, allSkillsInGraph)
-
-	switch {
-	case isUnlocked:
-		status = "ИЗУЧЕНО"
+	switch rpg.GetSkillStatus(m.Player, node, rpg.SkillMap(g)) {
+	case rpg.SkillUnlocked:
+		status = i18n.T("skill.status_learned")
 		statusStyled = styleSkillUnlocked.Render(fmt.Sprintf("[%s]", status))
-	case isAvailable && m.Player.SkillPoints > 0:
-		status = "ДОСТУПНО"
+	case rpg.SkillAvailable:
+		status = i18n.T("skill.status_available")
 		statusStyled = styleSkillAvailable.Render(fmt.Sprintf("[%s]", status))
-	case isAvailable && m.Player.SkillPoints <= 0:
-		status = "НЕ ХВАТАЕТ ОЧКОВ"
+	case rpg.SkillNoPoints:
+		status = i18n.T("skill.status_no_points")
 		statusStyled = styleSkillLocked.Render(fmt.Sprintf("[%s]", status))
 	default:
-		status = "ЗАБЛОКИРОВАНО"
+		status = i18n.T("skill.status_locked")
 		statusStyled = styleSkillUnavailable.Render(fmt.Sprintf("[%s]", status))
 	}
 
@@ -387,7 +240,7 @@ func (s *SkillsState) renderInfoBox(m *Model) string {
 
 func (s *SkillsState) buildRequirementsString(node player.SkillNode, p *player.Player) string {
 	if len(node.Requirements) == 0 {
-		return "Требования: нет"
+		return i18n.T("skill.reqs_none")
 	}
 
 	var reqs []string
@@ -399,7 +252,7 @@ func (s *SkillsState) buildRequirementsString(node player.SkillNode, p *player.P
 
 		if level, found := strings.CutPrefix(reqID, "level_"); found {
 			reqLevel, _ := strconv.Atoi(level)
-			reqStr = fmt.Sprintf("Уровень %s", level)
+			reqStr = i18n.T("skill.req_level", level)
 			if p.Level >= reqLevel {
 				style = styleSkillUnlocked
 			} else {
@@ -419,9 +272,9 @@ func (s *SkillsState) buildRequirementsString(node player.SkillNode, p *player.P
 		reqs = append(reqs, style.Render(reqStr))
 	}
 	if len(reqs) == 0 {
-		return "Требования: нет"
+		return i18n.T("skill.reqs_none")
 	}
-	return "Требует: " + strings.Join(reqs, ", ")
+	return i18n.T("skill.reqs") + strings.Join(reqs, ", ")
 }
 
 // --- Вспомогательные функции ---
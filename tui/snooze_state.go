@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"magus/i18n"
+	"magus/snooze"
+	"magus/storage"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SnoozeState — ввод срока, на который откладывается квест.
+type SnoozeState struct {
+	questID string
+	title   string
+	input   textinput.Model
+	errMsg  string
+}
+
+func NewSnoozeState(m *Model, questID, title string) *SnoozeState {
+	ti := textinput.New()
+	ti.Placeholder = "1d"
+	ti.SetValue("1d")
+	ti.CharLimit = 10
+	ti.Width = 20
+	ti.Focus()
+	return &SnoozeState{questID: questID, title: title, input: ti}
+}
+
+func (s *SnoozeState) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (s *SnoozeState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "esc":
+			return PopState{}, nil
+		case "enter":
+			return s.apply(m)
+		}
+	}
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	return s, cmd
+}
+
+// apply откладывает квест на введенный срок.
+func (s *SnoozeState) apply(m *Model) (State, tea.Cmd) {
+	now := time.Now()
+	until, err := snooze.Parse(s.input.Value(), now, m.settings().DayStartHour)
+	if err != nil {
+		s.errMsg = i18n.T("tui.snooze.err_duration", s.input.Value())
+		return s, nil
+	}
+	for i := range m.Quests {
+		if m.Quests[i].ID == s.questID {
+			snooze.Until(&m.Quests[i], until, now)
+		}
+	}
+	storage.SaveAllQuests(m.Quests)
+	return PopState{}, nil
+}
+
+func (s *SnoozeState) View(m *Model) string {
+	var b strings.Builder
+	b.WriteString(m.styles.TitleStyle.Render(i18n.T("tui.snooze.title", s.title)) + "\n\n")
+	b.WriteString(s.input.View() + "\n\n")
+	if s.errMsg != "" {
+		b.WriteString(m.styles.DeadlineStyle.Render(s.errMsg) + "\n\n")
+	}
+	b.WriteString(m.styles.StatusMessageStyle.Render(i18n.T("tui.snooze.help")))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
@@ -37,5 +37,6 @@ type DungeonResult struct {
 	Duration           time.Duration
 	DistractionAttacks int
 	Success            bool
-	XPEarned           int // Добавлено для случая досрочного выхода из старой версии
+	XPEarned           int      // Добавлено для случая досрочного выхода из старой версии
+	QuestIDs           []string // Квесты, выбранные для сессии
 }
\ No newline at end of file
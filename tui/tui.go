@@ -1,8 +1,16 @@
 package tui
 
 import (
+	"magus/config"
+	"magus/effects"
+	"magus/game"
+	"magus/i18n"
 	"magus/player"
+	"magus/recur"
+	"magus/ritual"
 	"magus/storage"
+	"magus/tags"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbletea"
@@ -15,6 +23,11 @@ type Model struct {
 
 	Player         *player.Player
 	Quests         []player.Quest
+	Config         *config.Config
+	Tags           tags.Registry // Настройки тегов из data/tags.json
+	Effects        effects.Sheet // Эффекты навыков и класса (см. refreshEffects)
+	undo           *undoStep     // Последняя массовая операция в списке квестов
+	Notice         string        // Сообщение для главного экрана (например, о прерванных сериях)
 	TerminalWidth  int
 	TerminalHeight int
 	ready          bool // Флаг готовности к отрисовке
@@ -37,6 +50,8 @@ type Styles struct {
 	GoalIcon               string
 	RitualIcon             string
 	FocusIcon              string
+	BlockedIcon            string
+	MarkedIcon             string
 	CollapseIconOpened     string
 	CollapseIconClosed     string
 	SubQuestIndent         string
@@ -59,6 +74,8 @@ func NewStyles() Styles {
 		GoalIcon:               "🏆",
 		RitualIcon:             "💧",
 		FocusIcon:              "🎯",
+		BlockedIcon:            "⛔",
+		MarkedIcon:             lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Render("●"),
 		CollapseIconOpened:     "▼",
 		CollapseIconClosed:     "▶",
 		SubQuestIndent:         "   ",
@@ -66,14 +83,23 @@ func NewStyles() Styles {
 }
 
 func InitialModel() *Model {
+	cfg, _ := config.Load()
 	p, err := player.LoadPlayer()
 	if err != nil {
 		return &Model{
 			currentState: NewCreatePlayerState(),
+			Config:       cfg,
 			styles:       NewStyles(),
 		}
 	}
 
+	// Максимумы HP и маны зависят от навыков и класса, порог уровня — от кривой опыта
+	sheet, _ := effects.Load(p)
+	synced := game.SyncLevel(&game.World{Player: p, Curve: cfg.Curve()})
+	if sheet.Apply(p) || synced {
+		player.SavePlayer(p)
+	}
+
 	if !p.LastSeen.IsZero() {
 		minutesPassed := time.Since(p.LastSeen).Minutes()
 		hpToRestore := int(minutesPassed / 5)
@@ -87,20 +113,57 @@ func InitialModel() *Model {
 	}
 
 	quests, _ := storage.LoadAllQuests()
+	// Прерванные серии ритуалов проверяем до того, как пропущенные экземпляры будут заменены
+	now := time.Now()
+	broken := ritual.CheckStreaks(quests, now, cfg.DayStartHour)
+	// Повторяющиеся квесты получают актуальные экземпляры
+	changed := recur.Roll(quests, now) || len(broken) > 0
+	// За каждый новый день просрочки игрок теряет HP, а награда квеста тает
+	w := &game.World{Player: p, Quests: quests, DayStartHour: cfg.DayStartHour, Effects: sheet, Curve: cfg.Curve()}
+	overdue := game.ApplyOverdue(w, cfg.OverdueHP(), cfg.OverdueXPDecay(), now)
+	if len(overdue) > 0 {
+		changed = true
+		player.SavePlayer(p)
+	}
+	// Давно выполненные квесты уходят в архив
+	archived := 0
+	if cutoff, ok := cfg.ArchiveCutoff(now); ok {
+		quests, archived, _ = storage.AutoArchive(quests, cutoff)
+	}
+	if changed || archived > 0 {
+		storage.SaveAllQuests(quests)
+	}
 
+	registry, _ := tags.Load()
 	m := &Model{
-		Player: p,
-		Quests: quests,
-		styles: NewStyles(),
+		Player:  p,
+		Quests:  quests,
+		Config:  cfg,
+		Tags:    registry,
+		Effects: sheet,
+		styles:  NewStyles(),
+	}
+	for _, q := range broken {
+		m.Notice += i18n.T("ritual.streak_broken", q.Title, q.Streak) + "\n"
+	}
+	m.Notice += overdueNotice(overdue)
+	if archived > 0 {
+		m.Notice += i18n.T("archive.moved", archived) + "\n"
 	}
 
-	if p.XP >= p.NextLevelXP {
-		levelUpState, err := NewLevelUpState(m)
-		if err != nil {
-			m.currentState = NewHomepageState(m)
-		} else {
+	if game.PendingLevels(w) > 0 {
+		levelUpState, status := NewLevelUpState(m)
+		if levelUpState != nil {
 			m.currentState = levelUpState
+		} else {
+			if status != "" {
+				m.Notice += status + "\n"
+			}
+			m.currentState = NewHomepageState(m)
 		}
+	} else if p.Class == player.ClassNone {
+		// Игрок без класса (старое сохранение) выбирает его при запуске
+		m.currentState = NewClassChoiceState(m)
 	} else {
 		m.currentState = NewHomepageState(m)
 	}
@@ -108,6 +171,36 @@ func InitialModel() *Model {
 	return m
 }
 
+// refreshEffects пересчитывает эффекты после изменения навыков, уровня или
+// класса и записывает новые максимумы HP и маны в игрока.
+func (m *Model) refreshEffects() {
+	m.Effects, _ = effects.Load(m.Player)
+	if m.Effects.Apply(m.Player) {
+		player.SavePlayer(m.Player)
+	}
+}
+
+// levelUp повышает уровень на один с учетом кривой опыта и бонусов класса
+// (см. game.LevelUp) и сохраняет игрока. Возвращает false, если опыта
+// не хватает.
+func (m *Model) levelUp() bool {
+	w := &game.World{Player: m.Player, Effects: m.Effects, Curve: m.settings().Curve()}
+	if game.LevelUp(w) == nil {
+		return false
+	}
+	player.SavePlayer(m.Player)
+	m.refreshEffects()
+	return true
+}
+
+// settings возвращает настройки, а если они не загружены — значения по умолчанию.
+func (m *Model) settings() *config.Config {
+	if m.Config == nil {
+		return config.Default()
+	}
+	return m.Config
+}
+
 func (m *Model) pushState(newState State) {
 	m.stateStack = append(m.stateStack, m.currentState)
 	m.currentState = newState
@@ -172,7 +265,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *Model) View() string {
 	if !m.ready {
-		return "\n  Initializing..."
+		return "\n  " + i18n.T("tui.initializing")
 	}
 	return m.currentState.View(m)
 }
@@ -181,3 +274,19 @@ func Start() error {
 	p := tea.NewProgram(InitialModel(), tea.WithAltScreen())
 	return p.Start()
 }
+
+// overdueNotice описывает штрафы за просрочку для главного экрана.
+func overdueNotice(events []game.Event) string {
+	var b strings.Builder
+	for _, e := range events {
+		switch e.Kind {
+		case game.EventQuestOverdue:
+			b.WriteString(i18n.T("overdue.quest", e.Quest.Title, e.Amount) + "\n")
+		case game.EventXPDecayed:
+			b.WriteString(i18n.T("overdue.xp_decayed", e.Amount, e.Quest.XP) + "\n")
+		case game.EventHPLost:
+			b.WriteString(i18n.T("overdue.hp_lost", e.Amount) + "\n")
+		}
+	}
+	return b.String()
+}
@@ -1,8 +1,15 @@
 package tui
 
 import (
+	"magus/i18n"
 	"magus/player"
+	"magus/ritual"
+	"magus/storage"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -17,6 +24,19 @@ func newTestModel() *Model {
 	}
 }
 
+// useTempData переносит файлы квестов, сессий и игрока во временный каталог,
+// чтобы тест не трогал данные в tui/data.
+func useTempData(t *testing.T) {
+	dir := t.TempDir()
+	questFile, sessionsFile, playerFile := storage.QuestFile, storage.SessionsFile, player.PlayerFile
+	storage.QuestFile = filepath.Join(dir, "quests.json")
+	storage.SessionsFile = filepath.Join(dir, "sessions.json")
+	player.PlayerFile = filepath.Join(dir, "player.json")
+	t.Cleanup(func() {
+		storage.QuestFile, storage.SessionsFile, player.PlayerFile = questFile, sessionsFile, playerFile
+	})
+}
+
 // TestDungeonPrepFocusing проверяет переключение фокуса в dungeonPrepModel.
 func TestDungeonPrepFocusing(t *testing.T) {
 	m := newTestModel()
@@ -125,3 +145,425 @@ func TestQuestCompletion(t *testing.T) {
 		t.Fatal("expected a status message command, but got nil")
 	}
 }
+
+// TestRitualCooldown проверяет, что ритуал нельзя выполнять ради маны много раз подряд.
+func TestRitualCooldown(t *testing.T) {
+	useTempData(t)
+
+	m := newTestModel()
+	m.Player.Mana = 0
+	m.Player.MaxMana = 100
+	player.SavePlayer(m.Player)
+	m.Quests = append(m.Quests, player.Quest{ID: "ritual-1", Title: "Walk", Type: player.TypeRitual})
+
+	s := NewQuestsState(m)
+	enterKey := tea.KeyMsg{Type: tea.KeyEnter}
+	s.Update(m, enterKey)
+	s.Update(m, enterKey)
+
+	p, err := player.LoadPlayer()
+	if err != nil {
+		t.Fatalf("could not load player: %v", err)
+	}
+	if p.Mana != ritual.BaseMana {
+		t.Errorf("expected mana %d after two presses, got %d", ritual.BaseMana, p.Mana)
+	}
+	if m.Quests[0].Streak != 1 || len(m.Quests[0].Completions) != 1 {
+		t.Errorf("expected one logged completion with streak 1, got %d/%d", len(m.Quests[0].Completions), m.Quests[0].Streak)
+	}
+}
+
+// TestBlockedQuestNotCompletable проверяет, что квест, ждущий другой квест, нельзя завершить.
+func TestBlockedQuestNotCompletable(t *testing.T) {
+	useTempData(t)
+
+	m := newTestModel()
+	m.Quests = []player.Quest{
+		{ID: "blocked", Title: "Ship", Type: player.TypeGoal, XP: 10, BlockedBy: []string{"blocker"}},
+		{ID: "blocker", Title: "Build", Type: player.TypeGoal, XP: 10},
+	}
+
+	s := NewQuestsState(m)
+	item := s.list.SelectedItem().(QuestListItem)
+	if item.ID != "blocked" || !item.Blocked() {
+		t.Fatalf("expected the first item to be blocked, got %+v", item)
+	}
+
+	s.Update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Quests[0].Completed {
+		t.Error("blocked quest was completed")
+	}
+}
+
+// TestUrgencySort проверяет смену приоритета и сортировку по срочности в списке квестов.
+func TestUrgencySort(t *testing.T) {
+	useTempData(t)
+
+	m := newTestModel()
+	m.Quests = []player.Quest{
+		{ID: "calm", Title: "Calm", Type: player.TypeGoal},
+		{ID: "urgent", Title: "Urgent", Type: player.TypeGoal},
+	}
+
+	s := NewQuestsState(m)
+	s.list.Select(1)
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+
+	s.Update(m, key('p')) // none → low
+	s.Update(m, key('p')) // low → medium
+	if m.Quests[1].Priority != player.PriorityMedium {
+		t.Fatalf("priority = %q, want medium", m.Quests[1].Priority)
+	}
+
+	s.Update(m, key('o'))
+	first := s.list.Items()[0].(QuestListItem)
+	if first.ID != "urgent" || first.Urgency == 0 {
+		t.Errorf("first item after sort = %s (urgency %.1f), want urgent", first.ID, first.Urgency)
+	}
+
+	s.Update(m, key('o'))
+	if first := s.list.Items()[0].(QuestListItem); first.ID != "calm" {
+		t.Errorf("first item after unsort = %s, want calm", first.ID)
+	}
+}
+
+// TestCompletedToggle проверяет, что выполненные квесты скрыты по умолчанию,
+// но учитываются в прогрессе цели и показываются по клавише c.
+func TestCompletedToggle(t *testing.T) {
+	useTempData(t)
+
+	future := time.Now().Add(24 * time.Hour)
+	m := newTestModel()
+	m.Quests = []player.Quest{
+		{ID: "goal", Title: "Goal", Type: player.TypeGoal},
+		{ID: "done", Title: "Done", Type: player.TypeGoal, ParentID: "goal", Completed: true},
+		{ID: "open", Title: "Open", Type: player.TypeGoal, ParentID: "goal"},
+		{ID: "old", Title: "Old", Type: player.TypeGoal, Completed: true},
+		{ID: "later", Title: "Later", Type: player.TypeGoal, ParentID: "goal", StartAt: &future},
+	}
+
+	s := NewQuestsState(m)
+	s.selectQuest("open")
+	var ids []string
+	for _, item := range s.list.Items() {
+		ids = append(ids, item.(QuestListItem).ID)
+	}
+	if strings.Join(ids, ",") != "goal,open" {
+		t.Fatalf("visible quests = %v, want [goal open]", ids)
+	}
+	// Прогресс цели одинаков во всех режимах и учитывает отложенную подзадачу
+	checkRollup := func(mode string) {
+		t.Helper()
+		if goal := s.list.Items()[0].(QuestListItem); goal.Rollup != 1.0/3 {
+			t.Errorf("%s: goal rollup = %.2f, want 0.33", mode, goal.Rollup)
+		}
+	}
+	checkRollup("completed hidden")
+
+	s.Update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if n := len(s.list.Items()); n != 4 {
+		t.Errorf("items with completed shown = %d, want 4", n)
+	}
+	checkRollup("completed shown")
+
+	s.byUrgency = true
+	s.list.SetItems(s.buildItems(s.list.Items()))
+	checkRollup("urgency sort")
+}
+
+// TestEditQuestNotes проверяет, что экран редактирования сохраняет описание,
+// ссылки и добавляет новую заметку с датой.
+func TestEditQuestNotes(t *testing.T) {
+	useTempData(t)
+
+	m := newTestModel()
+	m.Quests = []player.Quest{{ID: "q", Title: "Doc", Type: player.TypeGoal}}
+
+	s := NewEditQuestState(m, m.Quests[0])
+	s.description.SetValue("# Plan\n- draft")
+	s.note.SetValue("talked to the team")
+	s.inputs[5].SetValue("https://example.com, ~/plan.md")
+	s.Update(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+
+	q := m.Quests[0]
+	if q.Description != "# Plan\n- draft" || len(q.Links) != 2 {
+		t.Errorf("description %q, links %v", q.Description, q.Links)
+	}
+	if len(q.Notes) != 1 || q.Notes[0].Text != "talked to the team" || q.Notes[0].Date.IsZero() {
+		t.Errorf("notes = %+v", q.Notes)
+	}
+}
+
+// TestSnoozeHidesQuest проверяет, что отложенный квест уходит из списка
+// квестов в предстоящие и возвращается оттуда.
+func TestSnoozeHidesQuest(t *testing.T) {
+	useTempData(t)
+
+	m := newTestModel()
+	m.Quests = []player.Quest{
+		{ID: "goal", Title: "Goal", Type: player.TypeGoal},
+		{ID: "child", Title: "Child", Type: player.TypeGoal, ParentID: "goal"},
+		{ID: "open", Title: "Open", Type: player.TypeGoal},
+	}
+
+	snoozeState := NewSnoozeState(m, "goal", "Goal")
+	snoozeState.input.SetValue("3d")
+	if next, _ := snoozeState.Update(m, tea.KeyMsg{Type: tea.KeyEnter}); next != (PopState{}) {
+		t.Fatalf("snooze not applied: %q", snoozeState.errMsg)
+	}
+
+	s := NewQuestsState(m)
+	if items := s.list.Items(); len(items) != 1 || items[0].(QuestListItem).ID != "open" {
+		t.Fatalf("visible quests = %+v, want only open", items)
+	}
+
+	upcoming := NewUpcomingState(m)
+	if q := upcoming.quests(m); len(q) != 1 || q[0].ID != "goal" {
+		t.Fatalf("upcoming = %+v, want goal", q)
+	}
+	upcoming.Update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if n := len(NewQuestsState(m).list.Items()); n != 2 {
+		t.Errorf("items after wake = %d, want 2", n)
+	}
+}
+
+// TestSessionTimeSplit проверяет распределение времени сессии между квестами.
+func TestSessionTimeSplit(t *testing.T) {
+	useTempData(t)
+
+	m := newTestModel()
+	m.Quests = []player.Quest{
+		{ID: "a", Title: "A", Type: player.TypeFocus},
+		{ID: "b", Title: "B", Type: player.TypeFocus},
+	}
+	result := DungeonResult{Duration: 25 * time.Minute, Success: true, QuestIDs: []string{"a", "b"}}
+	s := NewDungeonSummaryState(m, result).(*dungeonSummaryModel)
+
+	if got := s.splitInputs[0].Value() + "/" + s.splitInputs[1].Value(); got != "13/12" {
+		t.Fatalf("default split = %s, want 13/12", got)
+	}
+
+	s.splitInputs[0].SetValue("20")
+	s.splitInputs[1].SetValue("2")
+	s.finalizeSession(m)
+	if s.errMsg == "" || m.Quests[0].FocusMinutes != 0 {
+		t.Fatal("expected an error when the split doesn't add up")
+	}
+
+	s.splitInputs[1].SetValue("5")
+	s.finalizeSession(m)
+	if m.Quests[0].FocusMinutes != 20 || m.Quests[1].FocusMinutes != 5 {
+		t.Errorf("FocusMinutes = %d/%d, want 20/5", m.Quests[0].FocusMinutes, m.Quests[1].FocusMinutes)
+	}
+
+	sessions, _ := storage.LoadSessions()
+	if len(sessions) != 1 || len(sessions[0].Quests) != 2 {
+		t.Errorf("sessions = %+v, want one session with two quests", sessions)
+	}
+}
+
+// TestSessionDefeatsQuest проверяет, что сессия наносит урон и завершает побежденный квест.
+func TestSessionDefeatsQuest(t *testing.T) {
+	useTempData(t)
+
+	m := newTestModel()
+	m.Quests = []player.Quest{{ID: "boss", Title: "Boss", Type: player.TypeFocus, HP: 30, Progress: 10, XP: 50}}
+	result := DungeonResult{Duration: 25 * time.Minute, Success: true, QuestIDs: []string{"boss"}}
+	s := NewDungeonSummaryState(m, result).(*dungeonSummaryModel)
+
+	if view := s.damageView(m); len(view) != 2 {
+		t.Fatalf("damageView() = %v, want a header and one quest line", view)
+	}
+
+	s.finalizeSession(m)
+	if !m.Quests[0].Completed || m.Quests[0].Progress != 30 {
+		t.Errorf("quest after session: completed %v, progress %d", m.Quests[0].Completed, m.Quests[0].Progress)
+	}
+	if m.Notice == "" {
+		t.Error("expected a notice about the defeated quest")
+	}
+}
+
+// TestBulkTagAndUndo проверяет массовое добавление тега отмеченным квестам
+// и отмену операции.
+func TestBulkTagAndUndo(t *testing.T) {
+	useTempData(t)
+
+	m := newTestModel()
+	m.Quests = []player.Quest{
+		{ID: "a", Title: "A", Type: player.TypeGoal},
+		{ID: "b", Title: "B", Type: player.TypeGoal},
+		{ID: "c", Title: "C", Type: player.TypeGoal},
+	}
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	s := NewQuestsState(m)
+	s.Update(m, space)
+	s.Update(m, space)
+	if len(s.marked) != 2 {
+		t.Fatalf("marked = %v, want 2 quests", s.marked)
+	}
+
+	s.Update(m, key('+'))
+	s.bulkInput.SetValue("work")
+	s.Update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	s.Update(m, key('y'))
+
+	tagged := 0
+	for _, q := range m.Quests {
+		if len(q.Tags) == 1 && q.Tags[0] == "work" {
+			tagged++
+		}
+	}
+	if tagged != 2 || len(s.marked) != 0 {
+		t.Fatalf("tagged = %d, marked = %d; want 2 and 0", tagged, len(s.marked))
+	}
+
+	s.Update(m, key('u'))
+	for _, q := range m.Quests {
+		if len(q.Tags) != 0 {
+			t.Errorf("quest %s still has tags %v after undo", q.ID, q.Tags)
+		}
+	}
+}
+
+// TestBulkDeadlines проверяет, что новый срок начинает счет просрочки
+// заново, а снятый срок обнуляет его.
+func TestBulkDeadlines(t *testing.T) {
+	useTempData(t)
+
+	m := newTestModel()
+	m.Quests = []player.Quest{{ID: "a", Title: "A", Type: player.TypeFocus}}
+	s := NewQuestsState(m)
+
+	s.setDeadlines(m.Quests, "2030-01-01 18:00")
+	if q := s.allQuests[0]; q.Deadline == nil || !q.DeadlineTime || q.PenaltyAt == nil {
+		t.Fatalf("after setting a deadline: %+v", q)
+	}
+	s.setDeadlines(m.Quests, "")
+	if q := s.allQuests[0]; q.Deadline != nil || q.PenaltyAt != nil {
+		t.Errorf("after clearing the deadline: deadline %v, penalty_at %v", q.Deadline, q.PenaltyAt)
+	}
+}
+
+// TestReorderAndIndent проверяет ручной порядок квестов и перенос под
+// соседа клавишами K и >.
+func TestReorderAndIndent(t *testing.T) {
+	useTempData(t)
+
+	m := newTestModel()
+	m.Quests = []player.Quest{
+		{ID: "a", Title: "A", Type: player.TypeGoal},
+		{ID: "b", Title: "B", Type: player.TypeGoal},
+	}
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+
+	s := NewQuestsState(m)
+	s.list.Select(1)
+	s.Update(m, key('K'))
+	if items := s.list.Items(); items[0].(QuestListItem).ID != "b" || s.list.Index() != 0 {
+		t.Fatalf("after K: first = %s, cursor = %d; want b and 0", items[0].(QuestListItem).ID, s.list.Index())
+	}
+
+	s.list.Select(1)
+	s.Update(m, key('>'))
+	items := s.list.Items()
+	if len(items) != 2 || items[1].(QuestListItem).ID != "a" || items[1].(QuestListItem).Depth != 1 {
+		t.Fatalf("after >: items = %+v, want a under b", items)
+	}
+	if m.Quests[0].ParentID != "b" {
+		t.Errorf("a parent = %q, want b", m.Quests[0].ParentID)
+	}
+
+	s.Update(m, key('<'))
+	if m.Quests[0].ParentID != "" {
+		t.Errorf("a parent after < = %q, want top level", m.Quests[0].ParentID)
+	}
+}
+
+// TestMoveRechecksGoals проверяет, что открытый квест нельзя перенести под
+// выполненную цель, а цель, из которой ушла последняя открытая подзадача,
+// завершается.
+func TestMoveRechecksGoals(t *testing.T) {
+	useTempData(t)
+
+	m := newTestModel()
+	m.Quests = []player.Quest{
+		{ID: "done", Title: "Done", Type: player.TypeGoal, Completed: true},
+		{ID: "open", Title: "Open", Type: player.TypeFocus},
+		{ID: "g", Title: "G", Type: player.TypeGoal, XP: 30},
+		{ID: "g1", Title: "G1", Type: player.TypeFocus, ParentID: "g", Completed: true},
+		{ID: "g2", Title: "G2", Type: player.TypeFocus, ParentID: "g"},
+		{ID: "h", Title: "H", Type: player.TypeGoal, XP: 20},
+		{ID: "h1", Title: "H1", Type: player.TypeFocus, ParentID: "h", Completed: true},
+		{ID: "h2", Title: "H2", Type: player.TypeFocus, ParentID: "h"},
+	}
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	s := NewQuestsState(m)
+
+	// Под выполненную цель открытый квест не переносится
+	s.showCompleted = true
+	s.selectQuest("open")
+	if _, cmd := s.Update(m, key('>')); m.Quests[1].ParentID != "" || cmd == nil {
+		t.Errorf("open quest moved under a completed goal")
+	}
+	if msg, _ := s.moveAll(m, []player.Quest{m.Quests[1]}, "done"); m.Quests[1].ParentID != "" {
+		t.Errorf("bulk move under a completed goal: %s", msg)
+	}
+
+	// Последняя открытая подзадача уходит — цель завершается
+	s.selectQuest("g2")
+	s.Update(m, key('<'))
+	if !m.Quests[2].Completed || m.Player.XP != 30 {
+		t.Errorf("goal after outdent: completed %v, XP %d", m.Quests[2].Completed, m.Player.XP)
+	}
+	s.moveAll(m, []player.Quest{m.Quests[7]}, "")
+	if !s.allQuests[5].Completed {
+		t.Error("goal left open after bulk move of its last open subquest")
+	}
+}
+
+// TestLevelUpScreens проверяет, что экран повышения показывается для каждого
+// уровня, даже без навыков, а если навыков нет ни на одном уровне, уровни
+// повышаются сразу с сообщением в строке статуса.
+func TestLevelUpScreens(t *testing.T) {
+	useTempData(t)
+	t.Chdir(t.TempDir())
+	writeTree := func(requirement string) {
+		t.Helper()
+		tree := `[{"id": "late", "name": "Late", "type": "STAT", "requirements": ["` + requirement + `"]}]`
+		if err := os.MkdirAll("data", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join("data", "skill_tree.json"), []byte(tree), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	// Опыта хватает на уровни 2 и 3, навык открывается только на третьем
+	writeTree("level_3")
+	m := newTestModel()
+	m.Player.XP = 500
+	s, status := NewLevelUpState(m)
+	if s == nil || m.Player.Level != 2 || len(s.skillChoices) != 0 {
+		t.Fatalf("first screen: state %v, status %q, level %d", s, status, m.Player.Level)
+	}
+	if view := s.View(m); !strings.Contains(view, i18n.T("tui.levelup.no_skills")) {
+		t.Errorf("empty level screen has no notice:\n%s", view)
+	}
+	next, _ := s.Update(m, enter)
+	third, ok := next.(*LevelUpState)
+	if !ok || m.Player.Level != 3 || len(third.skillChoices) != 1 {
+		t.Fatalf("second screen: %T, level %d", next, m.Player.Level)
+	}
+
+	// Навыков нет ни на одном новом уровне — экрана нет, есть сообщение
+	writeTree("level_10")
+	m = newTestModel()
+	m.Player.XP = 500
+	if s, status := NewLevelUpState(m); s != nil || status == "" || m.Player.Level != 3 {
+		t.Errorf("no skills: state %v, status %q, level %d", s, status, m.Player.Level)
+	}
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+	"magus/i18n"
+	"magus/player"
+	"magus/snooze"
+	"magus/storage"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// UpcomingState — предстоящие квесты: отложенные до даты начала.
+type UpcomingState struct {
+	cursor int
+}
+
+func NewUpcomingState(m *Model) *UpcomingState {
+	return &UpcomingState{}
+}
+
+func (s *UpcomingState) Init() tea.Cmd {
+	return nil
+}
+
+// quests возвращает отложенные квесты; список читается заново, так как
+// квест могли отложить еще раз или разбудить.
+func (s *UpcomingState) quests(m *Model) []player.Quest {
+	return snooze.Upcoming(m.Quests, time.Now())
+}
+
+func (s *UpcomingState) Update(m *Model, msg tea.Msg) (State, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return s, nil
+	}
+	upcoming := s.quests(m)
+	switch key.String() {
+	case "up", "k":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+	case "down", "j":
+		if s.cursor < len(upcoming)-1 {
+			s.cursor++
+		}
+	case "s":
+		if s.cursor < len(upcoming) {
+			q := upcoming[s.cursor]
+			return NewSnoozeState(m, q.ID, q.Title), nil
+		}
+	case "w", "enter":
+		if s.cursor < len(upcoming) {
+			s.wake(m, upcoming[s.cursor].ID)
+			if s.cursor >= len(upcoming)-1 && s.cursor > 0 {
+				s.cursor--
+			}
+		}
+	case "q", "esc":
+		return PopState{}, nil
+	}
+	return s, nil
+}
+
+// wake возвращает отложенный квест в список сразу.
+func (s *UpcomingState) wake(m *Model, questID string) {
+	for i := range m.Quests {
+		if m.Quests[i].ID == questID {
+			snooze.Wake(&m.Quests[i])
+		}
+	}
+	storage.SaveAllQuests(m.Quests)
+}
+
+func (s *UpcomingState) View(m *Model) string {
+	var b strings.Builder
+	b.WriteString(m.styles.TitleStyle.Render(i18n.T("tui.upcoming.title")) + "\n\n")
+
+	upcoming := s.quests(m)
+	if len(upcoming) == 0 {
+		b.WriteString(m.styles.StatusMessageStyle.Render(i18n.T("tui.upcoming.empty")) + "\n")
+	}
+	for i, q := range upcoming {
+		cursor := "  "
+		title := q.Title
+		if i == s.cursor {
+			cursor = "> "
+			title = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Render(title)
+		}
+		date := m.styles.DifficultyStyle.Render(q.StartAt.Format("2006-01-02 15:04"))
+		b.WriteString(fmt.Sprintf("%s%s  %s\n", cursor, date, title))
+	}
+
+	b.WriteString("\n" + m.styles.StatusMessageStyle.Render(i18n.T("tui.upcoming.help")))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
@@ -0,0 +1,199 @@
+// Package urgency вычисляет срочность квеста в духе Taskwarrior: сумму
+// слагаемых «коэффициент × фактор», где фактор лежит в [0, 1]. Учитываются
+// приоритет, близость срока, возраст, блокировки и теги.
+//
+// Коэффициенты можно переопределить в data/config.json:
+//
+//	"urgency": { "due": 15, "tag.work": 2, "blocked": -10 }
+package urgency
+
+import (
+	"magus/deadline"
+	"magus/deps"
+	"magus/player"
+	"magus/tags"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// dueWindow — за сколько дней до срока фактор начинает расти.
+	dueWindow = 14
+	// overdueCap — через сколько дней просрочки фактор достигает максимума.
+	overdueCap = 7
+	// ageMax — возраст в днях, после которого фактор возраста не растет.
+	ageMax = 365
+)
+
+// Coefficients — веса слагаемых срочности.
+type Coefficients struct {
+	PriorityHigh   float64
+	PriorityMedium float64
+	PriorityLow    float64
+	Due            float64 // Близость срока
+	Age            float64 // Возраст квеста
+	Blocked        float64 // Квест ждет другие квесты (обычно отрицательный)
+	Blocking       float64 // Квест держит другие квесты
+	Tags           float64 // Квест с тегами
+
+	// Tag — добавки за отдельные теги; действуют и на вложенные теги
+	Tag map[string]float64
+}
+
+// Default возвращает коэффициенты Taskwarrior по умолчанию.
+func Default() Coefficients {
+	return Coefficients{
+		PriorityHigh:   6.0,
+		PriorityMedium: 3.9,
+		PriorityLow:    1.8,
+		Due:            12.0,
+		Age:            2.0,
+		Blocked:        -5.0,
+		Blocking:       8.0,
+		Tags:           1.0,
+		Tag:            map[string]float64{},
+	}
+}
+
+// WithOverrides возвращает коэффициенты по умолчанию с переопределениями
+// из настроек. Ключи: "priority.high", "priority.medium", "priority.low",
+// "due", "age", "blocked", "blocking", "tags" и "tag.<имя>" для отдельных
+// тегов. Неизвестные ключи игнорируются.
+func WithOverrides(overrides map[string]float64) Coefficients {
+	c := Default()
+	for key, v := range overrides {
+		switch key {
+		case "priority.high":
+			c.PriorityHigh = v
+		case "priority.medium":
+			c.PriorityMedium = v
+		case "priority.low":
+			c.PriorityLow = v
+		case "due":
+			c.Due = v
+		case "age":
+			c.Age = v
+		case "blocked":
+			c.Blocked = v
+		case "blocking":
+			c.Blocking = v
+		case "tags":
+			c.Tags = v
+		default:
+			if tag, ok := strings.CutPrefix(key, "tag."); ok {
+				c.Tag[tag] = v
+			}
+		}
+	}
+	return c
+}
+
+// Scorer считает срочность квестов одного списка: блокировки зависят
+// от остальных квестов, поэтому индекс строится один раз.
+type Scorer struct {
+	coef     Coefficients
+	now      time.Time
+	index    map[string]player.Quest
+	blocking map[string]bool
+}
+
+// NewScorer готовит подсчет срочности для квестов на момент now.
+func NewScorer(quests []player.Quest, now time.Time, coef Coefficients) *Scorer {
+	index := deps.Index(quests)
+	blocking := make(map[string]bool)
+	for _, q := range quests {
+		if q.Completed {
+			continue
+		}
+		for _, id := range q.BlockedBy {
+			blocking[id] = true
+		}
+	}
+	return &Scorer{coef: coef, now: now, index: index, blocking: blocking}
+}
+
+// Score возвращает срочность квеста. У выполненных квестов она равна нулю.
+func (s *Scorer) Score(q player.Quest) float64 {
+	if q.Completed {
+		return 0
+	}
+	c := s.coef
+	var score float64
+
+	switch q.Priority {
+	case player.PriorityHigh:
+		score += c.PriorityHigh
+	case player.PriorityMedium:
+		score += c.PriorityMedium
+	case player.PriorityLow:
+		score += c.PriorityLow
+	}
+
+	if q.Deadline != nil {
+		score += c.Due * dueFactor(deadline.Due(*q.Deadline, q.DeadlineTime), s.now)
+	}
+	if !q.CreatedAt.IsZero() {
+		score += c.Age * ageFactor(q.CreatedAt, s.now)
+	}
+	if deps.IsBlocked(q, s.index) {
+		score += c.Blocked
+	}
+	if s.blocking[q.ID] {
+		score += c.Blocking
+	}
+	if len(q.Tags) > 0 {
+		score += c.Tags
+	}
+	// Коэффициент тега действует и на вложенные теги, но один раз на квест
+	counted := make(map[string]bool)
+	for _, tag := range q.Tags {
+		for _, t := range tags.Lineage(tag) {
+			if !counted[t] {
+				counted[t] = true
+				score += c.Tag[t]
+			}
+		}
+	}
+	return score
+}
+
+// Sort возвращает копию квестов, упорядоченную по убыванию срочности.
+// Квесты с равной срочностью сохраняют исходный порядок.
+func (s *Scorer) Sort(quests []player.Quest) []player.Quest {
+	sorted := make([]player.Quest, len(quests))
+	copy(sorted, quests)
+	scores := make(map[string]float64, len(sorted))
+	for _, q := range sorted {
+		scores[q.ID] = s.Score(q)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return scores[sorted[i].ID] > scores[sorted[j].ID]
+	})
+	return sorted
+}
+
+// dueFactor растет линейно от 0.2 за dueWindow дней до срока до 1.0
+// после overdueCap дней просрочки.
+func dueFactor(deadline, now time.Time) float64 {
+	days := now.Sub(deadline).Hours() / 24 // Положительное значение — просрочка
+	switch {
+	case days >= overdueCap:
+		return 1.0
+	case days <= -dueWindow:
+		return 0.2
+	}
+	return (days+dueWindow)*0.8/(dueWindow+overdueCap) + 0.2
+}
+
+// ageFactor растет линейно от 0 до 1 за ageMax дней.
+func ageFactor(created, now time.Time) float64 {
+	days := now.Sub(created).Hours() / 24
+	if days <= 0 {
+		return 0
+	}
+	if days >= ageMax {
+		return 1.0
+	}
+	return days / ageMax
+}
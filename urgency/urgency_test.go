@@ -0,0 +1,81 @@
+package urgency
+
+import (
+	"magus/player"
+	"math"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+func days(n int) *time.Time {
+	t := now.AddDate(0, 0, n)
+	return &t
+}
+
+func TestDueFactor(t *testing.T) {
+	tests := []struct {
+		deadline *time.Time
+		want     float64
+	}{
+		{days(30), 0.2},
+		{days(-10), 1.0},
+		{days(0), 14*0.8/21 + 0.2},
+	}
+	for _, tt := range tests {
+		if got := dueFactor(*tt.deadline, now); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("dueFactor(%v) = %v, want %v", tt.deadline, got, tt.want)
+		}
+	}
+}
+
+func TestScoreAndSort(t *testing.T) {
+	quests := []player.Quest{
+		{ID: "plain", CreatedAt: now},
+		{ID: "high", Priority: player.PriorityHigh, CreatedAt: now},
+		{ID: "overdue", Deadline: days(-7), CreatedAt: now},
+		{ID: "blocked", Priority: player.PriorityHigh, BlockedBy: []string{"plain"}, CreatedAt: now},
+		{ID: "done", Priority: player.PriorityHigh, Completed: true},
+	}
+	s := NewScorer(quests, now, Default())
+
+	// plain держит blocked, поэтому получает бонус за блокировку
+	if got := s.Score(quests[0]); got != 8.0 {
+		t.Errorf("Score(plain) = %v, want 8", got)
+	}
+	if got := s.Score(quests[3]); got != 1.0 {
+		t.Errorf("Score(blocked) = %v, want 1", got)
+	}
+	if got := s.Score(quests[4]); got != 0 {
+		t.Errorf("Score(done) = %v, want 0", got)
+	}
+
+	want := []string{"overdue", "plain", "high", "blocked", "done"}
+	for i, q := range s.Sort(quests) {
+		if q.ID != want[i] {
+			t.Fatalf("Sort()[%d] = %s, want %s", i, q.ID, want[i])
+		}
+	}
+	if quests[0].ID != "plain" {
+		t.Error("Sort() modified the input slice")
+	}
+}
+
+func TestWithOverrides(t *testing.T) {
+	c := WithOverrides(map[string]float64{"due": 20, "tag.work": 3, "unknown": 1})
+	if c.Due != 20 || c.Tag["work"] != 3 || c.Age != Default().Age {
+		t.Fatalf("WithOverrides() = %+v", c)
+	}
+
+	q := player.Quest{ID: "q", Tags: []string{"work", "home"}}
+	if got := NewScorer(nil, now, c).Score(q); got != c.Tags+3 {
+		t.Errorf("Score(tagged) = %v, want %v", got, c.Tags+3)
+	}
+
+	// Коэффициент родителя действует на вложенный тег один раз
+	nested := player.Quest{ID: "n", Tags: []string{"work/backend", "work"}}
+	if got := NewScorer(nil, now, c).Score(nested); got != c.Tags+3 {
+		t.Errorf("Score(nested) = %v, want %v", got, c.Tags+3)
+	}
+}
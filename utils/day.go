@@ -0,0 +1,24 @@
+package utils
+
+import "time"
+
+// DayStart возвращает начало "игрового" дня, которому принадлежит t.
+// dayStartHour сдвигает границу суток: при значении 4 день длится
+// с 04:00 до 04:00 следующего дня, и 02:00 еще относится ко вчерашнему дню.
+func DayStart(t time.Time, dayStartHour int) time.Time {
+	start := time.Date(t.Year(), t.Month(), t.Day(), dayStartHour, 0, 0, 0, t.Location())
+	if t.Before(start) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
+
+// DayEnd возвращает конец "игрового" дня, которому принадлежит t.
+func DayEnd(t time.Time, dayStartHour int) time.Time {
+	return DayStart(t, dayStartHour).AddDate(0, 0, 1)
+}
+
+// SameDay проверяет, что a и b приходятся на один "игровой" день.
+func SameDay(a, b time.Time, dayStartHour int) bool {
+	return DayStart(a, dayStartHour).Equal(DayStart(b.In(a.Location()), dayStartHour))
+}